@@ -0,0 +1,71 @@
+// Package agent serves a load-generating agent for `stroppy run --agents`.
+package agent
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/run"
+	"github.com/stroppy-io/stroppy/internal/agent"
+	"github.com/stroppy-io/stroppy/pkg/common/logger"
+)
+
+const (
+	listenFlag  = "listen"
+	tlsCertFlag = "tls-cert"
+	tlsKeyFlag  = "tls-key"
+)
+
+var Cmd = func() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent [--listen ADDR] [--tls-cert PATH --tls-key PATH]",
+		Short: "Serve as a load generator for a distributed run",
+		Long: `Agent listens on gRPC for a 'stroppy run --agents' coordinator and runs its
+share of the benchmark, one run at a time.
+
+The coordinator forwards its run arguments with this agent's part of the load
+appended: a share of the VUs, shared iterations, rates and stage targets, or a
+slice of the TPC-C warehouse range. The agent runs Setup, waits at the start
+barrier until every agent is ready, runs the workload and sends its metrics
+back; the coordinator prints the merged summary and evaluates thresholds.
+
+The agent runs only a registered workload with typed parameters, drivers and
+--latency-precision. It refuses config files, -e, SQL and spec
+files, answer dumps, result files and drivers writing local files (csv,
+sqlite); its own process environment still applies.
+
+Agents run only the workload step (--steps workload); any other step list is
+refused, since every agent would drop, create, load and audit the schema at
+once. Create and load it in a run without --agents beforehand.
+
+Without --tls-cert the protocol is plain-text gRPC and the agent listens on
+loopback only. To accept coordinators from other hosts, serve TLS with
+--tls-cert and --tls-key and set a shared secret in STROPPY_AGENT_TOKEN here
+and on the coordinator, which then connects over TLS (see --agent-ca of
+'stroppy run').
+
+SIGINT or SIGTERM stops the agent and cancels the run in progress.
+`,
+		Example: `  stroppy agent                        # listen on 127.0.0.1:7470
+  STROPPY_AGENT_TOKEN=secret stroppy agent --listen 10.0.0.5:7470 \
+    --tls-cert agent.pem --tls-key agent-key.pem
+  STROPPY_AGENT_TOKEN=secret stroppy run tpcc/tx --scale-factor 100 \
+    --executor constant-vus --vus 64 --duration 10m \
+    --steps workload --agents 10.0.0.5:7470,10.0.0.6:7470`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			addr, _ := cmd.Flags().GetString(listenFlag)
+			certFile, _ := cmd.Flags().GetString(tlsCertFlag)
+			keyFile, _ := cmd.Flags().GetString(tlsKeyFlag)
+			sec := agent.Security{CertFile: certFile, KeyFile: keyFile}.WithEnvToken()
+
+			return agent.Serve(cmd.Context(), addr, sec, run.ExecuteAgent, logger.Global().Named("agent"))
+		},
+	}
+
+	cmd.Flags().String(listenFlag, agent.DefaultAddr, "address to serve the agent protocol on")
+	cmd.Flags().String(tlsCertFlag, "", "TLS certificate; needs --tls-key and "+agent.TokenEnv)
+	cmd.Flags().String(tlsKeyFlag, "", "TLS private key of --tls-cert")
+
+	return cmd
+}()
@@ -0,0 +1,121 @@
+// Package diffanswers compares two query answer dumps written by
+// --dump-answers.
+package diffanswers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stroppy-io/stroppy/pkg/answers"
+)
+
+// ErrAnswersDiffer is returned when at least one query differs, so the
+// command exits non-zero and can gate CI.
+var ErrAnswersDiffer = errors.New("answers differ")
+
+const (
+	refFlag     = "ref"
+	testFlag    = "test"
+	absTolFlag  = "abs-tol"
+	relTolFlag  = "rel-tol"
+	verboseFlag = "verbose"
+)
+
+var Cmd = func() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff-answers --ref REF --test TEST",
+		Short: "Compare the query answers of two runs",
+		Long: `Diff-answers compares two answer dumps written by 'stroppy run tpch/tx' or
+'stroppy run tpcds' with --dump-answers PATH: typically a reference engine
+(PostgreSQL) and the engine under test, run at the same scale factor, seeds
+and streams. Any scale factor works, not only the SF1 answer sets.
+
+Each query's rows compare as a multiset, so engines that order ties or NULLs
+differently still match. Numeric cells match within --abs-tol, or within
+--rel-tol of the reference value; other cells must be equal. A query the
+reference failed is skipped; one only the tested engine failed is a diff.
+
+Run logs with the older __TPCDS_DUMP__ lines are read too.
+
+Exits 1 when any query differs.
+`,
+		Example: `  stroppy run tpcds -d pg --scale-factor 10 --dump-answers pg.ndjson
+  stroppy run tpcds -d mysql --scale-factor 10 --dump-answers mysql.ndjson
+  stroppy diff-answers --ref pg.ndjson --test mysql.ndjson -v`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			flags := cmd.Flags()
+			ref, _ := flags.GetString(refFlag)
+			test, _ := flags.GetString(testFlag)
+			absTol, _ := flags.GetFloat64(absTolFlag)
+			relTol, _ := flags.GetFloat64(relTolFlag)
+			verbose, _ := flags.GetBool(verboseFlag)
+
+			return diff(cmd.OutOrStdout(), ref, test, answers.Tolerance{Abs: absTol, Rel: relTol}, verbose)
+		},
+	}
+
+	cmd.Flags().String(refFlag, "", "reference answer dump, e.g. from PostgreSQL (required)")
+	cmd.Flags().String(testFlag, "", "answer dump to check against the reference (required)")
+	cmd.Flags().Float64(absTolFlag, answers.DefaultTolerance.Abs, "absolute tolerance for numeric cells")
+	cmd.Flags().Float64(relTolFlag, answers.DefaultTolerance.Rel, "relative tolerance for numeric cells")
+	cmd.Flags().BoolP(verboseFlag, "v", false, "list matching queries and the first mismatching cells")
+
+	_ = cmd.MarkFlagRequired(refFlag)
+	_ = cmd.MarkFlagRequired(testFlag)
+
+	return cmd
+}()
+
+func diff(out io.Writer, refPath, testPath string, tol answers.Tolerance, verbose bool) error {
+	ref, err := answers.Load(refPath)
+	if err != nil {
+		return fmt.Errorf("ref: %w", err)
+	}
+
+	test, err := answers.Load(testPath)
+	if err != nil {
+		return fmt.Errorf("test: %w", err)
+	}
+
+	counts := map[answers.Status]int{}
+
+	results := answers.Compare(ref, test, tol)
+	for _, r := range results {
+		counts[r.Status]++
+
+		if r.Status != answers.StatusOK || verbose {
+			fmt.Fprintln(out, resultLine(&r, verbose))
+		}
+	}
+
+	fmt.Fprintf(out, "===== diff-answers: ref=%s test=%s =====\n", refPath, testPath)
+	fmt.Fprintf(out, "total=%d  ok=%d  diff=%d  skipped=%d\n", len(results),
+		counts[answers.StatusOK], counts[answers.StatusDiff], counts[answers.StatusSkip])
+
+	if n := counts[answers.StatusDiff]; n > 0 {
+		return fmt.Errorf("%w: %d of %d queries", ErrAnswersDiffer, n, len(results))
+	}
+
+	return nil
+}
+
+func resultLine(r *answers.Result, verbose bool) string {
+	status := strings.ToUpper(string(r.Status))
+
+	if r.Detail != "" {
+		return fmt.Sprintf("  %-24s %-5s %s", r.Key, status, r.Detail)
+	}
+
+	line := fmt.Sprintf("  %-24s %-5s rows ref=%d test=%d", r.Key, status, r.RefRows, r.TestRows)
+	if verbose && len(r.Deltas) > 0 {
+		line += "  " + strings.Join(r.Deltas, "; ")
+	}
+
+	return line
+}
@@ -0,0 +1,52 @@
+package diffanswers
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/answers"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestDiff(t *testing.T) {
+	ref := writeFile(t, "ref.ndjson",
+		`{"query":"q1","rows":[["a","1.00"]]}`+"\n"+`{"query":"q2","rows":[["x"]]}`+"\n")
+	same := writeFile(t, "same.ndjson",
+		`{"query":"q1","rows":[["a","1"]]}`+"\n"+`{"query":"q2","rows":[["x"]]}`+"\n")
+	other := writeFile(t, "other.ndjson",
+		`{"query":"q1","rows":[["a","1"]]}`+"\n"+`{"query":"q2","rows":[["y"]]}`+"\n")
+
+	var out bytes.Buffer
+	if err := diff(&out, ref, same, answers.DefaultTolerance, false); err != nil {
+		t.Fatalf("matching dumps: %v\n%s", err, out.String())
+	}
+
+	if !strings.Contains(out.String(), "total=2  ok=2  diff=0") {
+		t.Fatalf("summary:\n%s", out.String())
+	}
+
+	out.Reset()
+
+	err := diff(&out, ref, other, answers.DefaultTolerance, true)
+	if !errors.Is(err, ErrAnswersDiffer) {
+		t.Fatalf("want ErrAnswersDiffer, got %v", err)
+	}
+
+	if !strings.Contains(out.String(), `ref="x" test="y"`) {
+		t.Fatalf("verbose output lacks the delta:\n%s", out.String())
+	}
+}
@@ -40,6 +40,7 @@ Example stroppy-config.json:
         "driverType": "postgres",
         "url": "postgres://user:pass@db:5432/bench",
         "insertProgress": { "interval": "30s", "stallAfter": "2m", "mode": "both" },
+        "interceptors": [ { "slowQuery": { "threshold": "250ms" } } ],
         "pool": { "maxConns": 200, "minConns": 200 }
       }
     },
@@ -64,11 +65,48 @@ Example stroppy-config.json:
     global   object            Logger and OTEL exporter config (no CLI equivalent)
     drivers  map[string]obj    Per-index driver configs (keys "0", "1", ...)
     run      object            Typed scenario params: executor, vus, iterations, duration,
-                              queryTimeout
+                              rate, startRate, stages, timeUnit, maxVus, queryTimeout,
+                              onError, errorBackoff, maxErrors, maxErrorRate, errorRateWindow,
+                              plus scenarios and sequential (see NAMED SCENARIOS)
     params   object            Typed parameters declared by the selected workload
     env      map[string]string Legacy workload env overrides (keys uppercased on load)
     steps    []string          Step allowlist (same as CLI --steps)
     noSteps  []string          Step blocklist (same as CLI --no-steps)
+    thresholds []string        Pass/fail gates (same as CLI --threshold; see
+                              'stroppy help thresholds')
+    summaryExport string       End-of-run summary file, .json or .csv (same as
+                              CLI --summary-export; see 'stroppy help summary-export')
+    metricsFile string         Per-interval NDJSON metrics file (same as CLI
+                              --metrics-file; see 'stroppy help metrics-file')
+    metricsListen string       Prometheus /metrics address, e.g. ":9464" (same as
+                              CLI --metrics-listen)
+    latencyPrecision string    Relative quantile error for millisecond trends,
+                              e.g. "1%" (same as CLI --latency-precision)
+
+  NAMED SCENARIOS
+
+    A "scenarios" list in "run" runs several scenarios in one process, for
+    example TPC-C OLTP next to a TPC-H reporting stream:
+
+      "run": {
+        "scenarios": [
+          { "name": "oltp", "executor": "constant-vus", "vus": 32, "duration": "30m" },
+          { "name": "reporting", "workload": "tpch/tx", "startTime": "5m",
+            "executor": "constant-vus", "vus": 1, "duration": "20m",
+            "params": { "scaleFactor": 1 } }
+        ]
+      }
+
+    Each scenario takes a required name, an optional workload (default: the
+    run's script), a startTime delay and its own params; every other key is a
+    run parameter. Run keys beside "scenarios" are shared defaults. Top-level
+    "params" and CLI workload flags reach only scenarios running the script's
+    workload; CLI run flags apply to all of them. Scenarios start together
+    unless "sequential": true runs them in list order, where startTime counts
+    from the end of the previous scenario. Every workload's Setup runs before
+    the first scenario starts, and the first failing scenario stops the rest.
+    All metrics carry a scenario tag, and the summary breaks each metric down
+    per scenario.
 
   OTLP METRICS
 
@@ -78,8 +116,17 @@ Example stroppy-config.json:
     "stroppy_". HTTP uses /v1/metrics unless otlpHttpExporterUrlPath overrides
     it. OTEL_METRIC_EXPORT_INTERVAL sets the export interval in milliseconds
     (default 10000). With no endpoint, metrics still appear in the local summary.
+    metricsListen serves the same metrics for a Prometheus scrape instead, and
+    metricsFile keeps them in a local NDJSON file; both work with or without
+    an OTLP endpoint.
+
+    Millisecond trends use fixed buckets from 0.1ms to 60s unless
+    latencyPrecision is set. Then they are OTel base-2 exponential histograms
+    sized so every quantile in the summary, thresholds and workload reports is
+    within that relative error. OTLP receives exponential histograms and
+    Prometheus native histograms, which the backend must accept.
 
-  Driver types: postgres, mysql, picodata, ydb, noop, csv
+  Driver types: postgres, mysql, picodata, ydb, sqlite, generic_sql, noop, csv
   Error modes:  silent, log, throw, fail, abort
   Insert methods: native, plain_bulk, plain_query (set per InsertSpec in code)
 
@@ -103,7 +150,10 @@ PRECEDENCE (highest to lowest)
     logger / OTEL exporter:      config file "global" only (no CLI equivalent)
 
   There is no "--" k6-args passthrough and no k6Args field in effect.
-  Use typed executor/vus/iterations/duration/queryTimeout parameters. The
+  Use typed executor/vus/iterations/duration/queryTimeout parameters;
+  ramping-vus adds stages, and arrival-rate executors add
+  rate/startRate/stages/timeUnit/maxVus, with stages as
+  [{"duration": "2m", "target": 100}, ...]. The
   VUS/DURATION/ITER/QUERY_TIMEOUT environment values remain compatible. A
   queryTimeout of "0" disables the per-statement deadline. Legacy DURATION
   without an explicit executor infers constant-vus and emits a warning; prefer
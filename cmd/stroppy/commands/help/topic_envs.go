@@ -14,11 +14,23 @@ func init() {
 
   Scenario parameters are shared by every workload:
 
-    --executor       shared-iterations or constant-vus
-    --vus            Number of virtual users
+    --executor       shared-iterations, constant-vus, ramping-vus,
+                     constant-arrival-rate or ramping-arrival-rate
+    --vus            Number of virtual users (starting count for ramping-vus,
+                     pre-allocated pool for arrival-rate)
     --iterations     Total shared iterations
     --duration       Run length as a Go duration, e.g. 60s or 10m
+    --rate           constant-arrival-rate: iterations started per --time-unit
+    --start-rate     ramping-arrival-rate: rate before the first stage
+    --stages         ramping executors: duration:target pairs, e.g. 2m:100,10m:5000
+    --time-unit      Period rates are counted over (default 1s)
+    --max-vus        Arrival-rate VU pool limit; 0 keeps it at --vus
     --query-timeout  Per-statement deadline as a Go duration; 0 disables it
+    --on-error       After a failed iteration: continue (default), backoff or abort
+    --error-backoff  First on-error backoff pause, doubled per consecutive error
+    --max-errors     Abort the scenario after this many failed iterations
+    --max-error-rate Abort when this percent of iterations fail in the window
+    --error-rate-window  Sliding window for --max-error-rate (default 1m)
 
   Select the executor explicitly. Examples:
 
@@ -28,6 +40,31 @@ func init() {
     # TPC-B fixed-iteration power run
     stroppy run tpcb/tx -d pg --executor shared-iterations --iterations 100
 
+    # Ramp to 50 VUs over 2 minutes, hold for 10, then ramp down
+    stroppy run tpcc/tx -d pg --executor ramping-vus --stages 2m:50,10m:50,1m:0
+
+    # Open model: start 5000 TPC-C transactions per second for 10 minutes
+    stroppy run tpcc/tx -d pg --executor constant-arrival-rate --rate 5000 \
+      --duration 10m --vus 64 --max-vus 512
+
+  The closed executors (shared-iterations, constant-vus) start the next
+  iteration only when the previous one finishes, so a stalled database slows
+  the load down with it. ramping-vus moves the VU count linearly through
+  --stages; VUs above the current target finish their iteration and park until
+  the target rises again. The arrival-rate executors start iterations on a
+  schedule; when no VU is idle they allocate another, up to --max-vus, and
+  count the start in dropped_iterations_total once the pool is exhausted.
+  iteration_lag_duration records how late each start ran.
+
+  A failed iteration is logged and the VU moves on to the next one; only a
+  fatal error stops the scenario. --on-error backoff pauses the failing VU,
+  and --on-error abort, --max-errors or --max-error-rate turn accumulated
+  errors into a failed run. vus_active reports the VUs running or ready to run:
+
+    # Stop a soak test once more than 5% of transactions fail over 30 seconds
+    stroppy run tpcc/tx -d pg --executor constant-vus --vus 64 --duration 8h \
+      --max-error-rate 5 --error-rate-window 30s
+
 SOURCES AND PRECEDENCE
 
   Every declared parameter has a direct flag, projected environment name, typed
@@ -0,0 +1,38 @@
+package help
+
+func init() {
+	Register(Topic{
+		Name:  "metrics-file",
+		Short: "Write metrics over time to a local NDJSON file",
+		Long: `METRICS FILE
+
+  --metrics-file PATH writes the run's metrics to a local file every export
+  interval, next to or instead of an OTLP collector. It is meant for labs
+  without a collector that still need throughput and latency over time, to
+  find warm-up, checkpoint or vacuum stalls after the run.
+
+    stroppy run tpcc/tx --metrics-file results/tpcc.ndjson
+    stroppy run tpcc/tx --metrics-file results/tpcc.ndjson.gz   # gzip
+
+  The config file takes the same path as a top-level "metricsFile" field; the
+  CLI flag replaces it. OTEL_METRIC_EXPORT_INTERVAL sets the interval in
+  milliseconds (default 10000). The file is flushed after every interval, so it
+  can be tailed during the run.
+
+FORMAT
+
+  One JSON object per line, per metric series, per interval:
+
+    {"time":"2026-10-17T09:00:10Z","run_id":"nightly-42",
+     "name":"tx_total_duration","type":"trend","tags":{"tx_name":"new_order"},
+     "count":5120,"sum":61440,"min":3.1,"max":480,"bounds":[...],
+     "buckets":[...],"quantiles":{"p50":10,"p90":25,"p95":50,"p99":250}}
+
+  Counters and trends hold only that interval's values: a counter's value is
+  the events in the interval, and a trend's quantiles cover the interval's
+  samples. Gauges hold their current value. A trend with no samples in an
+  interval writes no line. Durations are in milliseconds, and the fields match
+  the "metrics" entries of 'stroppy help summary-export'.
+`,
+	})
+}
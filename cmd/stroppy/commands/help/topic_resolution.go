@@ -18,6 +18,8 @@ INPUT MODES
                    stroppy run tpcb/tx
                    stroppy run tpch/tx
                    stroppy run tpcds
+                   stroppy run ycsb/a
+                   stroppy run sysbench/oltp_read_write
                    stroppy run simple
                    stroppy run execute_sql
 
@@ -43,8 +45,12 @@ PARAMETER DISCOVERY AND RESOLUTION
     stroppy run tpcc/tx --help
     stroppy run tpcc/tx --scale-factor 10 --load-workers 8
 
-  Shared run parameters are --executor, --vus, --iterations, --duration, and
-  --query-timeout. Select shared-iterations or constant-vus explicitly. Typed values
+  Shared run parameters are --executor, --vus, --iterations, --duration,
+  --rate, --start-rate, --stages, --time-unit, --max-vus, --query-timeout,
+  --on-error, --error-backoff, --max-errors, --max-error-rate, and
+  --error-rate-window.
+  Select shared-iterations, constant-vus, ramping-vus, constant-arrival-rate,
+  or ramping-arrival-rate explicitly. Typed values
   resolve in this order: CLI flag > process env > -e > matching "run"/"params" config >
   config "env" > declared default. Legacy DURATION can still infer constant-vus,
   but emits a warning; prefer an explicit executor.
@@ -84,6 +90,8 @@ EXAMPLES
   stroppy run tpcb/tx
   stroppy run tpch/tx        # TPC-H relational load + query suite
   stroppy run tpcds          # TPC-DS load + query suite
+  stroppy run ycsb/a         # YCSB core workload A (ycsb/a..ycsb/f)
+  stroppy run sysbench/oltp_read_only --tables 10   # sysbench OLTP scripts
 
   # TPC-C with picodata, local SQL file (no rebuild needed)
   stroppy run tpcc/tx ./workloads/tpcc/pico.sql -d pico -D url=http://...
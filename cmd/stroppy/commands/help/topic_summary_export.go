@@ -0,0 +1,51 @@
+package help
+
+func init() {
+	Register(Topic{
+		Name:  "summary-export",
+		Short: "Write the end-of-run summary as JSON or CSV",
+		Long: `SUMMARY EXPORT
+
+  --summary-export PATH writes the end-of-run summary to a file that tools can
+  load without scraping the terminal. The extension picks the format:
+
+    stroppy run tpcc/tx --summary-export results/tpcc.json
+    stroppy run tpcc/tx --summary-export results/tpcc.csv
+
+  The config file takes the same path as a top-level "summaryExport" field; the
+  CLI flag replaces it. An unknown extension is rejected before the run starts.
+  The file is written even when the run fails, with the error recorded in it.
+
+JSON
+
+  One object with:
+
+    run_id, workload          global.runId and the selected workload
+    started_at, finished_at   RFC 3339 timestamps in UTC
+    error                     the run error, when it failed
+    drivers                   index, type and URL (passwords masked)
+    scenarios                 name, workload, executor and every resolved
+                              parameter with its value and source (cli,
+                              process-env, legacy-env, config,
+                              legacy-config-env or default)
+    metrics                   one entry per series: name, type (counter,
+                              gauge or trend), tags, and either value or
+                              count, sum, min, max, histogram bounds and
+                              buckets, and p50/p90/p95/p99 quantiles
+    thresholds                expr, actual and passed for each threshold
+
+  Durations are in milliseconds. Quantiles are bucket upper bounds, as in the
+  printed summary. With --latency-precision, trend buckets are the exponential
+  histogram's, so bounds are spaced by that relative error.
+
+CSV
+
+  One row per metric series with the columns
+
+    run_id,metric,type,tags,value,count,sum,min,max,p50,p90,p95,p99,bounds,buckets
+
+  tags are k=v pairs, and bounds and buckets are lists, each joined with ';'.
+  Drivers, parameters and thresholds are only in the JSON form.
+`,
+	})
+}
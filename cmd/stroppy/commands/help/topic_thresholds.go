@@ -0,0 +1,67 @@
+package help
+
+func init() {
+	Register(Topic{
+		Name:  "thresholds",
+		Short: "Pass/fail gates over run metrics and the exit status they set",
+		Long: `THRESHOLDS
+
+  A threshold is a pass/fail check over the metrics a run collected. After the
+  bench summary, stroppy prints a table with every threshold and its actual
+  value. When the run itself succeeded but a threshold failed, stroppy exits
+  with status 99, so a CI job can gate on the exit code instead of log text.
+
+SYNTAX
+
+    metric{tag=value,...}.stat OP value
+
+  metric   A name from the bench summary, e.g. tx_total_duration or
+           iterations_total. A counter may drop its _total suffix.
+  {tags}   Optional. Only series carrying every listed tag count, e.g.
+           {tx_name=new_order} or {scenario=oltp}.
+  stat     Depends on the metric type:
+             trends     count, avg, min, max, med, p(N) (also pN, e.g. p95)
+             counters   count, or rate per second of scenario time
+             rates      rate, the fraction of non-zero samples
+             gauges     value
+  OP       <, <=, >, >=, ==, !=
+  value    A number, or a Go duration (50ms, 1.5s) compared in milliseconds,
+           the unit duration trends are recorded in.
+
+  The built-in rate errors records one sample per iteration, non-zero when the
+  iteration failed, so errors.rate is the failed fraction of iterations. An
+  iteration cut short by the end of its scenario does not count.
+
+  A threshold whose metric has no data fails. Percentiles come from the
+  histogram buckets, so p(N) reports the upper bound of the matching bucket.
+  The fixed duration buckets are up to 2.5x apart; gate on tail latencies with
+  --latency-precision (e.g. 1%) so p(99.9) is within that error of the truth.
+
+SETTING THRESHOLDS
+
+  Repeat --threshold on the CLI:
+
+    stroppy run tpcc/tx -d pg --executor constant-vus --vus 32 --duration 10m \
+      --threshold 'tx_total_duration{tx_name=new_order}.p95 < 50ms' \
+      --threshold 'iterations_total.rate > 1000' \
+      --threshold 'errors.rate < 0.01'
+
+  or list them in the config file:
+
+    {
+      "thresholds": [
+        "tx_total_duration{tx_name=new_order}.p95 < 50ms",
+        "tx_errors_total.count == 0"
+      ]
+    }
+
+  CLI --threshold flags replace the config file list.
+
+EXIT STATUS
+
+  0    the run succeeded and every threshold passed
+  99   the run succeeded but a threshold failed
+  1    the run failed; thresholds are still printed but do not change the code
+`,
+	})
+}
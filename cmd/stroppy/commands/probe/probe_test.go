@@ -35,7 +35,9 @@ func TestHumanCatalogIncludesGroupedWorkloads(t *testing.T) {
 		"PRESETS (embedded workloads)",
 		"WORKLOADS (typed parameters)",
 		"  tpcc/tx\n",
-		"    run:      --duration, --executor, --iterations, --query-timeout, --vus",
+		"    run:      --duration, --error-backoff, --error-rate-window, --executor, --iterations, " +
+			"--max-error-rate, --max-errors, --max-vus, --on-error, --query-timeout, --rate, --stages, " +
+			"--start-rate, --time-unit, --vus",
 		"    workload: --load-items",
 		"stroppy run <workload> --help",
 		"DRIVERS (supported insert methods)",
@@ -11,17 +11,24 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/agent"
+	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/diffanswers"
 	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/help"
 	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/probe"
 	"github.com/stroppy-io/stroppy/cmd/stroppy/commands/run"
 	"github.com/stroppy-io/stroppy/internal/version"
 	_ "github.com/stroppy-io/stroppy/internal/workloads"
+	"github.com/stroppy-io/stroppy/pkg/bench"
 	"github.com/stroppy-io/stroppy/pkg/common/shutdown"
 )
 
 // appName is the binary / command name.
 const appName = "stroppy"
 
+// exitThresholdsCrossed is the exit status of a run that completed but failed
+// at least one threshold, matching k6 so CI jobs can tell it from a crash.
+const exitThresholdsCrossed = 99
+
 var rootCmd = &cobra.Command{
 	Use:   appName,
 	Short: "Generate and run Go-native database stress tests",
@@ -94,8 +101,8 @@ func execute() int {
 }
 
 // exitCodeFor maps a command error to a process exit status. A graceful
-// cancellation uses the signal-derived code (130 SIGINT / 143 SIGTERM); any
-// other error uses 1.
+// cancellation uses the signal-derived code (130 SIGINT / 143 SIGTERM), a
+// crossed threshold uses 99, and any other error uses 1.
 func exitCodeFor(cancelCode int, err error) int {
 	if err == nil {
 		return 0
@@ -105,6 +112,10 @@ func exitCodeFor(cancelCode int, err error) int {
 		return cancelCode
 	}
 
+	if errors.Is(err, bench.ErrThresholdsCrossed) {
+		return exitThresholdsCrossed
+	}
+
 	return 1
 }
 
@@ -119,5 +130,5 @@ func init() {
 	rootCmd.SetVersionTemplate(`{{with .Name}}{{printf "%s " .}}{{end}}{{printf "%s" .Version}}`)
 
 	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "output versions as JSON")
-	rootCmd.AddCommand(versionCmd, run.Cmd, probe.Cmd, help.Cmd)
+	rootCmd.AddCommand(versionCmd, run.Cmd, agent.Cmd, probe.Cmd, diffanswers.Cmd, help.Cmd)
 }
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
 )
 
 func TestExitCodeFor(t *testing.T) {
@@ -21,6 +23,7 @@ func TestExitCodeFor(t *testing.T) {
 		{"canceled sigterm", 143, context.Canceled, 143},
 		{"wrapped canceled", 130, fmt.Errorf("run: %w", context.Canceled), 130},
 		{"other error", 130, errors.New("boom"), 1},
+		{"thresholds crossed", 130, fmt.Errorf("run: %w", bench.ErrThresholdsCrossed), 99},
 	}
 
 	for _, tc := range cases {
@@ -31,7 +31,7 @@ func TestMetricsConfig(t *testing.T) {
 		}},
 	}}
 
-	got := metricsConfig(config)
+	got := metricsConfig(config, nil)
 	require.Equal(t, grpcEndpoint, got.GRPCEndpoint)
 	require.Equal(t, httpEndpoint, got.HTTPEndpoint)
 	require.Equal(t, httpPath, got.HTTPPath)
@@ -45,7 +45,7 @@ func TestMetricsConfig(t *testing.T) {
 func TestMetricsConfigWithGlobalWithoutExporter(t *testing.T) {
 	t.Parallel()
 
-	got := metricsConfig(&stroppy.RunConfig{Global: &stroppy.GlobalConfig{RunId: "run-42"}})
+	got := metricsConfig(&stroppy.RunConfig{Global: &stroppy.GlobalConfig{RunId: "run-42"}}, nil)
 	require.Equal(t, "run-42", got.RunID)
 	require.Empty(t, got.GRPCEndpoint)
 	require.Empty(t, got.HTTPEndpoint)
@@ -54,7 +54,7 @@ func TestMetricsConfigWithGlobalWithoutExporter(t *testing.T) {
 func TestMetricsConfigWithoutFile(t *testing.T) {
 	t.Parallel()
 
-	got := metricsConfig(nil)
+	got := metricsConfig(nil, nil)
 	require.Empty(t, got.GRPCEndpoint)
 	require.Empty(t, got.HTTPEndpoint)
 }
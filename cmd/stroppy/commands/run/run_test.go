@@ -6,14 +6,24 @@ import (
 	"encoding/json"
 	"errors"
 	"maps"
+	"net"
 	"os"
+	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
+	"github.com/stroppy-io/stroppy/internal/agent"
 	"github.com/stroppy-io/stroppy/internal/runner"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/execute_sql"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/simple"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/sqlmix"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/tpcc"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/tpch"
 	"github.com/stroppy-io/stroppy/pkg/bench"
 	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
 	_ "github.com/stroppy-io/stroppy/pkg/driver/noop"
@@ -33,6 +43,12 @@ func TestParseRunArgs(t *testing.T) {
 		wantNoSteps   []string
 		wantAfterDash []string
 		wantTyped     map[string]string
+		wantThreshold []string
+		wantSummary   string
+		wantMetrics   string
+		wantListen    string
+		wantPrecision string
+		wantAgents    []string
 		wantHelp      bool
 		wantPresets   map[int]string
 		wantOpts      map[int][][2]string
@@ -100,6 +116,46 @@ func TestParseRunArgs(t *testing.T) {
 			wantPresets: map[int]string{0: "pg"},
 		},
 
+		{
+			name: "repeated threshold flags",
+			args: []string{
+				"tpcc", "--threshold", "tx_total_duration{tx_name=new_order}.p95 < 50ms",
+				"--threshold=iterations_total.rate > 1000",
+			},
+			wantScript:    "tpcc",
+			wantThreshold: []string{"tx_total_duration{tx_name=new_order}.p95 < 50ms", "iterations_total.rate > 1000"},
+		},
+		{
+			name:        "summary export flag",
+			args:        []string{"tpcc", "--summary-export", "a.csv", "--summary-export=out/summary.json"},
+			wantScript:  "tpcc",
+			wantSummary: "out/summary.json",
+		},
+		{
+			name:        "metrics file and listen flags",
+			args:        []string{"tpcc", "--metrics-file", "out/metrics.ndjson.gz", "--metrics-listen=:9464"},
+			wantScript:  "tpcc",
+			wantMetrics: "out/metrics.ndjson.gz",
+			wantListen:  ":9464",
+		},
+		{
+			name:          "latency precision flag",
+			args:          []string{"tpcc", "--latency-precision=0.1%"},
+			wantScript:    "tpcc",
+			wantPrecision: "0.1%",
+		},
+		{
+			name:       "repeated agents flags",
+			args:       []string{"tpcc", "--agents", "gen1:7470, gen2:7470", "--agents=127.0.0.1:7471"},
+			wantScript: "tpcc",
+			wantAgents: []string{"gen1:7470", "gen2:7470", "127.0.0.1:7471"},
+		},
+		{
+			name:       "threshold without value",
+			args:       []string{"tpcc", "--threshold"},
+			wantErrStr: "--threshold: flag requires a value",
+		},
+
 		// ── Missing script ─────────────────────────────────────────────────
 		{
 			name:    "empty args returns errNoScript",
@@ -523,6 +579,30 @@ func TestParseRunArgs(t *testing.T) {
 				t.Errorf("typedParams: got %v, want %v", got.typedParams, tt.wantTyped)
 			}
 
+			if !stringSliceEqual(got.thresholds, tt.wantThreshold) {
+				t.Errorf("thresholds: got %v, want %v", got.thresholds, tt.wantThreshold)
+			}
+
+			if got.summaryExport != tt.wantSummary {
+				t.Errorf("summaryExport: got %q, want %q", got.summaryExport, tt.wantSummary)
+			}
+
+			if got.metricsFile != tt.wantMetrics {
+				t.Errorf("metricsFile: got %q, want %q", got.metricsFile, tt.wantMetrics)
+			}
+
+			if got.metricsListen != tt.wantListen {
+				t.Errorf("metricsListen: got %q, want %q", got.metricsListen, tt.wantListen)
+			}
+
+			if got.precision != tt.wantPrecision {
+				t.Errorf("latencyPrecision: got %q, want %q", got.precision, tt.wantPrecision)
+			}
+
+			if !slices.Equal(got.agents, tt.wantAgents) {
+				t.Errorf("agents: got %v, want %v", got.agents, tt.wantAgents)
+			}
+
 			if got.help != tt.wantHelp {
 				t.Errorf("help: got %v, want %v", got.help, tt.wantHelp)
 			}
@@ -1198,6 +1278,175 @@ func presetMapsEqual(a, b map[int]string) bool {
 	return true
 }
 
+func TestAgentArgs(t *testing.T) {
+	t.Parallel()
+
+	got := agentArgs([]string{
+		"tpcc/tx", "--agents", "gen1:7470,gen2:7470", "--vus", "8", "--agents=gen3:7470", "-d", "pg",
+		"--threshold", "iterations_total.rate > 1", "--summary-export=out.json", "--agent-ca", "ca.pem",
+		"--metrics-file", "m.ndjson", "--metrics-listen=:9464",
+	})
+	if want := []string{"tpcc/tx", "--vus", "8", "-d", "pg"}; !slices.Equal(got, want) {
+		t.Fatalf("agentArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckAgentArgs(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"tpcc/tx", "--warehouses", "2", "--steps", "workload", "-d", "pg", "--latency-precision", "1%"},
+		{"simple", "--steps=workload", "-d", "generic", "-D", "url=mysql://root@tcp(db:3306)/stroppy"},
+	} {
+		parsed, err := parseRunArgs(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkAgentArgs(parsed); err != nil {
+			t.Errorf("checkAgentArgs(%v) error = %v", args, err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"tpcc/tx", "-f", "stroppy-config.json"},
+		{"tpcc/tx", "-e", "SQL_FILE=/etc/passwd"},
+		{"tpcc/tx", "--sql-file", "/etc/passwd"},
+		{"tpch/tx", "--dump-answers=/root/.bashrc"},
+		{"sqlmix", "--spec", "mix.json"},
+		{"tpcc/tx", "--metrics-file", "/tmp/m.ndjson"},
+		{"tpcc/tx", "--metrics-listen", ":9464"},
+		{"tpcc/tx", "--summary-export", "out.json"},
+		{"tpcc/tx", "--threshold", "iterations_total.count > 1"},
+		{"execute_sql", "queries.sql"},
+		{"queries.sql"},
+		{"select 1 "},
+	} {
+		parsed, err := parseRunArgs(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkAgentArgs(parsed); !errors.Is(err, errAgentArgument) {
+			t.Errorf("checkAgentArgs(%v) error = %v, want errAgentArgument", args, err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"tpcc/tx"},
+		{"tpcc/tx", "--steps", "workload,validate_consistency"},
+		{"tpcc/tx", "--steps", "create_schema,load_data"},
+		{"tpcc/tx", "--no-steps", "drop_schema"},
+	} {
+		parsed, err := parseRunArgs(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkAgentArgs(parsed); !errors.Is(err, errAgentSteps) {
+			t.Errorf("checkAgentArgs(%v) error = %v, want errAgentSteps", args, err)
+		}
+	}
+
+	for _, preset := range []string{"sqlite", "generic"} {
+		configs := runner.DriverCLIConfigs{}
+		if err := applyDriverPreset(configs, 0, preset); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := checkAgentDrivers(configs); !errors.Is(err, errAgentArgument) {
+			t.Errorf("checkAgentDrivers(%s) error = %v, want errAgentArgument", preset, err)
+		}
+	}
+
+	configs := runner.DriverCLIConfigs{}
+	if err := applyDriverOpt(configs, 0, "driverType", "csv"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkAgentDrivers(configs); !errors.Is(err, errAgentArgument) {
+		t.Errorf("checkAgentDrivers(csv) error = %v, want errAgentArgument", err)
+	}
+}
+
+func TestDistributedRunOnLocalAgent(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+
+	go func() { served <- agent.Serve(ctx, addr, agent.Security{}, ExecuteAgent, zap.NewNop()) }()
+
+	defer func() {
+		cancel()
+
+		if err := <-served; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	}()
+
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("agent did not listen: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	summaryPath := filepath.Join(t.TempDir(), "summary.json")
+	args := []string{
+		"simple", "-d", "noop", "--steps", "workload", "--vus", "2", "--iterations", "10", "--agents", addr,
+		"--summary-export", summaryPath, "--threshold", "iterations_total.count == 10",
+	}
+
+	if err := execute(ctx, Cmd, args, false); err != nil {
+		t.Fatalf("distributed run error = %v", err)
+	}
+
+	export, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(export, []byte(`"agent": "`+addr+`"`)) {
+		t.Fatalf("summary export lacks the agent tag:\n%s", export)
+	}
+
+	args = []string{
+		"simple", "-d", "noop", "--steps", "workload", "--agents", addr, "--threshold", "iterations_total.count > 1",
+	}
+	if err := execute(ctx, Cmd, args, false); !errors.Is(err, bench.ErrThresholdsCrossed) {
+		t.Fatalf("crossed threshold error = %v, want ErrThresholdsCrossed", err)
+	}
+
+	if err := ExecuteAgent(ctx, []string{"simple", "--agents", addr}); !errors.Is(err, errAgentsOnAgent) {
+		t.Fatalf("nested agents error = %v, want errAgentsOnAgent", err)
+	}
+
+	args = []string{"simple", "-d", "sqlite", "--steps", "workload", "--agents", addr}
+	if err := execute(ctx, Cmd, args, false); !errors.Is(err, errAgentArgument) {
+		t.Fatalf("file-writing driver error = %v, want errAgentArgument", err)
+	}
+
+	args = []string{"simple", "-d", "noop", "--agents", addr}
+	if err := execute(ctx, Cmd, args, false); !errors.Is(err, errAgentSteps) {
+		t.Fatalf("setup steps on agents error = %v, want errAgentSteps", err)
+	}
+}
+
 func TestApplyDriverPresetJSON(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,208 @@
+// Package agent runs one benchmark across several stroppy processes: a
+// `stroppy agent` executes its share of the load, and `stroppy run --agents`
+// coordinates them behind a common start barrier and merges their metrics.
+//
+// The coordinator opens one Run stream per agent:
+//
+//	coordinator → agent  start{args}   run these `stroppy run` arguments
+//	agent → coordinator  ready         every Setup succeeded; waiting
+//	coordinator → agent  go            sent once every agent is ready
+//	agent → coordinator  done{metrics, error}
+//
+// An agent that fails before it is ready answers done straight away; closing
+// the stream cancels the agent's run.
+//
+// Agents listen on loopback unless they serve TLS and require the shared token
+// of [Security]. The [Exec] decides which arguments a coordinator may send.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+// DefaultAddr is the address `stroppy agent` listens on by default.
+const DefaultAddr = "127.0.0.1:7470"
+
+const (
+	serviceName = "stroppy.agent.v1.AgentAPI"
+	runMethod   = "/" + serviceName + "/Run"
+)
+
+const (
+	msgStart = "start"
+	msgReady = "ready"
+	msgGo    = "go"
+	msgDone  = "done"
+)
+
+var (
+	errUnexpectedMessage = errors.New("unexpected agent message")
+	errAgentBusy         = errors.New("agent is already running a benchmark")
+)
+
+// message is one frame of a Run stream, in either direction.
+type message struct {
+	Type    string                `json:"type"`
+	Args    []string              `json:"args,omitempty"`    // start
+	Metrics *bench.MetricsPayload `json:"metrics,omitempty"` // done
+	Error   string                `json:"error,omitempty"`   // done
+}
+
+// jsonCodec carries messages as JSON: the protocol is small enough that it
+// does not need generated protobuf types.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return "json" }
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Run",
+		Handler:       runHandler,
+		ServerStreams: true,
+		ClientStreams: true,
+	}},
+}
+
+// Exec runs one benchmark from `stroppy run` arguments. It must honor the
+// bench.RunHooks on ctx.
+type Exec func(ctx context.Context, args []string) error
+
+// server executes the runs its coordinators send, one at a time.
+type server struct {
+	exec Exec
+	lg   *zap.Logger
+	busy sync.Mutex
+}
+
+func newGRPCServer(exec Exec, lg *zap.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(append(opts, grpc.ForceServerCodec(jsonCodec{}))...)
+	srv.RegisterService(&serviceDesc, &server{exec: exec, lg: lg})
+
+	return srv
+}
+
+// Serve runs an agent on addr until ctx is done; stopping cancels the run in
+// progress. A non-loopback addr needs sec to carry a certificate and a token.
+func Serve(ctx context.Context, addr string, sec Security, exec Exec, lg *zap.Logger) error {
+	opts, err := sec.serverOptions(addr)
+	if err != nil {
+		return err
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("agent listen %s: %w", addr, err)
+	}
+
+	srv := newGRPCServer(exec, lg, opts...)
+
+	stop := context.AfterFunc(ctx, srv.Stop)
+	defer stop()
+
+	lg.Info("agent listening", zap.String("addr", listener.Addr().String()))
+
+	if err := srv.Serve(listener); err != nil {
+		return fmt.Errorf("agent serve: %w", err)
+	}
+
+	return nil
+}
+
+func runHandler(srv any, stream grpc.ServerStream) error {
+	s, _ := srv.(*server)
+
+	return s.run(stream)
+}
+
+func (s *server) run(stream grpc.ServerStream) error {
+	var start message
+	if err := stream.RecvMsg(&start); err != nil {
+		return err
+	}
+
+	if start.Type != msgStart {
+		return status.Errorf(codes.InvalidArgument, "%v: %q, want %q", errUnexpectedMessage, start.Type, msgStart)
+	}
+
+	if !s.busy.TryLock() {
+		return status.Error(codes.ResourceExhausted, errAgentBusy.Error())
+	}
+	defer s.busy.Unlock()
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// The only message after start is go; anything else, or the coordinator
+	// going away, cancels the run.
+	released := make(chan struct{})
+
+	go func() {
+		var msg message
+		if err := stream.RecvMsg(&msg); err != nil || msg.Type != msgGo {
+			cancel()
+
+			return
+		}
+
+		close(released)
+
+		// Wait for the stream to end so a vanished coordinator still cancels.
+		if err := stream.RecvMsg(&msg); err != nil {
+			cancel()
+		}
+	}()
+
+	var payload *bench.MetricsPayload
+
+	hooks := bench.RunHooks{
+		Ready: func(ctx context.Context) error {
+			if err := stream.SendMsg(&message{Type: msgReady}); err != nil {
+				return err
+			}
+
+			select {
+			case <-released:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+		Collected: func(data bench.MetricsPayload) { payload = &data },
+	}
+
+	s.lg.Info("run started", zap.Strings("args", start.Args))
+
+	done := message{Type: msgDone}
+	if err := s.exec(bench.WithRunHooks(ctx, hooks), start.Args); err != nil {
+		done.Error = err.Error()
+		s.lg.Warn("run failed", zap.Error(err))
+	} else {
+		s.lg.Info("run finished")
+	}
+
+	done.Metrics = payload
+
+	if err := stream.SendMsg(&done); err != nil {
+		return fmt.Errorf("send result: %w", err)
+	}
+
+	return nil
+}
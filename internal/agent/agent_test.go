@@ -0,0 +1,159 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+func startAgent(t *testing.T, exec Exec, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := newGRPCServer(exec, zap.NewNop(), opts...)
+	go func() { _ = srv.Serve(listener) }()
+
+	t.Cleanup(srv.Stop)
+
+	return listener.Addr().String()
+}
+
+// barrierLog records when fake agents became ready and when they were released.
+type barrierLog struct {
+	mu       sync.Mutex
+	ready    []time.Time
+	released []time.Time
+	args     map[string][]string
+}
+
+func (l *barrierLog) exec(name string, setup time.Duration) Exec {
+	return func(ctx context.Context, args []string) error {
+		l.mu.Lock()
+		l.args[name] = args
+		l.mu.Unlock()
+
+		time.Sleep(setup)
+
+		hooks := bench.RunHooksFrom(ctx)
+
+		l.mu.Lock()
+		l.ready = append(l.ready, time.Now())
+		l.mu.Unlock()
+
+		if err := hooks.Ready(ctx); err != nil {
+			return err
+		}
+
+		l.mu.Lock()
+		l.released = append(l.released, time.Now())
+		l.mu.Unlock()
+
+		hooks.Collected(bench.MetricsPayload{Metrics: []bench.PayloadMetric{{
+			Name: "iterations_total", Kind: "counter", Cumulative: true,
+			Points: []bench.PayloadPoint{{Value: float64(len(args))}},
+		}}})
+
+		return nil
+	}
+}
+
+func TestCoordinateReleasesAgentsTogether(t *testing.T) {
+	t.Parallel()
+
+	log := &barrierLog{args: map[string][]string{}}
+	jobs := []Job{
+		{Addr: startAgent(t, log.exec("a", 0)), Args: []string{"simple", "--vus=1"}},
+		{Addr: startAgent(t, log.exec("b", 100*time.Millisecond)), Args: []string{"simple", "--vus=2", "-d", "noop"}},
+		{Addr: startAgent(t, log.exec("c", 0)), Args: []string{"simple"}},
+	}
+
+	outcome, err := Coordinate(context.Background(), jobs, Security{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if outcome.Started.IsZero() || outcome.Elapsed < 0 {
+		t.Fatalf("outcome = %+v", outcome)
+	}
+
+	if !slices.Equal(log.args["b"], jobs[1].Args) {
+		t.Fatalf("agent b ran %v, want %v", log.args["b"], jobs[1].Args)
+	}
+
+	lastReady := slices.MaxFunc(log.ready, time.Time.Compare)
+	for _, released := range log.released {
+		if released.Before(lastReady) {
+			t.Fatalf("an agent was released at %v, before the last one was ready at %v", released, lastReady)
+		}
+	}
+
+	for i, result := range outcome.Results {
+		if result.Addr != jobs[i].Addr || result.Err != nil ||
+			result.Metrics.Metrics[0].Points[0].Value != float64(len(jobs[i].Args)) {
+			t.Fatalf("result %d = %+v", i, result)
+		}
+	}
+}
+
+func TestCoordinateAbortsOnSetupFailure(t *testing.T) {
+	t.Parallel()
+
+	canceled := make(chan error, 1)
+	waiting := func(ctx context.Context, _ []string) error {
+		err := bench.RunHooksFrom(ctx).Ready(ctx)
+		canceled <- err
+
+		return err
+	}
+	failing := func(context.Context, []string) error {
+		return errors.New("create_schema: relation exists")
+	}
+
+	_, err := Coordinate(context.Background(), []Job{
+		{Addr: startAgent(t, waiting)},
+		{Addr: startAgent(t, failing)},
+	}, Security{})
+	if err == nil || !strings.Contains(err.Error(), "create_schema: relation exists") {
+		t.Fatalf("Coordinate() error = %v", err)
+	}
+
+	select {
+	case err := <-canceled:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("waiting agent Ready() = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting agent was not canceled")
+	}
+}
+
+func TestCoordinateReportsUnreachableAgent(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	outcome, err := Coordinate(context.Background(), []Job{{Addr: addr}}, Security{})
+	if err == nil || !strings.Contains(err.Error(), addr) || !outcome.Started.IsZero() {
+		t.Fatalf("Coordinate() = %+v, %v", outcome, err)
+	}
+}
@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+var errNotReady = errors.New("finished before the start barrier")
+
+// Job is one agent's share of a distributed run.
+type Job struct {
+	Addr string
+	Args []string // `stroppy run` arguments, split overrides included
+}
+
+// Result is what one agent reported back.
+type Result struct {
+	Addr    string
+	Metrics bench.MetricsPayload
+	Err     error
+}
+
+// Outcome is a coordinated run. Started is zero when the agents never passed
+// the start barrier.
+type Outcome struct {
+	Started time.Time
+	Elapsed time.Duration
+	Results []Result
+}
+
+// remoteError is an agent's run error; it crosses the wire as text.
+type remoteError string
+
+func (e remoteError) Error() string { return string(e) }
+
+type event struct {
+	agent int
+	msg   message
+	err   error
+}
+
+// Coordinate runs every job on its agent and releases them together once all
+// of them finished Setup. It returns when every agent is done; the error joins
+// the agents' failures. A failure before the barrier cancels every agent.
+func Coordinate(ctx context.Context, jobs []Job, sec Security) (Outcome, error) {
+	dialOpts, err := sec.dialOptions()
+	if err != nil {
+		return Outcome{}, err
+	}
+
+	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{})))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outcome := Outcome{Results: make([]Result, len(jobs))}
+	streams := make([]grpc.ClientStream, len(jobs))
+
+	for i, job := range jobs {
+		outcome.Results[i].Addr = job.Addr
+
+		conn, err := grpc.NewClient(job.Addr, dialOpts...)
+		if err != nil {
+			return outcome, fmt.Errorf("agent %s: %w", job.Addr, err)
+		}
+		defer conn.Close()
+
+		stream, err := conn.NewStream(ctx, &serviceDesc.Streams[0], runMethod)
+		if err != nil {
+			return outcome, fmt.Errorf("agent %s: %w", job.Addr, err)
+		}
+
+		if err := stream.SendMsg(&message{Type: msgStart, Args: job.Args}); err != nil {
+			return outcome, fmt.Errorf("agent %s: %w", job.Addr, err)
+		}
+
+		streams[i] = stream
+	}
+
+	// Every agent sends at most ready and done, so the buffer never blocks a
+	// receiver left behind by an early return.
+	events := make(chan event, 2*len(jobs))
+
+	for i, stream := range streams {
+		go func() {
+			for {
+				var msg message
+
+				err := stream.RecvMsg(&msg)
+				events <- event{agent: i, msg: msg, err: err}
+
+				if err != nil || msg.Type == msgDone {
+					return
+				}
+			}
+		}()
+	}
+
+	for ready := 0; ready < len(jobs); ready++ {
+		ev := <-events
+		if ev.err == nil && ev.msg.Type == msgReady {
+			continue
+		}
+
+		return outcome, errors.Join(ctx.Err(), fmt.Errorf("agent %s: %w", jobs[ev.agent].Addr, eventError(ev)))
+	}
+
+	outcome.Started = time.Now()
+
+	for i, stream := range streams {
+		if err := stream.SendMsg(&message{Type: msgGo}); err != nil {
+			return outcome, fmt.Errorf("agent %s: %w", jobs[i].Addr, err)
+		}
+	}
+
+	var errs []error
+
+	for range jobs {
+		ev := <-events
+		result := &outcome.Results[ev.agent]
+
+		if ev.err == nil && ev.msg.Metrics != nil {
+			result.Metrics = *ev.msg.Metrics
+		}
+
+		if ev.err != nil || ev.msg.Error != "" {
+			result.Err = eventError(ev)
+			errs = append(errs, fmt.Errorf("agent %s: %w", result.Addr, result.Err))
+		}
+	}
+
+	outcome.Elapsed = time.Since(outcome.Started)
+
+	return outcome, errors.Join(append([]error{ctx.Err()}, errs...)...)
+}
+
+func eventError(ev event) error {
+	switch {
+	case ev.err != nil:
+		return ev.err
+	case ev.msg.Error != "":
+		return remoteError(ev.msg.Error)
+	case ev.msg.Type == msgDone:
+		return errNotReady
+	default:
+		return fmt.Errorf("%w: %q", errUnexpectedMessage, ev.msg.Type)
+	}
+}
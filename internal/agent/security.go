@@ -0,0 +1,155 @@
+package agent
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenEnv is the environment variable both sides read the shared token from.
+const TokenEnv = "STROPPY_AGENT_TOKEN"
+
+const authorizationKey = "authorization"
+
+var (
+	errInsecureListen = errors.New("a non-loopback agent address needs TLS and a token")
+	errTokenNeedsTLS  = errors.New("an agent token needs TLS")
+	errTLSNeedsToken  = errors.New("agent TLS needs a token")
+	errIncompleteTLS  = errors.New("agent TLS needs both a certificate and a key")
+	errNoCACerts      = errors.New("no certificates in CA file")
+)
+
+// Security is how a coordinator and its agents trust each other. Plain-text
+// agents only listen on loopback; anything reachable from other hosts serves
+// TLS and accepts only coordinators presenting the shared token.
+type Security struct {
+	CertFile string // agent: TLS certificate
+	KeyFile  string // agent: TLS private key
+	CAFile   string // coordinator: CA of the agents' certificates, system roots when empty
+	Token    string // shared secret; the coordinator's presence of one turns on TLS
+}
+
+// WithEnvToken returns s with Token read from TokenEnv.
+func (s Security) WithEnvToken() Security {
+	s.Token = os.Getenv(TokenEnv)
+
+	return s
+}
+
+// serverOptions validates the agent side of s for addr and returns the gRPC
+// options that enforce it.
+func (s Security) serverOptions(addr string) ([]grpc.ServerOption, error) {
+	if (s.CertFile == "") != (s.KeyFile == "") {
+		return nil, errIncompleteTLS
+	}
+
+	if s.CertFile == "" {
+		if s.Token != "" {
+			return nil, errTokenNeedsTLS
+		}
+
+		if !isLoopback(addr) {
+			return nil, fmt.Errorf("%w: %s", errInsecureListen, addr)
+		}
+
+		return nil, nil
+	}
+
+	if s.Token == "" {
+		return nil, errTLSNeedsToken
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("agent TLS: %w", err)
+	}
+
+	creds := credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS13})
+
+	return []grpc.ServerOption{grpc.Creds(creds), grpc.StreamInterceptor(tokenInterceptor(s.Token))}, nil
+}
+
+// dialOptions returns the coordinator's transport for s: TLS with the token
+// when there is one, plain text to loopback agents otherwise.
+func (s Security) dialOptions() ([]grpc.DialOption, error) {
+	if s.Token == "" {
+		if s.CAFile != "" {
+			return nil, errTLSNeedsToken
+		}
+
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS13}
+
+	if s.CAFile != "" {
+		pem, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("agent CA: %w", err)
+		}
+
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errNoCACerts, s.CAFile)
+		}
+	}
+
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
+		grpc.WithPerRPCCredentials(tokenCredentials(s.Token)),
+	}, nil
+}
+
+// isLoopback reports whether addr only accepts local connections; an empty
+// host listens on every interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+func tokenInterceptor(token string) grpc.StreamServerInterceptor {
+	want := []byte("Bearer " + token)
+
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+
+		got := strings.Join(md.Get(authorizationKey), "")
+		if subtle.ConstantTimeCompare([]byte(got), want) != 1 {
+			return status.Error(codes.Unauthenticated, "invalid agent token")
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// tokenCredentials sends the shared token with every call, over TLS only.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationKey: "Bearer " + string(t)}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool { return true }
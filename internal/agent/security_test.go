@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+// writeCert writes a self-signed certificate for 127.0.0.1 and its key to dir.
+func writeCert(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "stroppy agent"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "agent.pem"), filepath.Join(dir, "agent-key.pem")
+
+	for path, block := range map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return certFile, keyFile
+}
+
+func TestServerSecurityRequirements(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeCert(t, t.TempDir())
+	tlsOnly := Security{CertFile: certFile, KeyFile: keyFile}
+	full := Security{CertFile: certFile, KeyFile: keyFile, Token: "secret"}
+
+	for _, tc := range []struct {
+		name string
+		addr string
+		sec  Security
+		want error
+	}{
+		{name: "loopback", addr: DefaultAddr},
+		{name: "localhost", addr: "localhost:7470"},
+		{name: "all interfaces", addr: ":7470", want: errInsecureListen},
+		{name: "remote", addr: "10.0.0.5:7470", want: errInsecureListen},
+		{name: "token without TLS", addr: DefaultAddr, sec: Security{Token: "secret"}, want: errTokenNeedsTLS},
+		{name: "TLS without token", addr: ":7470", sec: tlsOnly, want: errTLSNeedsToken},
+		{name: "cert without key", addr: ":7470", sec: Security{CertFile: certFile, Token: "secret"}, want: errIncompleteTLS},
+		{name: "TLS", addr: ":7470", sec: full},
+	} {
+		if _, err := tc.sec.serverOptions(tc.addr); !errors.Is(err, tc.want) {
+			t.Errorf("%s: serverOptions() error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	if _, err := (Security{CAFile: certFile}).dialOptions(); !errors.Is(err, errTLSNeedsToken) {
+		t.Errorf("dialOptions() with a CA and no token error = %v, want errTLSNeedsToken", err)
+	}
+}
+
+func TestCoordinateOverTLS(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeCert(t, t.TempDir())
+
+	opts, err := Security{CertFile: certFile, KeyFile: keyFile, Token: "secret"}.serverOptions(":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := startAgent(t, func(ctx context.Context, _ []string) error {
+		return bench.RunHooksFrom(ctx).Ready(ctx)
+	}, opts...)
+
+	jobs := []Job{{Addr: addr}}
+
+	if _, err := Coordinate(context.Background(), jobs, Security{CAFile: certFile, Token: "secret"}); err != nil {
+		t.Fatalf("Coordinate() with the token error = %v", err)
+	}
+
+	_, err = Coordinate(context.Background(), jobs, Security{CAFile: certFile, Token: "guess"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Coordinate() with a wrong token error = %v, want Unauthenticated", err)
+	}
+
+	if _, err := Coordinate(context.Background(), jobs, Security{}); err == nil {
+		t.Fatal("Coordinate() in plain text to a TLS agent succeeded")
+	}
+}
@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+var errTooFewToSplit = errors.New("too small to split across agents")
+
+// Split divides one run's load across n agents and returns, per agent, the
+// parameter overrides its run gets on top of the coordinator's arguments.
+// values are the run's resolved parameters (bench.ResolveParams).
+//
+// VUs, max-vus, shared iterations, rates and stage targets are divided, the
+// remainder going to the first agents. A workload with warehouse-start (TPC-C)
+// has its warehouse range cut into contiguous slices for its terminals; one
+// with insert-shards (sysbench) gives every agent its own share of new row ids.
+// Agents only run the workload step, so nothing here concerns loading.
+func Split(values map[string]any, n int) ([]map[string]string, error) {
+	overrides := make([]map[string]string, n)
+	for i := range overrides {
+		overrides[i] = map[string]string{}
+	}
+
+	if err := splitCount(overrides, "vus", values["vus"], 1); err != nil {
+		return nil, err
+	}
+
+	if maxVUs, _ := values["max-vus"].(int); maxVUs > 0 {
+		if err := splitCount(overrides, "max-vus", maxVUs, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if executor, _ := values["executor"].(string); executor == "shared-iterations" {
+		if err := splitCount(overrides, "iterations", values["iterations"], 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if rate, _ := values["rate"].(int); rate > 0 {
+		if err := splitCount(overrides, "rate", rate, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if startRate, _ := values["start-rate"].(int); startRate > 0 {
+		if err := splitCount(overrides, "start-rate", startRate, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if stages, _ := values["stages"].([]bench.Stage); len(stages) > 0 {
+		splitStages(overrides, stages)
+	}
+
+	if start, ok := values["warehouse-start"].(int); ok {
+		if err := splitWarehouses(overrides, start, values["scale-factor"]); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := values["insert-shards"].(int); ok {
+		splitInsertShards(overrides)
+	}
+
+	return overrides, nil
+}
+
+// share returns agent i's part of total split n ways.
+func share(total int64, n, i int) int64 {
+	part := total / int64(n)
+	if int64(i) < total%int64(n) {
+		part++
+	}
+
+	return part
+}
+
+// splitCount divides an int or int64 parameter; every agent must get at least
+// least.
+func splitCount(overrides []map[string]string, name string, value any, least int64) error {
+	var total int64
+
+	switch typed := value.(type) {
+	case int:
+		total = int64(typed)
+	case int64:
+		total = typed
+	default:
+		return nil
+	}
+
+	if total < least*int64(len(overrides)) {
+		return fmt.Errorf("%w: %s %d over %d agents", errTooFewToSplit, name, total, len(overrides))
+	}
+
+	for i := range overrides {
+		overrides[i][name] = strconv.FormatInt(share(total, len(overrides), i), 10)
+	}
+
+	return nil
+}
+
+func splitStages(overrides []map[string]string, stages []bench.Stage) {
+	for i := range overrides {
+		parts := make([]string, len(stages))
+		for j, stage := range stages {
+			target := share(int64(stage.Target), len(overrides), i)
+			parts[j] = stage.Duration.String() + ":" + strconv.FormatInt(target, 10)
+		}
+
+		overrides[i]["stages"] = strings.Join(parts, ",")
+	}
+}
+
+func splitWarehouses(overrides []map[string]string, start int, scale any) error {
+	warehouses, _ := scale.(int)
+	if warehouses < len(overrides) {
+		return fmt.Errorf("%w: scale-factor %d over %d agents", errTooFewToSplit, warehouses, len(overrides))
+	}
+
+	first := int64(start)
+
+	for i := range overrides {
+		count := share(int64(warehouses), len(overrides), i)
+		overrides[i]["warehouse-start"] = strconv.FormatInt(first, 10)
+		overrides[i]["scale-factor"] = strconv.FormatInt(count, 10)
+		first += count
+	}
+
+	return nil
+}
+
+// splitInsertShards numbers the agents as insert shards, so each inserts only
+// ids of its own residue modulo the agent count.
+func splitInsertShards(overrides []map[string]string) {
+	for i := range overrides {
+		overrides[i]["insert-shard"] = strconv.Itoa(i)
+		overrides[i]["insert-shards"] = strconv.Itoa(len(overrides))
+	}
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+func TestSplitDividesLoad(t *testing.T) {
+	t.Parallel()
+
+	got, err := Split(map[string]any{
+		"executor": "shared-iterations", "vus": 10, "iterations": int64(1001), "max-vus": 0,
+		"rate": 0, "start-rate": 0, "stages": []bench.Stage(nil),
+	}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"vus": "4", "iterations": "334"},
+		{"vus": "3", "iterations": "334"},
+		{"vus": "3", "iterations": "333"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Split() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitArrivalRateAndStages(t *testing.T) {
+	t.Parallel()
+
+	got, err := Split(map[string]any{
+		"executor": "ramping-arrival-rate", "vus": 4, "iterations": int64(1), "max-vus": 9,
+		"rate": 0, "start-rate": 1,
+		"stages": []bench.Stage{{Duration: time.Minute, Target: 5}, {Duration: 30 * time.Second, Target: 0}},
+	}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"vus": "2", "max-vus": "5", "start-rate": "1", "stages": "1m0s:3,30s:0"},
+		{"vus": "2", "max-vus": "4", "start-rate": "0", "stages": "1m0s:2,30s:0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Split() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitWarehouseRange(t *testing.T) {
+	t.Parallel()
+
+	got, err := Split(map[string]any{
+		"executor": "constant-vus", "vus": 2, "warehouse-start": 11, "scale-factor": 5, "load-items": false,
+	}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"vus": "1", "warehouse-start": "11", "scale-factor": "3"},
+		{"vus": "1", "warehouse-start": "14", "scale-factor": "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Split() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitInsertShards(t *testing.T) {
+	t.Parallel()
+
+	got, err := Split(map[string]any{"vus": 2, "insert-shard": 0, "insert-shards": 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []map[string]string{
+		{"vus": "1", "insert-shard": "0", "insert-shards": "2"},
+		{"vus": "1", "insert-shard": "1", "insert-shards": "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Split() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitRejectsTooLittleLoad(t *testing.T) {
+	t.Parallel()
+
+	for name, values := range map[string]map[string]any{
+		"vus":        {"vus": 1},
+		"iterations": {"vus": 2, "executor": "shared-iterations", "iterations": int64(1)},
+		"warehouses": {"vus": 2, "warehouse-start": 1, "scale-factor": 1},
+	} {
+		if _, err := Split(values, 2); !errors.Is(err, errTooFewToSplit) {
+			t.Errorf("%s: Split() error = %v, want errTooFewToSplit", name, err)
+		}
+	}
+}
@@ -25,13 +25,23 @@ var (
 	errDuplicateConfigField = errors.New("duplicate JSON field")
 	errConfigEnvCollision   = errors.New("config env keys collide case-insensitively")
 	errTrailingConfigData   = errors.New("trailing JSON data")
+	errThresholdsNotStrings = errors.New(`config field "thresholds" must be a list of strings`)
+	errConfigFieldNotString = errors.New("config field must be a string")
 )
 
-// LoadedConfig keeps the frozen run config separate from typed parameter scopes.
+// LoadedConfig keeps the frozen run config separate from typed parameter scopes
+// and the run's local result sinks: threshold expressions, the summary export
+// path, the time-series metrics file, the Prometheus listen address and the
+// latency histogram precision.
 type LoadedConfig struct {
-	RunConfig *stroppy.RunConfig
-	Run       map[string]json.RawMessage
-	Params    map[string]json.RawMessage
+	RunConfig     *stroppy.RunConfig
+	Run           map[string]json.RawMessage
+	Params        map[string]json.RawMessage
+	Thresholds    []string
+	SummaryExport string
+	MetricsFile   string
+	MetricsListen string
+	Precision     string // "latencyPrecision", a raw relative error such as "1%"
 }
 
 // LoadRunConfig loads a RunConfig from a JSON file.
@@ -69,6 +79,31 @@ func LoadRunConfig(path string) (*LoadedConfig, bool, error) {
 		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
 	}
 
+	thresholds, err := takeThresholds(fields)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	summaryExport, err := takeString(fields, "summaryExport")
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	metricsFile, err := takeString(fields, "metricsFile")
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	metricsListen, err := takeString(fields, "metricsListen")
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
+	latencyPrecision, err := takeString(fields, "latencyPrecision")
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
+	}
+
 	protoData, err := json.Marshal(fields)
 	if err != nil {
 		return nil, false, fmt.Errorf("parsing config file %q: %w", path, err)
@@ -108,7 +143,16 @@ func LoadRunConfig(path string) (*LoadedConfig, bool, error) {
 		)
 	}
 
-	return &LoadedConfig{RunConfig: cfg, Run: runParams, Params: workloadParams}, true, nil
+	return &LoadedConfig{
+		RunConfig:     cfg,
+		Run:           runParams,
+		Params:        workloadParams,
+		Thresholds:    thresholds,
+		SummaryExport: summaryExport,
+		MetricsFile:   metricsFile,
+		MetricsListen: metricsListen,
+		Precision:     latencyPrecision,
+	}, true, nil
 }
 
 func normalizeRunConfigEnv(config *stroppy.RunConfig) error {
@@ -157,6 +201,40 @@ func takeParamScope(fields map[string]json.RawMessage, name string) (map[string]
 	return scope, nil
 }
 
+func takeThresholds(fields map[string]json.RawMessage) ([]string, error) {
+	raw, ok := fields["thresholds"]
+	if !ok {
+		return nil, nil
+	}
+
+	delete(fields, "thresholds")
+
+	var thresholds []string
+	if err := json.Unmarshal(raw, &thresholds); err != nil {
+		return nil, errThresholdsNotStrings
+	}
+
+	return thresholds, nil
+}
+
+// takeString removes a top-level string field the RunConfig proto does not
+// carry.
+func takeString(fields map[string]json.RawMessage, name string) (string, error) {
+	raw, ok := fields[name]
+	if !ok {
+		return "", nil
+	}
+
+	delete(fields, name)
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("%w: %q", errConfigFieldNotString, name)
+	}
+
+	return value, nil
+}
+
 func decodeRawObject(data []byte) (map[string]json.RawMessage, error) {
 	decoder := json.NewDecoder(bytes.NewReader(data))
 
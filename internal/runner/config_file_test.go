@@ -146,6 +146,39 @@ func TestLoadRunConfig_TypedParameterScopes(t *testing.T) {
 	assert.JSONEq(t, `"sample"`, string(cfg.Params["label"]))
 }
 
+func TestLoadRunConfig_Thresholds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"version": "1",
+		"thresholds": ["iterations_total.rate > 1000", "tx_total_duration{tx_name=new_order}.p95 < 50ms"]
+	}`), 0o600))
+
+	cfg, _, err := runner.LoadRunConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"iterations_total.rate > 1000",
+		"tx_total_duration{tx_name=new_order}.p95 < 50ms",
+	}, cfg.Thresholds)
+}
+
+func TestLoadRunConfig_ResultFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"version": "1",
+		"summaryExport": "out/summary.csv",
+		"metricsFile": "out/metrics.ndjson.gz",
+		"metricsListen": ":9464",
+		"latencyPrecision": "0.5%"
+	}`), 0o600))
+
+	cfg, _, err := runner.LoadRunConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "out/summary.csv", cfg.SummaryExport)
+	assert.Equal(t, "out/metrics.ndjson.gz", cfg.MetricsFile)
+	assert.Equal(t, ":9464", cfg.MetricsListen)
+	assert.Equal(t, "0.5%", cfg.Precision)
+}
+
 func TestLoadRunConfig_RejectsInvalidParameterScopes(t *testing.T) {
 	tests := map[string]string{
 		"null run":            `{"run":null}`,
@@ -153,6 +186,9 @@ func TestLoadRunConfig_RejectsInvalidParameterScopes(t *testing.T) {
 		"duplicate run":       `{"run":{},"run":{}}`,
 		"duplicate run field": `{"run":{"vus":1,"vus":2}}`,
 		"unknown top level":   `{"unknown":{}}`,
+		"threshold object":    `{"thresholds":{"a":"b"}}`,
+		"summary export list": `{"summaryExport":["a.json"]}`,
+		"metrics file number": `{"metricsFile":1}`,
 		"trailing data":       `{"version":"1"} {"version":"2"}`,
 	}
 
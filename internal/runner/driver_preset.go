@@ -108,6 +108,18 @@ var driverPresets = map[string]DriverPreset{
 		DefaultInsertMethod: "native",
 		PoolKind:            "sql",
 	},
+	"sqlite": {
+		DriverType:          "sqlite",
+		URL:                 "stroppy.db",
+		DefaultInsertMethod: insertPlainBulk,
+		PoolKind:            "sql",
+	},
+	"generic": {
+		DriverType:          "generic_sql",
+		URL:                 "sqlite://stroppy.db",
+		DefaultInsertMethod: insertPlainBulk,
+		PoolKind:            "sql",
+	},
 	"noop": {
 		DriverType:          "noop",
 		URL:                 "noop://localhost",
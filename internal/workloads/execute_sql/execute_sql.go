@@ -31,7 +31,7 @@ func init() { bench.Register(func() bench.Workload { return &workload{} }) }
 func (*workload) Name() string { return "execute_sql" }
 
 func (w *workload) Define(d *bench.Def) error {
-	w.sqlFile = d.Param.String("sql-file", "", "SQL file to execute.").Value()
+	w.sqlFile = d.Param.String("sql-file", "", "SQL file to execute.", bench.LocalPath()).Value()
 
 	return nil
 }
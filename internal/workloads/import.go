@@ -6,8 +6,11 @@ package workloads
 import (
 	_ "github.com/stroppy-io/stroppy/internal/workloads/execute_sql"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/simple"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/sqlmix"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/sysbench"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/tpcb"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/tpcc"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/tpcds"
 	_ "github.com/stroppy-io/stroppy/internal/workloads/tpch"
+	_ "github.com/stroppy-io/stroppy/internal/workloads/ycsb"
 )
@@ -22,37 +22,70 @@ func TestBuiltInWorkloadParameterSchemas(t *testing.T) {
 		want []bench.ParamSchema
 	}{
 		{"execute_sql", []bench.ParamSchema{
-			param("sql-file", bench.ParamTypeString, "", "SQL_FILE", "sqlFile"),
+			pathParam("sql-file", "SQL_FILE", "sqlFile"),
 		}},
 		{"simple", []bench.ParamSchema{}},
+		{"sql_mix", []bench.ParamSchema{
+			pathParam("spec", "SPEC", "spec"),
+			pathParam("sql-file", "SQL_FILE", "sqlFile"),
+		}},
+		{"sysbench/oltp_insert", sysbenchParamSchema()},
+		{"sysbench/oltp_point_select", sysbenchParamSchema()},
+		{"sysbench/oltp_read_only", sysbenchParamSchema()},
+		{"sysbench/oltp_read_write", sysbenchParamSchema()},
+		{"sysbench/oltp_update_index", sysbenchParamSchema()},
+		{"sysbench/oltp_write_only", sysbenchParamSchema()},
 		{"tpcb/tx", []bench.ParamSchema{
 			param("load-workers", bench.ParamTypeInt, 1, "LOAD_WORKERS", "loadWorkers"),
 			param("retry-attempts", bench.ParamTypeInt, 3, "RETRY_ATTEMPTS", "retryAttempts"),
 			param("scale-factor", bench.ParamTypeInt, 1, "SCALE_FACTOR", "scaleFactor"),
-			param("sql-file", bench.ParamTypeString, "", "SQL_FILE", "sqlFile"),
+			pathParam("sql-file", "SQL_FILE", "sqlFile"),
 			param("tx-isolation", bench.ParamTypeString, "", "TX_ISOLATION", "txIsolation"),
 		}},
 		{"tpcc/procs", tpccParamSchema()},
 		{"tpcc/tx", tpccParamSchema()},
 		{"tpcds", []bench.ParamSchema{
+			pathParam("dump-answers", "DUMP_ANSWERS", "dumpAnswers"),
 			param("load-workers", bench.ParamTypeInt, 0, "LOAD_WORKERS", "loadWorkers"),
+			param("maintenance", bench.ParamTypeString, "none", "MAINTENANCE", "maintenance"),
+			param("maintenance-set", bench.ParamTypeInt, 1, "MAINTENANCE_SET", "maintenanceSet"),
+			param("mode", bench.ParamTypeString, "queries", "MODE", "mode"),
 			param("pg-unlogged", bench.ParamTypeBool, false, "PG_UNLOGGED", "pgUnlogged"),
 			param("query-seed", bench.ParamTypeInt, 19620718, "QUERY_SEED", "querySeed"),
 			param("query-stream", bench.ParamTypeInt, 0, "QUERY_STREAM", "queryStream"),
 			param("scale-factor", bench.ParamTypeFloat64, float64(1), "SCALE_FACTOR", "scaleFactor"),
-			param("schema-file", bench.ParamTypeString, "", "SCHEMA_FILE", "schemaFile"),
-			param("sql-file", bench.ParamTypeString, "", "SQL_FILE", "sqlFile"),
+			pathParam("schema-file", "SCHEMA_FILE", "schemaFile"),
+			pathParam("sql-file", "SQL_FILE", "sqlFile"),
 			param("streams", bench.ParamTypeInt, 1, "STREAMS", "streams"),
 			param("validate-force", bench.ParamTypeBool, false, "VALIDATE_FORCE", "validateForce"),
 			param("ydb-store-mode", bench.ParamTypeString, "column", "YDB_STORE_MODE", "ydbStoreMode"),
 		}},
 		{"tpch/tx", []bench.ParamSchema{
+			pathParam("dump-answers", "DUMP_ANSWERS", "dumpAnswers"),
 			param("load-workers", bench.ParamTypeInt, 0, "LOAD_WORKERS", "loadWorkers"),
+			param("mode", bench.ParamTypeString, "queries", "MODE", "mode"),
 			param("pg-unlogged", bench.ParamTypeBool, false, "PG_UNLOGGED", "pgUnlogged"),
+			derivedParam(
+				"query-params",
+				bench.ParamTypeString,
+				"QUERY_PARAMS",
+				"queryParams",
+				"validation in queries mode; qgen in power, throughput and full",
+			),
+			param("query-seed", bench.ParamTypeInt, 19620718, "QUERY_SEED", "querySeed"),
+			param("query-stream", bench.ParamTypeInt, 0, "QUERY_STREAM", "queryStream"),
+			param("refresh-set", bench.ParamTypeInt, 1, "REFRESH_SET", "refreshSet"),
 			param("scale-factor", bench.ParamTypeFloat64, float64(1), "SCALE_FACTOR", "scaleFactor"),
-			param("sql-file", bench.ParamTypeString, "", "SQL_FILE", "sqlFile"),
+			pathParam("sql-file", "SQL_FILE", "sqlFile"),
+			param("streams", bench.ParamTypeInt, 0, "STREAMS", "streams"),
 			param("ydb-store-mode", bench.ParamTypeString, "column", "YDB_STORE_MODE", "ydbStoreMode"),
 		}},
+		{"ycsb/a", ycsbParamSchema("zipfian")},
+		{"ycsb/b", ycsbParamSchema("zipfian")},
+		{"ycsb/c", ycsbParamSchema("zipfian")},
+		{"ycsb/d", ycsbParamSchema("latest")},
+		{"ycsb/e", ycsbParamSchema("zipfian")},
+		{"ycsb/f", ycsbParamSchema("zipfian")},
 	}
 
 	for _, tt := range tests {
@@ -131,6 +164,32 @@ func isEnvRead(name string) bool {
 	return name == "Env" || name == "EnvInt" || name == "EnvFloat"
 }
 
+func sysbenchParamSchema() []bench.ParamSchema {
+	return []bench.ParamSchema{
+		param("insert-shard", bench.ParamTypeInt, 0, "INSERT_SHARD", "insertShard"),
+		param("insert-shards", bench.ParamTypeInt, 1, "INSERT_SHARDS", "insertShards"),
+		param("load-workers", bench.ParamTypeInt, 1, "LOAD_WORKERS", "loadWorkers"),
+		param("rand-type", bench.ParamTypeString, "special", "RAND_TYPE", "randType"),
+		param("range-size", bench.ParamTypeInt, 100, "RANGE_SIZE", "rangeSize"),
+		param("retry-attempts", bench.ParamTypeInt, 3, "RETRY_ATTEMPTS", "retryAttempts"),
+		pathParam("sql-file", "SQL_FILE", "sqlFile"),
+		param("table-size", bench.ParamTypeInt, 10000, "TABLE_SIZE", "tableSize"),
+		param("tables", bench.ParamTypeInt, 1, "TABLES", "tables"),
+		param("tx-isolation", bench.ParamTypeString, "", "TX_ISOLATION", "txIsolation"),
+	}
+}
+
+func ycsbParamSchema(distribution string) []bench.ParamSchema {
+	return []bench.ParamSchema{
+		param("field-count", bench.ParamTypeInt, 10, "FIELD_COUNT", "fieldCount"),
+		param("field-length", bench.ParamTypeInt, 100, "FIELD_LENGTH", "fieldLength"),
+		param("load-workers", bench.ParamTypeInt, 1, "LOAD_WORKERS", "loadWorkers"),
+		param("max-scan-length", bench.ParamTypeInt, 100, "MAX_SCAN_LENGTH", "maxScanLength"),
+		param("record-count", bench.ParamTypeInt, 1000, "RECORD_COUNT", "recordCount"),
+		param("request-distribution", bench.ParamTypeString, distribution, "REQUEST_DISTRIBUTION", "requestDistribution"),
+	}
+}
+
 func tpccParamSchema() []bench.ParamSchema {
 	return []bench.ParamSchema{
 		derivedParam(
@@ -145,7 +204,7 @@ func tpccParamSchema() []bench.ParamSchema {
 		param("pg-unlogged", bench.ParamTypeBool, false, "PG_UNLOGGED", "pgUnlogged"),
 		param("retry-attempts", bench.ParamTypeInt, 3, "RETRY_ATTEMPTS", "retryAttempts"),
 		param("scale-factor", bench.ParamTypeInt, 1, "SCALE_FACTOR", "scaleFactor", "WAREHOUSES"),
-		param("sql-file", bench.ParamTypeString, "", "SQL_FILE", "sqlFile"),
+		pathParam("sql-file", "SQL_FILE", "sqlFile"),
 		param("tx-isolation", bench.ParamTypeString, "", "TX_ISOLATION", "txIsolation"),
 		param("warehouse-start", bench.ParamTypeInt, 1, "WAREHOUSE_START", "warehouseStart"),
 	}
@@ -165,6 +224,13 @@ func param(
 	}
 }
 
+func pathParam(name, env, config string) bench.ParamSchema {
+	schema := param(name, bench.ParamTypeString, "", env, config)
+	schema.LocalPath = true
+
+	return schema
+}
+
 func derivedParam(
 	name string,
 	typ bench.ParamType,
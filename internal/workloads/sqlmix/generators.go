@@ -0,0 +1,204 @@
+package sqlmix
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sync/atomic"
+
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// generator draws one parameter's value for an iteration's entity.
+type generator interface {
+	draw(entity uint64) any
+}
+
+func newGenerator(field gen.Field, ps paramSpec) (generator, error) {
+	switch ps.Type {
+	case "uniform":
+		if ps.Max < ps.Min {
+			return nil, errBadRange
+		}
+
+		return uniformGen{field: field, lo: ps.Min, hi: ps.Max, scale: ps.Scale}, nil
+	case "zipf":
+		if ps.Max < ps.Min {
+			return nil, errBadRange
+		}
+
+		theta := ps.Theta
+		if theta == 0 {
+			theta = defaultTheta
+		}
+
+		if theta <= 0 || theta >= 1 {
+			return nil, errBadTheta
+		}
+
+		//nolint:gosec // max >= min, so the item count is positive
+		return zipfGen{field: field, lo: ps.Min, zipf: gen.NewZipfian(uint64(ps.Max-ps.Min)+1, theta)}, nil
+	case "nurand":
+		if ps.Max < ps.Min {
+			return nil, errBadRange
+		}
+
+		if ps.A < 0 {
+			return nil, errBadNURand
+		}
+
+		g := nurandGen{field: field, a: ps.A, lo: ps.Min, hi: ps.Max}
+		if ps.C != nil {
+			g.c = *ps.C
+		} else {
+			// TPC-C picks C once per run; a fixed draw keeps it reproducible.
+			g.c = field.Int64(math.MaxUint64, 0, ps.A)
+		}
+
+		return g, nil
+	case "sequence":
+		g := &sequenceGen{start: 1, step: ps.Step}
+		if ps.Start != nil {
+			g.start = *ps.Start
+		}
+
+		if g.step == 0 {
+			g.step = 1
+		}
+
+		return g, nil
+	case "pick":
+		return newPickGen(field, ps)
+	default:
+		return nil, fmt.Errorf("%w, got %q", errUnknownGen, ps.Type)
+	}
+}
+
+type uniformGen struct {
+	field  gen.Field
+	lo, hi int64
+	scale  int
+}
+
+func (g uniformGen) draw(entity uint64) any {
+	if g.scale > 0 {
+		return g.field.Decimal(entity, float64(g.lo), float64(g.hi), g.scale)
+	}
+
+	return g.field.Int64(entity, g.lo, g.hi)
+}
+
+type zipfGen struct {
+	field gen.Field
+	lo    int64
+	zipf  gen.Zipfian
+}
+
+func (g zipfGen) draw(entity uint64) any {
+	return g.lo + int64(g.zipf.Next(g.field.Float64(entity))) //nolint:gosec // below max-min+1
+}
+
+type nurandGen struct {
+	field  gen.Field
+	a, c   int64
+	lo, hi int64
+}
+
+func (g nurandGen) draw(entity uint64) any {
+	return g.field.NURand(entity, g.a, g.c, g.lo, g.hi)
+}
+
+// sequenceGen hands out start, start+step, ... across all VUs, so its values
+// are unique but, unlike the other generators, not tied to the entity.
+type sequenceGen struct {
+	start, step int64
+	next        atomic.Int64
+}
+
+func (g *sequenceGen) draw(uint64) any {
+	return g.start + (g.next.Add(1)-1)*g.step
+}
+
+type pickGen struct {
+	field   gen.Field
+	values  []any
+	weights []float64 // cumulative; nil picks uniformly
+}
+
+func newPickGen(field gen.Field, ps paramSpec) (generator, error) {
+	if len(ps.Values) == 0 {
+		return nil, errPickNoValues
+	}
+
+	g := pickGen{field: field, values: make([]any, len(ps.Values))}
+	for i, v := range ps.Values {
+		g.values[i] = plainValue(v)
+	}
+
+	if ps.Weights == nil {
+		return g, nil
+	}
+
+	if len(ps.Weights) != len(ps.Values) {
+		return nil, errPickWeightCount
+	}
+
+	var total float64
+
+	for _, w := range ps.Weights {
+		if w < 0 || math.IsNaN(w) {
+			return nil, errBadWeight
+		}
+
+		total += w
+		g.weights = append(g.weights, total)
+	}
+
+	if total <= 0 {
+		return nil, errBadWeight
+	}
+
+	return g, nil
+}
+
+func (g pickGen) draw(entity uint64) any {
+	if g.weights == nil {
+		return g.values[g.field.Int(entity, 0, len(g.values)-1)]
+	}
+
+	return g.values[pickWeighted(g.weights, g.field.Float64(entity))]
+}
+
+// pickWeighted returns the index whose cumulative weight bucket holds u in
+// [0, 1).
+func pickWeighted(cumulative []float64, u float64) int {
+	u *= cumulative[len(cumulative)-1]
+
+	i, _ := slices.BinarySearchFunc(cumulative, u, func(cum, u float64) int {
+		if cum <= u {
+			return -1
+		}
+
+		return 1
+	})
+
+	return min(i, len(cumulative)-1)
+}
+
+// plainValue turns a decoded JSON number into int64 when it is integral, so
+// integer columns bind without a float conversion.
+func plainValue(v any) any {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+
+	f, _ := n.Float64()
+
+	return f
+}
@@ -0,0 +1,402 @@
+// Package sqlmix is a data-driven workload, registered as sql_mix: the
+// queries come from a SQL file and a small JSON spec draws their parameters
+// and weights them into a transaction mix, so application-specific queries
+// can be benchmarked without writing Go.
+//
+// The SQL file holds `--= name` queries with :param placeholders, either flat
+// or under a `--+ workload` section. Optional drop_schema, create_schema,
+// load_data and analyze sections run once, in that order, as Setup steps of
+// the same names.
+//
+// The spec names a generator per parameter and the weighted groups of queries
+// an iteration picks from:
+//
+//	{
+//	  "seed": 42,
+//	  "params": {
+//	    "aid":   {"type": "zipf", "min": 1, "max": 100000},
+//	    "delta": {"type": "uniform", "min": -5000, "max": 5000},
+//	    "hid":   {"type": "sequence"},
+//	    "kind":  {"type": "pick", "values": ["deposit", "withdrawal"], "weights": [3, 1]}
+//	  },
+//	  "mix": [
+//	    {"name": "balance", "weight": 80, "queries": ["get_balance"]},
+//	    {"name": "transfer", "weight": 20, "queries": ["debit", "history"],
+//	     "transaction": true, "isolation": "repeatable_read"}
+//	  ]
+//	}
+//
+// Generators are uniform (min, max; scale > 0 draws decimals), zipf (min, max,
+// theta), nurand (a, c, min, max), sequence (start, step; unique across VUs)
+// and pick (values, optional weights). Draws are deterministic from the seed,
+// VU and iteration. A parameter keeps its value across the queries of one
+// group execution. Without a spec every query is its own group of weight 1.
+//
+// Every query records sql_query_duration and sql_query_errors tagged by
+// query; transaction groups also report the tx_* metrics under their name.
+package sqlmix
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+const (
+	workloadSection = "workload"
+	defaultTheta    = 0.99
+	domainName      = "sql_mix@1"
+	vuEntityShift   = 40 // entity = vuid<<40 | iteration
+)
+
+// setupSections run as steps of the same name, in this order.
+var setupSections = []string{"drop_schema", "create_schema", "load_data", "analyze"}
+
+var (
+	errNoSQLFile       = errors.New("sql_mix: --sql-file is required")
+	errNoQueries       = errors.New("sql_mix: SQL file has no `--= name` queries")
+	errSpec            = errors.New("sql_mix: invalid spec")
+	errUnknownQuery    = errors.New("unknown query")
+	errUnknownParam    = errors.New("uses a parameter the spec does not define")
+	errUnknownGen      = errors.New("type must be uniform, zipf, nurand, sequence or pick")
+	errBadRange        = errors.New("max must be >= min")
+	errBadWeight       = errors.New("weights must be >= 0 and not all 0")
+	errEmptyGroup      = errors.New("group has no queries")
+	errIsolationNoTx   = errors.New("isolation needs \"transaction\": true")
+	errPickNoValues    = errors.New("pick needs values")
+	errPickWeightCount = errors.New("pick needs one weight per value")
+	errBadNURand       = errors.New("nurand needs a >= 0")
+	errBadTheta        = errors.New("zipf needs 0 < theta < 1")
+)
+
+// argRe finds :name placeholders the way sqldriver binds them, so a query is
+// handed exactly the parameters it references.
+var argRe = regexp.MustCompile(`(\s|^|\()(:[a-zA-Z0-9_]+)(\s|$|;|::|,|\))`)
+
+type spec struct {
+	Seed   uint64               `json:"seed"`
+	Params map[string]paramSpec `json:"params"`
+	Mix    []groupSpec          `json:"mix"`
+}
+
+type paramSpec struct {
+	Type    string    `json:"type"`
+	Min     int64     `json:"min"`
+	Max     int64     `json:"max"`
+	Scale   int       `json:"scale"`
+	Theta   float64   `json:"theta"`
+	A       int64     `json:"a"`
+	C       *int64    `json:"c"`
+	Start   *int64    `json:"start"`
+	Step    int64     `json:"step"`
+	Values  []any     `json:"values"`
+	Weights []float64 `json:"weights"`
+}
+
+type groupSpec struct {
+	Name        string   `json:"name"`
+	Weight      float64  `json:"weight"`
+	Queries     []string `json:"queries"`
+	Transaction bool     `json:"transaction"`
+	Isolation   string   `json:"isolation"`
+}
+
+type query struct {
+	name string
+	sql  string
+	args []string
+}
+
+type group struct {
+	name        string
+	queries     []query
+	params      []string // every parameter the group's queries use
+	transaction bool
+	isolation   bench.TxIsolationName
+}
+
+type workload struct {
+	sqlFile  string
+	specFile string
+
+	sql       *bench.SQL
+	section   string
+	groups    []group
+	weights   []float64 // cumulative
+	gens      map[string]generator
+	mixField  gen.Field
+	durations *bench.Metric
+	errors    *bench.Metric
+
+	vuStates sync.Map // uint64 -> *atomic.Uint64 iteration counter
+}
+
+func init() { bench.Register(func() bench.Workload { return &workload{} }) }
+
+func (*workload) Name() string { return "sql_mix" }
+
+func (w *workload) Define(d *bench.Def) error {
+	w.sqlFile = d.Param.String("sql-file", "", "SQL file with the `--= name` queries.", bench.LocalPath()).Value()
+	w.specFile = d.Param.String("spec", "",
+		"JSON spec with parameter generators and the weighted mix.", bench.LocalPath()).Value()
+
+	return nil
+}
+
+func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
+	if w.sqlFile == "" {
+		return errNoSQLFile
+	}
+
+	sql, err := bench.LoadSQL("sql_mix", w.sqlFile)
+	if err != nil {
+		return fmt.Errorf("sql_mix: %w", err)
+	}
+
+	w.sql = sql
+
+	var s spec
+	if w.specFile != "" {
+		raw, err := os.ReadFile(w.specFile)
+		if err != nil {
+			return fmt.Errorf("sql_mix: read spec: %w", err)
+		}
+
+		if s, err = parseSpec(raw); err != nil {
+			return err
+		}
+	}
+
+	if err := w.prepare(s); err != nil {
+		return err
+	}
+
+	w.durations = b.Trend("sql_query_duration")
+	w.errors = b.Counter("sql_query_errors")
+
+	for _, name := range setupSections {
+		statements := sql.Section(name)
+		if len(statements) == 0 {
+			continue
+		}
+
+		if err := b.Step(name, func() error {
+			for _, statement := range statements {
+				if err := b.Exec(ctx, statement, nil); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseSpec(raw []byte) (spec, error) {
+	var s spec
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+
+	if err := dec.Decode(&s); err != nil {
+		return spec{}, fmt.Errorf("%w: %w", errSpec, err)
+	}
+
+	return s, nil
+}
+
+// prepare resolves the spec against the SQL file: groups, their queries and
+// placeholders, and one generator per parameter.
+func (w *workload) prepare(s spec) error {
+	w.section = workloadSection
+	if len(w.sql.Names(w.section)) == 0 {
+		w.section = ""
+	}
+
+	names := w.sql.Names(w.section)
+	if len(names) == 0 {
+		return errNoQueries
+	}
+
+	mix := s.Mix
+	if len(mix) == 0 {
+		for _, name := range names {
+			mix = append(mix, groupSpec{Name: name, Weight: 1, Queries: []string{name}})
+		}
+	}
+
+	root := gen.New(s.Seed).Domain(domainName)
+	w.mixField = root.Field("mix")
+	w.gens = make(map[string]generator, len(s.Params))
+
+	for name, ps := range s.Params {
+		g, err := newGenerator(root.Field(name), ps)
+		if err != nil {
+			return fmt.Errorf("%w: param %q: %w", errSpec, name, err)
+		}
+
+		w.gens[name] = g
+	}
+
+	w.groups = w.groups[:0]
+	w.weights = w.weights[:0]
+
+	var total float64
+
+	for _, gs := range mix {
+		g, err := w.newGroup(gs)
+		if err != nil {
+			return fmt.Errorf("%w: group %q: %w", errSpec, gs.Name, err)
+		}
+
+		if gs.Weight < 0 || math.IsNaN(gs.Weight) {
+			return fmt.Errorf("%w: group %q: %w", errSpec, gs.Name, errBadWeight)
+		}
+
+		total += gs.Weight
+		w.groups = append(w.groups, g)
+		w.weights = append(w.weights, total)
+	}
+
+	if total <= 0 {
+		return fmt.Errorf("%w: mix: %w", errSpec, errBadWeight)
+	}
+
+	return nil
+}
+
+func (w *workload) newGroup(gs groupSpec) (group, error) {
+	g := group{name: gs.Name, transaction: gs.Transaction, isolation: bench.TxIsolationName(gs.Isolation)}
+
+	switch {
+	case len(gs.Queries) == 0:
+		return group{}, errEmptyGroup
+	case gs.Isolation != "" && !gs.Transaction:
+		return group{}, errIsolationNoTx
+	case gs.Transaction && gs.Isolation == "":
+		g.isolation = bench.IsoDBDefault
+	}
+
+	if g.transaction {
+		if _, err := bench.ParseTxIsolation(string(g.isolation)); err != nil {
+			return group{}, err
+		}
+	}
+
+	for _, name := range gs.Queries {
+		sql, ok := w.sql.Query(w.section, name)
+		if !ok {
+			return group{}, fmt.Errorf("%w %q", errUnknownQuery, name)
+		}
+
+		q := query{name: name, sql: sql, args: queryArgs(sql)}
+		for _, arg := range q.args {
+			if _, ok := w.gens[arg]; !ok {
+				return group{}, fmt.Errorf("query %q: %w: :%s", name, errUnknownParam, arg)
+			}
+
+			if !slices.Contains(g.params, arg) {
+				g.params = append(g.params, arg)
+			}
+		}
+
+		g.queries = append(g.queries, q)
+	}
+
+	return g, nil
+}
+
+// queryArgs returns the distinct placeholder names of sql in order of use.
+func queryArgs(sql string) []string {
+	var args []string
+
+	for _, match := range argRe.FindAllStringSubmatch(sql, -1) {
+		if name := match[2][1:]; !slices.Contains(args, name) {
+			args = append(args, name)
+		}
+	}
+
+	return args
+}
+
+func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
+	entity := w.nextEntity(b.VUID())
+	g := &w.groups[w.pick(entity)]
+
+	return b.StepSilent("workload", func() error {
+		values := make(map[string]any, len(g.params))
+		for _, name := range g.params {
+			values[name] = w.gens[name].draw(entity)
+		}
+
+		if !g.transaction {
+			return w.runQueries(ctx, b, g, values)
+		}
+
+		return b.BeginTx(ctx, bench.BeginOpts{Isolation: g.isolation, Name: g.name}, func(tx *bench.TxX) error {
+			return w.runQueries(ctx, tx, g, values)
+		})
+	})
+}
+
+// querier is what a group's queries run on: the driver or a transaction.
+type querier interface {
+	QueryRows(ctx context.Context, sql string, args map[string]any) ([][]any, error)
+}
+
+func (w *workload) runQueries(ctx context.Context, q querier, g *group, values map[string]any) error {
+	for _, query := range g.queries {
+		args := make(map[string]any, len(query.args))
+		for _, name := range query.args {
+			args[name] = values[name]
+		}
+
+		start := time.Now()
+		_, err := q.QueryRows(ctx, query.sql, args)
+
+		// Fractional milliseconds: point queries are often sub-ms.
+		w.durations.Add(float64(time.Since(start))/float64(time.Millisecond), "query", query.name)
+
+		if err != nil {
+			w.errors.Add(1, "query", query.name)
+
+			return fmt.Errorf("%s/%s: %w", g.name, query.name, err)
+		}
+	}
+
+	return nil
+}
+
+// pick returns the group the mix draws for entity.
+func (w *workload) pick(entity uint64) int {
+	return pickWeighted(w.weights, w.mixField.Float64(entity))
+}
+
+// nextEntity returns the draw coordinate of the VU's next iteration.
+func (w *workload) nextEntity(vuid uint64) uint64 {
+	v, _ := w.vuStates.LoadOrStore(vuid, new(atomic.Uint64))
+	counter, _ := v.(*atomic.Uint64) //nolint:errcheck // vuStates only stores *atomic.Uint64 values
+
+	return vuid<<vuEntityShift | counter.Add(1) - 1
+}
+
+func (*workload) Teardown(context.Context, *bench.Bench) error {
+	return nil
+}
@@ -0,0 +1,306 @@
+package sqlmix
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+
+	_ "github.com/stroppy-io/stroppy/pkg/driver/noop"
+)
+
+const testSQL = `--+ create_schema
+--= accounts
+CREATE TABLE accounts (id BIGINT PRIMARY KEY, balance BIGINT);
+
+--+ workload
+--= get_balance
+SELECT balance FROM accounts WHERE id = :aid;
+--= debit
+UPDATE accounts SET balance = balance - :delta WHERE id = :aid;
+--= history
+INSERT INTO history (hid, aid, delta, kind) VALUES (:hid, :aid, :delta, :kind);
+`
+
+const testSpec = `{
+  "seed": 42,
+  "params": {
+    "aid":   {"type": "zipf", "min": 1, "max": 1000},
+    "delta": {"type": "uniform", "min": -50, "max": 50},
+    "hid":   {"type": "sequence", "start": 10},
+    "kind":  {"type": "pick", "values": ["deposit", "withdrawal"], "weights": [3, 1]}
+  },
+  "mix": [
+    {"name": "balance", "weight": 80, "queries": ["get_balance"]},
+    {"name": "transfer", "weight": 20, "queries": ["debit", "history"],
+     "transaction": true, "isolation": "repeatable_read"}
+  ]
+}`
+
+func prepared(t *testing.T, sqlText, specText string) (*workload, error) {
+	t.Helper()
+
+	var (
+		s   spec
+		err error
+	)
+
+	if specText != "" {
+		if s, err = parseSpec([]byte(specText)); err != nil {
+			return nil, err
+		}
+	}
+
+	w := &workload{sql: bench.ParseSQL(sqlText)}
+
+	return w, w.prepare(s)
+}
+
+func TestPrepare(t *testing.T) {
+	w, err := prepared(t, testSQL, testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(w.groups) != 2 {
+		t.Fatalf("groups = %d, want 2", len(w.groups))
+	}
+
+	transfer := w.groups[1]
+	if !transfer.transaction || transfer.isolation != bench.IsoRepeatableRead {
+		t.Fatalf("transfer = %+v, want a repeatable_read transaction", transfer)
+	}
+
+	if want := []string{"delta", "aid", "hid", "kind"}; !slices.Equal(transfer.params, want) {
+		t.Fatalf("transfer params = %v, want %v", transfer.params, want)
+	}
+
+	if want := []string{"aid"}; !slices.Equal(w.groups[0].queries[0].args, want) {
+		t.Fatalf("get_balance args = %v, want %v", w.groups[0].queries[0].args, want)
+	}
+}
+
+func TestPrepareWithoutSpec(t *testing.T) {
+	w, err := prepared(t, "--= one\nSELECT 1;\n--= two\nSELECT 2;\n", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(w.groups) != 2 || w.groups[0].name != "one" || w.groups[1].name != "two" {
+		t.Fatalf("groups = %+v, want one group per query", w.groups)
+	}
+}
+
+func TestPrepareRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want error
+	}{
+		{"unknown field", `{"mixes": []}`, errSpec},
+		{"unknown query", `{"params": {"aid": {"type": "uniform", "max": 9}},
+			"mix": [{"name": "g", "weight": 1, "queries": ["nope"]}]}`, errUnknownQuery},
+		{"missing param", `{"mix": [{"name": "g", "weight": 1, "queries": ["get_balance"]}]}`, errUnknownParam},
+		{"unknown generator", `{"params": {"aid": {"type": "gauss"}}}`, errUnknownGen},
+		{"bad range", `{"params": {"aid": {"type": "uniform", "min": 5, "max": 1}}}`, errBadRange},
+		{"bad theta", `{"params": {"aid": {"type": "zipf", "max": 5, "theta": 1.5}}}`, errBadTheta},
+		{"pick weights", `{"params": {"aid": {"type": "pick", "values": [1, 2], "weights": [1]}}}`, errPickWeightCount},
+		{"isolation without tx", `{"params": {"aid": {"type": "uniform", "max": 9}},
+			"mix": [{"name": "g", "weight": 1, "queries": ["get_balance"], "isolation": "serializable"}]}`,
+			errIsolationNoTx},
+		{"zero weights", `{"params": {"aid": {"type": "uniform", "max": 9}},
+			"mix": [{"name": "g", "queries": ["get_balance"]}]}`, errBadWeight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := prepared(t, testSQL, tt.spec)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("prepare error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	field := gen.New(1).Domain("test@1").Field("x")
+
+	tests := []struct {
+		name  string
+		spec  paramSpec
+		check func(any) bool
+	}{
+		{"uniform", paramSpec{Type: "uniform", Min: -3, Max: 3}, func(v any) bool {
+			n, ok := v.(int64)
+			return ok && n >= -3 && n <= 3
+		}},
+		{"uniform decimal", paramSpec{Type: "uniform", Min: 1, Max: 2, Scale: 2}, func(v any) bool {
+			f, ok := v.(float64)
+			return ok && f >= 1 && f <= 2
+		}},
+		{"zipf", paramSpec{Type: "zipf", Min: 10, Max: 19}, func(v any) bool {
+			n, ok := v.(int64)
+			return ok && n >= 10 && n <= 19
+		}},
+		{"nurand", paramSpec{Type: "nurand", A: 255, Min: 0, Max: 999}, func(v any) bool {
+			n, ok := v.(int64)
+			return ok && n >= 0 && n <= 999
+		}},
+		{"pick", paramSpec{Type: "pick", Values: []any{"a", "b"}, Weights: []float64{0, 1}}, func(v any) bool {
+			return v == "b"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newGenerator(field, tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			again, _ := newGenerator(field, tt.spec)
+
+			for entity := range uint64(1000) {
+				v := g.draw(entity)
+				if !tt.check(v) {
+					t.Fatalf("draw(%d) = %v (%T) out of spec", entity, v, v)
+				}
+
+				if w := again.draw(entity); w != v {
+					t.Fatalf("draw(%d) = %v then %v, want deterministic", entity, v, w)
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	start := int64(100)
+
+	g, err := newGenerator(gen.Field{}, paramSpec{Type: "sequence", Start: &start, Step: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []int64{100, 105, 110} {
+		if got := g.draw(0); got != want {
+			t.Fatalf("draw #%d = %v, want %d", i, got, want)
+		}
+	}
+}
+
+func TestMixFollowsWeights(t *testing.T) {
+	w, err := prepared(t, testSQL, testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const draws = 20000
+
+	transfers := 0
+
+	for entity := range uint64(draws) {
+		if w.pick(entity) == 1 {
+			transfers++
+		}
+	}
+
+	if share := float64(transfers) / draws; share < 0.18 || share > 0.22 {
+		t.Fatalf("transfer share = %.3f, want about 0.20", share)
+	}
+}
+
+func TestQueryArgs(t *testing.T) {
+	got := queryArgs("SELECT :a, x::int FROM t WHERE (b = :b) AND c IN (:a, :c);")
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("queryArgs = %v, want %v", got, want)
+	}
+}
+
+func TestNoopEndToEnd(t *testing.T) {
+	unsetEnv(t, "SQL_FILE", "SPEC", "EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION")
+
+	dir := t.TempDir()
+	sqlFile := filepath.Join(dir, "bank.sql")
+	specFile := filepath.Join(dir, "bank.json")
+
+	if err := os.WriteFile(sqlFile, []byte(testSQL), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(specFile, []byte(testSpec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var payload bench.MetricsPayload
+
+	ctx := bench.WithRunHooks(context.Background(), bench.RunHooks{
+		Collected: func(data bench.MetricsPayload) { payload = data },
+	})
+
+	err := bench.Run(
+		ctx,
+		"sql_mix",
+		map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP}},
+		nil,
+		bench.ParamInputs{CLI: map[string]string{
+			"sql-file":   sqlFile,
+			"spec":       specFile,
+			"executor":   "shared-iterations",
+			"vus":        "2",
+			"iterations": "200",
+		}},
+		zap.NewNop(),
+		&bench.MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("sql_mix noop run: %v", err)
+	}
+
+	counts := map[string]uint64{}
+
+	for _, metric := range payload.Metrics {
+		if metric.Name != "sql_query_duration" {
+			continue
+		}
+
+		for _, point := range metric.Points {
+			counts[point.Tags["query"]] += point.Count
+		}
+	}
+
+	if total := counts["get_balance"] + counts["debit"]; total != 200 {
+		t.Fatalf("query counts = %v, want 200 iterations", counts)
+	}
+
+	if counts["debit"] == 0 || counts["debit"] != counts["history"] {
+		t.Fatalf("query counts = %v, want every transfer to run debit and history", counts)
+	}
+}
+
+func unsetEnv(t *testing.T, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		value, present := os.LookupEnv(name)
+		if err := os.Unsetenv(name); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			if present {
+				_ = os.Setenv(name, value)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		})
+	}
+}
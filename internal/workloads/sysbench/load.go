@@ -0,0 +1,73 @@
+package sysbench
+
+import (
+	"strconv"
+
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+const (
+	seedSbtest = 0x5B7E57
+
+	// c and pad follow oltp_common.lua's "###########-###..." templates:
+	// 10 and 5 groups of 11 digits.
+	digitGroup = 11
+	cLen       = 10*digitGroup + 9
+	padLen     = 5*digitGroup + 4
+)
+
+// sbtestRequest builds the typed insert request for table sbtest<n>.
+func sbtestRequest(n int, tableSize int64, workers int) *driver.InsertRequest {
+	return &driver.InsertRequest{
+		Table: "sbtest" + strconv.Itoa(n), Method: driver.InsertNative, Workers: workers,
+		Source: sbtestSource(gen.New(seedSbtest), n, tableSize),
+	}
+}
+
+// sbtestSource returns the indexed source for sbtest<n>: id is the 1-based
+// entity index, k is uniform in [1, tableSize] and c/pad are digit groups.
+// Each table has its own versioned domain, so tables hold different data.
+func sbtestSource(root gen.Root, n int, tableSize int64) *gen.IndexedSource {
+	domainName := "sysbench/sbtest" + strconv.Itoa(n) + "@1"
+	domain := root.Domain(domainName)
+	kField := domain.Field("k")
+	cField := domain.Field("c")
+	padField := domain.Field("pad")
+
+	b := gen.NewSchemaBuilder()
+	idCol := b.Int64("id")
+	kCol := b.Int64("k")
+	cCol := b.Bytes("c", cLen)
+	padCol := b.Bytes("pad", padLen)
+	schema := b.Build()
+
+	fn := func(r gen.Row, entity uint64) error {
+		r.SetInt64(idCol, int64(entity)+1) //nolint:gosec // G115: bounded by tableSize
+		r.SetInt64(kCol, kField.Int64(entity, 1, tableSize))
+
+		if err := fillDigits(r, cCol, cLen, cField.At(entity)); err != nil {
+			return err
+		}
+
+		return fillDigits(r, padCol, padLen, padField.At(entity))
+	}
+
+	return gen.NewIndexedSource(schema, root, domainName, tableSize, 64, fn)
+}
+
+// fillDigits writes length digits into col and dashes every 12th position.
+func fillDigits(r gen.Row, col gen.Column, length int, draw gen.Draw) error {
+	dst, err := r.Bytes(col, length)
+	if err != nil {
+		return err
+	}
+
+	gen.Numeric.Fill(&draw, dst)
+
+	for i := digitGroup; i < length; i += digitGroup + 1 {
+		dst[i] = '-'
+	}
+
+	return nil
+}
@@ -0,0 +1,582 @@
+// Package sysbench is a Go-native port of sysbench's bundled OLTP scripts
+// (oltp_common.lua and friends), registered as sysbench/<script>:
+//
+//   - oltp_point_select: one primary-key SELECT, no transaction.
+//   - oltp_read_only: 10 point selects plus the four range queries in a
+//     transaction.
+//   - oltp_read_write: oltp_read_only plus an index update, a non-index
+//     update and a delete/insert pair.
+//   - oltp_write_only: the writes of oltp_read_write alone, in a transaction.
+//   - oltp_update_index: one UPDATE of the indexed k column, no transaction.
+//   - oltp_insert: one INSERT of a new row, no transaction.
+//
+// All variants share the sbtest1..sbtestN tables (--tables of --table-size
+// rows), loaded through typed InsertRequests, and sysbench's statement text,
+// statement counts and --range-size, so a regression reported in sysbench
+// terms reproduces here. Ids, k values and range starts follow --rand-type:
+// special (the default, as in sysbench 1.0) or uniform.
+package sysbench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+var (
+	errTablesMustBePositive    = errors.New("TABLES must be >= 1")
+	errTableSizeMustBePositive = errors.New("TABLE_SIZE must be >= 1")
+	errRangeSizeMustBePositive = errors.New("RANGE_SIZE must be >= 1")
+	errUnknownRandType         = errors.New("RAND_TYPE must be special or uniform")
+	errBadInsertShard          = errors.New("INSERT_SHARD must be in [0, INSERT_SHARDS)")
+	errMissingSection          = errors.New("sysbench: missing section")
+	errMissingQuery            = errors.New("sysbench: missing query")
+)
+
+const preset = "sysbench"
+
+// Per-event statement counts: the oltp_common.lua option defaults.
+const (
+	pointSelects    = 10
+	simpleRanges    = 1
+	sumRanges       = 1
+	orderRanges     = 1
+	distinctRanges  = 1
+	indexUpdates    = 1
+	nonIndexUpdates = 1
+	deleteInserts   = 1
+)
+
+// Row id distributions (--rand-type).
+const (
+	randSpecial = "special"
+	randUniform = "uniform"
+)
+
+// sysbench's --rand-spec-pct and --rand-spec-res defaults: the special
+// distribution sends specialRes percent of draws to the first specialPct
+// percent of the range and spreads the rest uniformly.
+const (
+	specialPct = 1
+	specialRes = 75
+)
+
+// oltpQueries are the oltp section entries the measured iteration runs.
+var oltpQueries = []string{
+	"point_select", "simple_range", "sum_range", "order_range", "distinct_range",
+	"index_update", "non_index_update", "delete", "insert", "max_id",
+}
+
+// requiredSetupSections run once per table during setup and exist in every
+// dialect file (analyze is pg/mysql-only).
+var requiredSetupSections = []string{"drop_schema", "create_schema"}
+
+// Query classes, as sysbench's "queries performed" report splits them.
+const (
+	classRead  = "read"
+	classWrite = "write"
+	classOther = "other"
+)
+
+type workload struct {
+	script string // sysbench script name, e.g. "oltp_read_write"
+
+	tables        int
+	tableSize     int64
+	rangeSize     int64
+	randType      string
+	insertShard   int64
+	insertShards  int64
+	loadWorkers   int
+	retryAttempts int
+	iso           bench.TxIsolationName
+	sqlFile       string
+
+	driverType  bench.DriverTypeName
+	stmts       []map[string]string // [table-1][oltp query name] with {table} rendered
+	queries     *bench.Metric
+	retryPolicy bench.RetryPolicy
+	retries     *bench.Metric
+
+	insertIDs []insertIDs // per table
+	vuStates  sync.Map    // uint64 -> *vuState
+}
+
+// insertIDs hands out one table's oltp_insert ids. The first insert reads the
+// table's largest id, so rows an earlier run added are never reused. Ids then
+// step by the shard count within the shard's residue class, so agents
+// inserting into one table never collide, even when they read the maximum at
+// different times.
+type insertIDs struct {
+	mu     sync.Mutex
+	loaded atomic.Bool
+	first  int64
+	next   atomic.Int64
+}
+
+func init() {
+	for _, script := range []string{
+		"oltp_point_select", "oltp_read_only", "oltp_read_write",
+		"oltp_write_only", "oltp_update_index", "oltp_insert",
+	} {
+		bench.Register(func() bench.Workload { return &workload{script: script} })
+	}
+}
+
+func (w *workload) Name() string { return "sysbench/" + w.script }
+
+func (w *workload) Define(d *bench.Def) error {
+	w.tables = d.Param.Int("tables", 1, "Number of sbtest tables.").Value()
+	w.tableSize = int64(d.Param.Int("table-size", 10000, "Rows per sbtest table.").Value())
+	w.rangeSize = int64(d.Param.Int("range-size", 100, "Rows each range query covers.").Value())
+	w.randType = d.Param.String("rand-type", randSpecial, "Row id distribution: special or uniform.").Value()
+	w.insertShard = int64(d.Param.Int("insert-shard", 0, "This process's share of oltp_insert ids.").Value())
+	w.insertShards = int64(d.Param.Int("insert-shards", 1, "Processes inserting into the same tables.").Value())
+	w.loadWorkers = max(d.Param.Int("load-workers", 1, "Workers used to load each table.").Value(), 1)
+	w.retryAttempts = d.Param.Int("retry-attempts", 3, "Maximum transaction attempts.").Value()
+	w.iso = bench.TxIsolationName(d.Param.String("tx-isolation", "", "Transaction isolation override.").Value())
+	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.", bench.LocalPath()).Value()
+
+	switch {
+	case w.tables < 1:
+		return fmt.Errorf("%w, got %d", errTablesMustBePositive, w.tables)
+	case w.tableSize < 1:
+		return fmt.Errorf("%w, got %d", errTableSizeMustBePositive, w.tableSize)
+	case w.rangeSize < 1:
+		return fmt.Errorf("%w, got %d", errRangeSizeMustBePositive, w.rangeSize)
+	case w.randType != randSpecial && w.randType != randUniform:
+		return fmt.Errorf("%w, got %q", errUnknownRandType, w.randType)
+	case w.insertShards < 1 || w.insertShard < 0 || w.insertShard >= w.insertShards:
+		return fmt.Errorf("%w, got %d of %d", errBadInsertShard, w.insertShard, w.insertShards)
+	}
+
+	return nil
+}
+
+func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
+	w.driverType = b.DriverTypeName()
+	w.iso = resolveIsolation(w.driverType, w.iso)
+
+	sql, err := bench.LoadSQL(preset, sqlFile(w.driverType, w.sqlFile))
+	if err != nil {
+		return err
+	}
+
+	if err := validateSQL(sql); err != nil {
+		return err
+	}
+
+	w.stmts = make([]map[string]string, w.tables)
+	w.insertIDs = make([]insertIDs, w.tables)
+
+	for i := range w.tables {
+		w.stmts[i] = make(map[string]string, len(oltpQueries))
+		for _, name := range oltpQueries {
+			body, _ := sql.Query("oltp", name)
+			w.stmts[i][name] = renderTable(body, i+1)
+		}
+	}
+
+	runSection := func(name string) error {
+		for n := 1; n <= w.tables; n++ {
+			for _, q := range sql.Section(name) {
+				if err := b.Exec(ctx, renderTable(q, n), nil); err != nil {
+					return fmt.Errorf("%s sbtest%d: %w", name, n, err)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"drop_schema", func() error { return runSection("drop_schema") }},
+		{"create_schema", func() error { return runSection("create_schema") }},
+		{"load_data", func() error {
+			for n := 1; n <= w.tables; n++ {
+				if _, err := b.Insert(ctx, sbtestRequest(n, w.tableSize, w.loadWorkers)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}},
+		{"create_indexes", func() error { return runSection("create_indexes") }},
+		{"analyze", func() error { return runSection("analyze") }},
+	}
+
+	for _, s := range steps {
+		if err := b.Step(s.name, s.fn); err != nil {
+			return err
+		}
+	}
+
+	w.queries = b.Counter("sysbench_queries")
+	w.retries = b.Counter("sysbench_retry_attempts")
+	w.retryPolicy = b.TxRetryPolicy(bench.TxRetryPolicyOptions{
+		MaxAttempts: w.retryAttempts,
+		OnRetry:     func(int, error, bench.RetryDecision) { w.retries.Add(1) },
+	})
+
+	return nil
+}
+
+// renderTable expands the {table}/{n} placeholders for table number n.
+func renderTable(s string, n int) string {
+	num := strconv.Itoa(n)
+	s = strings.ReplaceAll(s, "{table}", "sbtest"+num)
+
+	return strings.ReplaceAll(s, "{n}", num)
+}
+
+func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
+	vs := w.vuState(b.VUID())
+
+	return b.StepSilent("workload", func() error {
+		switch w.script {
+		case "oltp_point_select":
+			return w.pointSelects(ctx, b, vs, 1)
+		case "oltp_update_index":
+			return w.indexUpdates(ctx, b, vs)
+		case "oltp_insert":
+			return w.insert(ctx, b, vs)
+		}
+
+		return bench.Retry0(ctx, w.retryPolicy, func() error {
+			err := b.BeginTx(ctx, bench.BeginOpts{Isolation: w.iso, Name: w.script}, func(tx *bench.TxX) error {
+				return w.transaction(ctx, tx, vs)
+			})
+			if err == nil {
+				w.queries.Add(2, "type", classOther) // BEGIN and COMMIT
+			}
+
+			return err
+		})
+	})
+}
+
+// transaction is the body of one oltp_read_only/read_write/write_only event.
+func (w *workload) transaction(ctx context.Context, tx *bench.TxX, vs *vuState) error {
+	if w.script != "oltp_write_only" {
+		if err := w.pointSelects(ctx, tx, vs, pointSelects); err != nil {
+			return err
+		}
+
+		if err := w.ranges(ctx, tx, vs); err != nil {
+			return err
+		}
+	}
+
+	if w.script == "oltp_read_only" {
+		return nil
+	}
+
+	if err := w.indexUpdates(ctx, tx, vs); err != nil {
+		return err
+	}
+
+	if err := w.nonIndexUpdates(ctx, tx, vs); err != nil {
+		return err
+	}
+
+	return w.deleteInserts(ctx, tx, vs)
+}
+
+// executor is what the statement groups run on: the bench itself outside a
+// transaction, or the event's transaction.
+type executor interface {
+	Exec(ctx context.Context, sql string, args map[string]any) error
+	QueryRows(ctx context.Context, sql string, args map[string]any) ([][]any, error)
+}
+
+func (w *workload) read(ctx context.Context, q executor, sql string, args map[string]any) error {
+	if _, err := q.QueryRows(ctx, sql, args); err != nil {
+		return err
+	}
+
+	w.queries.Add(1, "type", classRead)
+
+	return nil
+}
+
+func (w *workload) write(ctx context.Context, q executor, sql string, args map[string]any) error {
+	if err := q.Exec(ctx, sql, args); err != nil {
+		return err
+	}
+
+	w.queries.Add(1, "type", classWrite)
+
+	return nil
+}
+
+// Each statement group picks its table once, as oltp_common.lua does.
+
+func (w *workload) pointSelects(ctx context.Context, q executor, vs *vuState, count int) error {
+	stmts := w.stmts[vs.table(w.tables)]
+	for range count {
+		if err := w.read(ctx, q, stmts["point_select"], map[string]any{"id": vs.id(w.tableSize)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *workload) ranges(ctx context.Context, q executor, vs *vuState) error {
+	groups := []struct {
+		name  string
+		count int
+	}{
+		{"simple_range", simpleRanges},
+		{"sum_range", sumRanges},
+		{"order_range", orderRanges},
+		{"distinct_range", distinctRanges},
+	}
+
+	for _, g := range groups {
+		sql := w.stmts[vs.table(w.tables)][g.name]
+		for range g.count {
+			start := vs.id(w.tableSize)
+			if err := w.read(ctx, q, sql, map[string]any{"start_id": start, "end_id": start + w.rangeSize - 1}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *workload) indexUpdates(ctx context.Context, q executor, vs *vuState) error {
+	stmts := w.stmts[vs.table(w.tables)]
+	for range indexUpdates {
+		if err := w.write(ctx, q, stmts["index_update"], map[string]any{"id": vs.id(w.tableSize)}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *workload) nonIndexUpdates(ctx context.Context, q executor, vs *vuState) error {
+	stmts := w.stmts[vs.table(w.tables)]
+	for range nonIndexUpdates {
+		args := map[string]any{"id": vs.id(w.tableSize), "c": vs.digits(cLen)}
+		if err := w.write(ctx, q, stmts["non_index_update"], args); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteInserts deletes a random row and inserts it back with new values.
+func (w *workload) deleteInserts(ctx context.Context, q executor, vs *vuState) error {
+	stmts := w.stmts[vs.table(w.tables)]
+	for range deleteInserts {
+		id := vs.id(w.tableSize)
+		if err := w.write(ctx, q, stmts["delete"], map[string]any{"id": id}); err != nil {
+			return err
+		}
+
+		if err := w.write(ctx, q, stmts["insert"], vs.row(id, w.tableSize)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// insert adds a row past the table's ids. sysbench leaves the id to
+// AUTO_INCREMENT; a per-table counter does the same on every dialect.
+func (w *workload) insert(ctx context.Context, b *bench.Bench, vs *vuState) error {
+	table := vs.table(w.tables)
+
+	id, err := w.nextInsertID(ctx, b, table)
+	if err != nil {
+		return err
+	}
+
+	return w.write(ctx, b, w.stmts[table]["insert"], vs.row(id, w.tableSize))
+}
+
+// nextInsertID returns table's next oltp_insert id, reading the table's
+// largest id on first use.
+func (w *workload) nextInsertID(ctx context.Context, b *bench.Bench, table int) (int64, error) {
+	ids := &w.insertIDs[table]
+
+	if !ids.loaded.Load() {
+		ids.mu.Lock()
+		defer ids.mu.Unlock()
+
+		if !ids.loaded.Load() {
+			v, err := b.QueryValue(ctx, w.stmts[table]["max_id"], nil)
+			if err != nil {
+				return 0, err
+			}
+
+			maxID, err := asInt64(v)
+			if err != nil {
+				return 0, fmt.Errorf("sbtest%d max id: %w", table+1, err)
+			}
+
+			ids.first = firstShardID(maxID, w.insertShard, w.insertShards)
+			ids.loaded.Store(true)
+		}
+	}
+
+	return ids.first + (ids.next.Add(1)-1)*w.insertShards, nil
+}
+
+// firstShardID returns the smallest id above maxID that is congruent to shard
+// modulo shards.
+func firstShardID(maxID, shard, shards int64) int64 {
+	id := maxID + 1
+
+	return id + ((shard-id)%shards+shards)%shards
+}
+
+// asInt64 reads a MAX(id) result; an empty table yields nil.
+func asInt64(v any) (int64, error) {
+	switch x := v.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return x, nil
+	case int32:
+		return int64(x), nil
+	case int:
+		return int64(x), nil
+	case []byte:
+		return strconv.ParseInt(string(x), 10, 64)
+	case string:
+		return strconv.ParseInt(x, 10, 64)
+	default:
+		return strconv.ParseInt(fmt.Sprint(x), 10, 64)
+	}
+}
+
+func (w *workload) Teardown(context.Context, *bench.Bench) error {
+	return nil
+}
+
+// --- config helpers ---
+
+// resolveIsolation keeps the server default on pg/mysql, as sysbench does.
+func resolveIsolation(dt bench.DriverTypeName, override bench.TxIsolationName) bench.TxIsolationName {
+	if override != "" {
+		return override
+	}
+
+	switch dt {
+	case bench.DriverPicodata:
+		return bench.IsoNone
+	case bench.DriverYDB:
+		return bench.IsoSerializable
+	default:
+		return bench.IsoDBDefault
+	}
+}
+
+func sqlFile(dt bench.DriverTypeName, override string) string {
+	if override != "" {
+		return override
+	}
+
+	switch dt {
+	case bench.DriverMySQL:
+		return "mysql.sql"
+	case bench.DriverPicodata:
+		return "pico.sql"
+	case bench.DriverYDB:
+		return "ydb.sql"
+	default:
+		return "pg.sql"
+	}
+}
+
+// validateSQL asserts a custom SQL file carries every section and statement
+// the workload runs, so a missing one fails setup instead of running as a
+// silent noop.
+func validateSQL(sql *bench.SQL) error {
+	for _, name := range requiredSetupSections {
+		if len(sql.Section(name)) == 0 {
+			return fmt.Errorf("%w %q", errMissingSection, name)
+		}
+	}
+
+	for _, name := range oltpQueries {
+		if body, ok := sql.Query("oltp", name); !ok || strings.TrimSpace(body) == "" {
+			return fmt.Errorf("%w oltp/%s", errMissingQuery, name)
+		}
+	}
+
+	return nil
+}
+
+// --- per-VU generators ---
+
+type vuState struct {
+	rng     *rand.Rand
+	special bool // --rand-type=special
+	buf     []byte
+}
+
+func (w *workload) vuState(vuid uint64) *vuState {
+	if v, ok := w.vuStates.Load(vuid); ok {
+		vs, _ := v.(*vuState) //nolint:errcheck // vuStates only stores *vuState values
+
+		return vs
+	}
+
+	vs := &vuState{
+		rng:     rand.New(rand.NewPCG(vuid, vuid^seedSbtest)), //nolint:gosec // G404: benchmark RNG
+		special: w.randType == randSpecial,
+	}
+	actual, _ := w.vuStates.LoadOrStore(vuid, vs)
+	stored, _ := actual.(*vuState) //nolint:errcheck // vuStates only stores *vuState values
+
+	return stored
+}
+
+// table draws a 0-based table index.
+func (v *vuState) table(tables int) int { return v.rng.IntN(tables) }
+
+// id draws a row id in [1, tableSize], uniformly or, with the special
+// distribution, specialRes percent of the time from the first specialPct
+// percent of the ids.
+func (v *vuState) id(tableSize int64) int64 {
+	if v.special && v.rng.IntN(100) < specialRes {
+		return v.rng.Int64N(max(tableSize*specialPct/100, 1)) + 1
+	}
+
+	return v.rng.Int64N(tableSize) + 1
+}
+
+// digits draws a c/pad value: dash-separated groups of 11 random digits.
+func (v *vuState) digits(length int) string {
+	v.buf = v.buf[:0]
+	for i := range length {
+		if i%(digitGroup+1) == digitGroup {
+			v.buf = append(v.buf, '-')
+		} else {
+			v.buf = append(v.buf, byte('0'+v.rng.IntN(10))) //nolint:gosec // G115: a digit
+		}
+	}
+
+	return string(v.buf)
+}
+
+// row draws the insert arguments of a row with the given id.
+func (v *vuState) row(id, tableSize int64) map[string]any {
+	return map[string]any{"id": id, "k": v.id(tableSize), "c": v.digits(cLen), "pad": v.digits(padLen)}
+}
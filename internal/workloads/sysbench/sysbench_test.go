@@ -0,0 +1,176 @@
+package sysbench
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// digitsRe matches oltp_common.lua's c/pad template shape.
+var digitsRe = regexp.MustCompile(`^\d{11}(-\d{11})*$`)
+
+func TestDialectFilesCarryEveryStatement(t *testing.T) {
+	for _, dt := range []bench.DriverTypeName{
+		bench.DriverPostgres, bench.DriverMySQL, bench.DriverPicodata, bench.DriverYDB,
+	} {
+		sql, err := bench.LoadSQL(preset, sqlFile(dt, ""))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := validateSQL(sql); err != nil {
+			t.Errorf("%s: %v", dt, err)
+		}
+
+		for _, q := range sql.Section("create_indexes") {
+			if rendered := renderTable(q, 3); strings.Contains(rendered, "{") || !strings.Contains(rendered, "sbtest3") {
+				t.Errorf("%s create_indexes renders as %q", dt, rendered)
+			}
+		}
+	}
+
+	if err := validateSQL(bench.ParseSQL("--+ drop_schema\n--=\nDROP TABLE t\n")); !errors.Is(err, errMissingSection) {
+		t.Fatalf("want errMissingSection, got %v", err)
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	got := renderTable("CREATE INDEX k_{n} ON {table} (k)", 12)
+	if got != "CREATE INDEX k_12 ON sbtest12 (k)" {
+		t.Fatalf("renderTable = %q", got)
+	}
+}
+
+func TestSbtestSource(t *testing.T) {
+	const size = 500
+
+	src := sbtestSource(gen.New(seedSbtest), 1, size)
+
+	cur, err := src.Prepare(0, -1, 128)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scratch := make([]any, src.Schema().Columns())
+	rows := 0
+
+	for {
+		b, err := cur.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range b.Len() {
+			b.MaterializeRow(i, scratch)
+
+			id, _ := scratch[0].(int64)
+			k, _ := scratch[1].(int64)
+			c, _ := scratch[2].(string)
+			pad, _ := scratch[3].(string)
+
+			if id != int64(rows+1) || k < 1 || k > size {
+				t.Fatalf("row %d: id=%d k=%d", rows, id, k)
+			}
+
+			if len(c) != cLen || len(pad) != padLen || !digitsRe.MatchString(c) || !digitsRe.MatchString(pad) {
+				t.Fatalf("row %d: c=%q pad=%q", rows, c, pad)
+			}
+
+			rows++
+		}
+	}
+
+	if rows != size {
+		t.Fatalf("rows = %d, want %d", rows, size)
+	}
+}
+
+func TestVUStateDraws(t *testing.T) {
+	vs := &vuState{rng: rand.New(rand.NewPCG(1, 2))}
+
+	for range 1000 {
+		if id := vs.id(10); id < 1 || id > 10 {
+			t.Fatalf("id %d outside [1, 10]", id)
+		}
+
+		if table := vs.table(4); table < 0 || table >= 4 {
+			t.Fatalf("table %d outside [0, 4)", table)
+		}
+	}
+
+	if c := vs.digits(cLen); !digitsRe.MatchString(c) {
+		t.Fatalf("c = %q", c)
+	}
+
+	row := vs.row(42, 10)
+	if row["id"] != int64(42) || !digitsRe.MatchString(row["pad"].(string)) {
+		t.Fatalf("row = %v", row)
+	}
+}
+
+func TestSpecialDistribution(t *testing.T) {
+	vs := &vuState{rng: rand.New(rand.NewPCG(1, 2)), special: true}
+
+	const draws = 10000
+
+	hot := 0
+
+	for range draws {
+		id := vs.id(1000)
+		if id < 1 || id > 1000 {
+			t.Fatalf("id %d outside [1, 1000]", id)
+		}
+
+		if id <= 10 {
+			hot++
+		}
+	}
+
+	// 75% of draws land in the first 1% directly, plus 1% of the rest.
+	if got := float64(hot) / draws; got < 0.72 || got > 0.79 {
+		t.Fatalf("%.3f of special draws in the first 1%% of ids, want about 0.75", got)
+	}
+}
+
+func TestFirstShardID(t *testing.T) {
+	for _, tc := range []struct{ maxID, shard, shards, want int64 }{
+		{maxID: 0, shard: 0, shards: 1, want: 1},
+		{maxID: 10000, shard: 0, shards: 1, want: 10001},
+		{maxID: 10000, shard: 0, shards: 3, want: 10002},
+		{maxID: 10000, shard: 1, shards: 3, want: 10003},
+		{maxID: 10000, shard: 2, shards: 3, want: 10001},
+		{maxID: 10001, shard: 2, shards: 3, want: 10004},
+	} {
+		if got := firstShardID(tc.maxID, tc.shard, tc.shards); got != tc.want {
+			t.Errorf("firstShardID(%d, %d, %d) = %d, want %d", tc.maxID, tc.shard, tc.shards, got, tc.want)
+		}
+	}
+}
+
+func TestResolveIsolation(t *testing.T) {
+	tests := map[bench.DriverTypeName]bench.TxIsolationName{
+		bench.DriverPostgres: bench.IsoDBDefault,
+		bench.DriverMySQL:    bench.IsoDBDefault,
+		bench.DriverPicodata: bench.IsoNone,
+		bench.DriverYDB:      bench.IsoSerializable,
+	}
+	for dt, want := range tests {
+		if got := resolveIsolation(dt, ""); got != want {
+			t.Errorf("resolveIsolation(%s) = %s, want %s", dt, got, want)
+		}
+	}
+
+	if got := resolveIsolation(bench.DriverYDB, bench.IsoNone); got != bench.IsoNone {
+		t.Fatalf("override = %s", got)
+	}
+}
@@ -96,6 +96,7 @@ func TestProcsSupported(t *testing.T) {
 		{bench.DriverPicodata, false},
 		{bench.DriverYDB, false},
 		{bench.DriverCSV, false},
+		{bench.DriverSQLite, false},
 	}
 	for _, c := range cases {
 		if got := procsSupported(c.driver); got != c.want {
@@ -0,0 +1,68 @@
+package tpcb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	_ "github.com/stroppy-io/stroppy/pkg/driver/sqlite"
+)
+
+// TestSQLiteEndToEnd runs the whole tpcb/tx lifecycle against an embedded
+// SQLite file and checks the TPC-B invariant: every balance moved by exactly
+// the deltas the history recorded.
+func TestSQLiteEndToEnd(t *testing.T) {
+	unsetEnv(
+		t,
+		"SCALE_FACTOR", "RETRY_ATTEMPTS", "TX_ISOLATION", "SQL_FILE", "LOAD_WORKERS",
+		"EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION",
+	)
+
+	path := filepath.Join(t.TempDir(), "tpcb.db")
+
+	err := bench.Run(
+		context.Background(),
+		"tpcb/tx",
+		map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_SQLITE, Url: path}},
+		nil,
+		bench.ParamInputs{CLI: map[string]string{
+			"load-workers": "2",
+			"executor":     "shared-iterations",
+			"vus":          "4",
+			"iterations":   "200",
+		}},
+		zap.NewNop(),
+		&bench.MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("tpcb/tx sqlite run: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var history, deltas, accounts, tellers, branches int64
+
+	err = db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM pgbench_history),
+		(SELECT COALESCE(SUM(delta), 0) FROM pgbench_history),
+		(SELECT SUM(abalance) FROM pgbench_accounts),
+		(SELECT SUM(tbalance) FROM pgbench_tellers),
+		(SELECT SUM(bbalance) FROM pgbench_branches)`).Scan(&history, &deltas, &accounts, &tellers, &branches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if history != 200 || accounts != deltas || tellers != deltas || branches != deltas {
+		t.Fatalf("history=%d deltas=%d accounts=%d tellers=%d branches=%d, want 200 rows and matching sums",
+			history, deltas, accounts, tellers, branches)
+	}
+}
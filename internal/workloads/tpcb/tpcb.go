@@ -2,7 +2,7 @@
 // transaction, shipping two registered variants:
 //
 //   - tpcb/tx runs the five DML steps inline under one client-side transaction
-//     per iteration; supports pg/mysql/picodata/ydb.
+//     per iteration; supports pg/mysql/picodata/ydb/sqlite.
 //   - tpcb/procs calls the stored procedure tpcb_transaction (workload_procs
 //     section) as a single server-side round-trip; pg/mysql only.
 //
@@ -26,7 +26,7 @@ import (
 
 var (
 	errAccountNotFound        = errors.New("tpc-b: account not found")
-	errProcsDriverUnsupported = errors.New("tpcb/procs only supports postgres and mysql; use tpcb/tx for picodata/ydb")
+	errProcsDriverUnsupported = errors.New("tpcb/procs only supports postgres and mysql; use tpcb/tx for picodata/ydb/sqlite")
 	errProcsMissingQuery      = errors.New("tpc-b/procs: missing query")
 )
 
@@ -97,7 +97,7 @@ func (w *workload) Define(d *bench.Def) error {
 	w.scale = int64(max(d.Param.Int("scale-factor", 1, "TPC-B scale factor.").Value(), 1))
 	w.retryAttempts = d.Param.Int("retry-attempts", 3, "Maximum transaction attempts.").Value()
 	w.iso = bench.TxIsolationName(d.Param.String("tx-isolation", "", "Transaction isolation override.").Value())
-	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.").Value()
+	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.", bench.LocalPath()).Value()
 	w.loadWorkers = d.Param.Int("load-workers", 1, "Workers used to load each table.").Value()
 	w.loadWorkers = max(w.loadWorkers, 1)
 
@@ -261,7 +261,7 @@ func resolveIsolation(dt bench.DriverTypeName, override bench.TxIsolationName) b
 	switch dt {
 	case bench.DriverPicodata:
 		return bench.IsoNone
-	case bench.DriverYDB:
+	case bench.DriverYDB, bench.DriverSQLite:
 		return bench.IsoSerializable
 	default:
 		return bench.IsoReadCommitted
@@ -293,6 +293,8 @@ func sqlFile(dt bench.DriverTypeName, override string) string {
 		return "pico.sql"
 	case bench.DriverYDB:
 		return "ydb.sql"
+	case bench.DriverSQLite:
+		return "sqlite.sql"
 	default:
 		return "pg.sql"
 	}
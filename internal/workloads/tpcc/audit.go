@@ -0,0 +1,356 @@
+package tpcc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+var errValidateConsistency = errors.New("validate_consistency")
+
+// Consistency-check statuses.
+const (
+	consistencyPass    = "pass"
+	consistencyFail    = "fail"
+	consistencyError   = "error"
+	consistencySkipped = "skipped"
+)
+
+// moneyTolerance is the absolute slack on money comparisons: pg/mysql keep
+// DECIMAL(12,2) columns while ydb/picodata sum doubles.
+const moneyTolerance = 0.005
+
+// ConsistencyCheck is one §3.3.2 consistency condition evaluated after the run.
+// Violations counts the warehouses, districts, orders or customers breaking it.
+type ConsistencyCheck struct {
+	Condition   string `json:"condition"` // "CC1" .. "CC12"
+	Description string `json:"description"`
+	Status      string `json:"status"` // "pass", "fail", "error", "skipped"
+	Violations  int64  `json:"violations"`
+	Detail      string `json:"detail,omitempty"`
+}
+
+type districtKey struct{ w, d int64 }
+
+func (k districtKey) String() string { return fmt.Sprintf("%d/%d", k.w, k.d) }
+
+// warehouseAudit is one warehouse's W_YTD plus the history paid into it.
+type warehouseAudit struct {
+	ytd, hSum float64
+}
+
+// districtAudit is one district's row plus its orders/new_order/order_line/history
+// aggregates.
+type districtAudit struct {
+	ytd, hSum           float64
+	nextOID, oMax       int64
+	olCnt, olRows       int64
+	noMax, noMin, noCnt int64
+}
+
+// sqlCheck is a per-order or per-customer condition evaluated in the database as
+// a violation count, so the audit never pulls order_line-sized results.
+type sqlCheck struct {
+	condition, description, unit string
+	query                        func(wRange string) string
+}
+
+// auditConsistency evaluates the §3.3.2 consistency conditions over the run's
+// warehouse slice. Per-warehouse and per-district conditions are compared here
+// from GROUP BY aggregates; per-order and per-customer ones are violation counts.
+// A query error marks its conditions "error" rather than failing them.
+//
+// history is not loaded (spec §4.3.3.1 seeds one 10.00 payment per customer), so
+// CC8–CC10 fold those initial payments back in from the load constants. CC11 only
+// holds on an untouched population and is skipped here.
+func auditConsistency(ctx context.Context, b *bench.Bench, warehouseStart, wIDMax int64) []ConsistencyCheck {
+	wRange := fmt.Sprintf("BETWEEN %d AND %d", warehouseStart, wIDMax)
+
+	var checks []ConsistencyCheck
+
+	whs, dists, err := fetchAudit(ctx, b, wRange)
+	if err != nil {
+		for _, c := range aggregateChecks(nil, nil) {
+			c.Status, c.Detail = consistencyError, err.Error()
+			checks = append(checks, c)
+		}
+	} else {
+		checks = append(checks, aggregateChecks(whs, dists)...)
+	}
+
+	for _, sc := range sqlChecks {
+		checks = append(checks, runSQLCheck(ctx, b, sc, wRange))
+	}
+
+	checks = append(checks, ConsistencyCheck{
+		Condition:   "CC11",
+		Description: "count(ORDER) - count(NEW_ORDER) = 2100 per district",
+		Status:      consistencySkipped,
+		Detail:      "holds only on the initial population",
+	})
+
+	slices.SortFunc(checks, func(a, b ConsistencyCheck) int {
+		return cmp.Compare(atoi(a.Condition), atoi(b.Condition))
+	})
+
+	return checks
+}
+
+// consistent folds the audit into one verdict: false on any failure, nil when a
+// condition could not be evaluated (or none ran), true otherwise.
+func consistent(checks []ConsistencyCheck) *bool {
+	if len(checks) == 0 {
+		return nil
+	}
+
+	ok, errored := true, false
+
+	for _, c := range checks {
+		switch c.Status {
+		case consistencyFail:
+			ok = false
+		case consistencyError:
+			errored = true
+		}
+	}
+
+	if ok && errored {
+		return nil
+	}
+
+	return &ok
+}
+
+// auditError returns an errValidateConsistency naming every failed condition, or
+// nil when none failed.
+func auditError(checks []ConsistencyCheck) error {
+	var failures []string
+
+	for _, c := range checks {
+		if c.Status == consistencyFail {
+			failures = append(failures, c.Condition+" "+c.Description+": "+c.Detail)
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d condition(s) failed:\n  %s",
+		errValidateConsistency, len(failures), strings.Join(failures, "\n  "))
+}
+
+// fetchAudit loads the warehouse and district rows with every aggregate the
+// CC1–CC4, CC8 and CC9 comparisons need.
+func fetchAudit(
+	ctx context.Context, b *bench.Bench, wRange string,
+) (map[int64]*warehouseAudit, map[districtKey]*districtAudit, error) {
+	whs := map[int64]*warehouseAudit{}
+	dists := map[districtKey]*districtAudit{}
+
+	district := func(r []any) *districtAudit {
+		k := districtKey{toInt64(r[0]), toInt64(r[1])}
+		if dists[k] == nil {
+			dists[k] = &districtAudit{}
+		}
+
+		return dists[k]
+	}
+
+	queries := []struct {
+		sql  string
+		scan func([]any)
+	}{
+		{"SELECT w_id, w_ytd FROM warehouse WHERE w_id " + wRange, func(r []any) {
+			whs[toInt64(r[0])] = &warehouseAudit{ytd: toFloat64(r[1])}
+		}},
+		{"SELECT d_w_id, d_id, d_next_o_id, d_ytd FROM district WHERE d_w_id " + wRange, func(r []any) {
+			d := district(r)
+			d.nextOID, d.ytd = toInt64(r[2]), toFloat64(r[3])
+		}},
+		{"SELECT o_w_id, o_d_id, MAX(o_id), SUM(o_ol_cnt) FROM orders WHERE o_w_id " + wRange +
+			" GROUP BY o_w_id, o_d_id", func(r []any) {
+			d := district(r)
+			d.oMax, d.olCnt = toInt64(r[2]), toInt64(r[3])
+		}},
+		{"SELECT no_w_id, no_d_id, MAX(no_o_id), MIN(no_o_id), COUNT(*) FROM new_order WHERE no_w_id " + wRange +
+			" GROUP BY no_w_id, no_d_id", func(r []any) {
+			d := district(r)
+			d.noMax, d.noMin, d.noCnt = toInt64(r[2]), toInt64(r[3]), toInt64(r[4])
+		}},
+		{"SELECT ol_w_id, ol_d_id, COUNT(*) FROM order_line WHERE ol_w_id " + wRange +
+			" GROUP BY ol_w_id, ol_d_id", func(r []any) {
+			district(r).olRows = toInt64(r[2])
+		}},
+		{"SELECT h_w_id, h_d_id, SUM(h_amount) FROM history WHERE h_w_id " + wRange +
+			" GROUP BY h_w_id, h_d_id", func(r []any) {
+			district(r).hSum = toFloat64(r[2])
+		}},
+	}
+
+	for _, q := range queries {
+		rows, err := b.QueryRows(ctx, q.sql, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("validate_consistency: prefetch failed: %w", err)
+		}
+
+		for _, r := range rows {
+			q.scan(r)
+		}
+	}
+
+	for k, d := range dists {
+		if wh := whs[k.w]; wh != nil {
+			wh.hSum += d.hSum
+		}
+	}
+
+	return whs, dists, nil
+}
+
+// violations accumulates one condition's offending keys, keeping the first
+// (in key order) as the report's example.
+type violations struct {
+	n       int64
+	example string
+}
+
+func (v *violations) add(format string, args ...any) {
+	if v.n == 0 {
+		v.example = fmt.Sprintf(format, args...)
+	}
+
+	v.n++
+}
+
+func (v *violations) check(condition, description, unit string, total int) ConsistencyCheck {
+	c := ConsistencyCheck{Condition: condition, Description: description, Status: consistencyPass}
+	if v.n > 0 {
+		c.Status, c.Violations = consistencyFail, v.n
+		c.Detail = fmt.Sprintf("%d of %d %s; e.g. %s", v.n, total, unit, v.example)
+	}
+
+	return c
+}
+
+// aggregateChecks runs CC1–CC4, CC8 and CC9 against the fetched aggregates.
+// With nil inputs it returns the bare condition list (all passing).
+func aggregateChecks(whs map[int64]*warehouseAudit, dists map[districtKey]*districtAudit) []ConsistencyCheck {
+	var cc1, cc2, cc3, cc4, cc8, cc9 violations
+
+	dSum := map[int64]float64{}
+	for k, d := range dists {
+		dSum[k.w] += d.ytd
+	}
+
+	for _, w := range slices.Sorted(maps.Keys(whs)) {
+		wh := whs[w]
+		if absf(wh.ytd-dSum[w]) > moneyTolerance {
+			cc1.add("warehouse %d: W_YTD = %.2f, sum(D_YTD) = %.2f", w, wh.ytd, dSum[w])
+		}
+
+		if want := warehouseYTD + wh.hSum; absf(wh.ytd-want) > moneyTolerance {
+			cc8.add("warehouse %d: W_YTD = %.2f, %.2f paid", w, wh.ytd, want)
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(dists), func(a, b districtKey) int {
+		return cmp.Or(cmp.Compare(a.w, b.w), cmp.Compare(a.d, b.d))
+	})
+
+	for _, k := range keys {
+		d := dists[k]
+		if d.nextOID-1 != d.oMax || (d.noCnt > 0 && d.noMax != d.oMax) {
+			cc2.add("district %s: D_NEXT_O_ID = %d, max(O_ID) = %d, max(NO_O_ID) = %d", k, d.nextOID, d.oMax, d.noMax)
+		}
+
+		if d.noCnt > 0 && d.noMax-d.noMin+1 != d.noCnt {
+			cc3.add("district %s: NO_O_ID %d..%d holds %d rows", k, d.noMin, d.noMax, d.noCnt)
+		}
+
+		if d.olCnt != d.olRows {
+			cc4.add("district %s: sum(O_OL_CNT) = %d, %d order lines", k, d.olCnt, d.olRows)
+		}
+
+		if want := districtYTD + d.hSum; absf(d.ytd-want) > moneyTolerance {
+			cc9.add("district %s: D_YTD = %.2f, %.2f paid", k, d.ytd, want)
+		}
+	}
+
+	return []ConsistencyCheck{
+		cc1.check("CC1", "W_YTD = sum(D_YTD)", "warehouses", len(whs)),
+		cc2.check("CC2", "D_NEXT_O_ID - 1 = max(O_ID) = max(NO_O_ID)", "districts", len(dists)),
+		cc3.check("CC3", "NEW_ORDER ids contiguous per district", "districts", len(dists)),
+		cc4.check("CC4", "sum(O_OL_CNT) = count(ORDER_LINE) per district", "districts", len(dists)),
+		cc8.check("CC8", "W_YTD = sum(H_AMOUNT) per warehouse", "warehouses", len(whs)),
+		cc9.check("CC9", "D_YTD = sum(H_AMOUNT) per district", "districts", len(dists)),
+	}
+}
+
+// deliveredAmounts sums delivered order-line amounts per customer (CC10/CC12).
+const deliveredAmounts = "SELECT o.o_w_id AS w_id, o.o_d_id AS d_id, o.o_c_id AS c_id, SUM(l.ol_amount) AS amount " +
+	"FROM orders AS o JOIN order_line AS l " +
+	"ON l.ol_w_id = o.o_w_id AND l.ol_d_id = o.o_d_id AND l.ol_o_id = o.o_id " +
+	"WHERE o.o_w_id %[1]s AND l.ol_delivery_d IS NOT NULL GROUP BY o.o_w_id, o.o_d_id, o.o_c_id"
+
+var sqlChecks = []sqlCheck{
+	{"CC5", "O_CARRIER_ID is null iff a NEW_ORDER row exists", "orders", func(wRange string) string {
+		return fmt.Sprintf("SELECT COUNT(*) FROM orders AS o LEFT JOIN new_order AS n "+
+			"ON n.no_w_id = o.o_w_id AND n.no_d_id = o.o_d_id AND n.no_o_id = o.o_id "+
+			"WHERE o.o_w_id %s AND ((o.o_carrier_id IS NULL AND n.no_o_id IS NULL) "+
+			"OR (o.o_carrier_id IS NOT NULL AND n.no_o_id IS NOT NULL))", wRange)
+	}},
+	{"CC6", "O_OL_CNT = count(ORDER_LINE) per order", "orders", func(wRange string) string {
+		return fmt.Sprintf("SELECT COUNT(*) FROM orders AS o LEFT JOIN "+
+			"(SELECT ol_w_id, ol_d_id, ol_o_id, COUNT(*) AS cnt FROM order_line WHERE ol_w_id %[1]s "+
+			"GROUP BY ol_w_id, ol_d_id, ol_o_id) AS l "+
+			"ON l.ol_w_id = o.o_w_id AND l.ol_d_id = o.o_d_id AND l.ol_o_id = o.o_id "+
+			"WHERE o.o_w_id %[1]s AND (l.cnt IS NULL OR l.cnt <> o.o_ol_cnt)", wRange)
+	}},
+	{"CC7", "OL_DELIVERY_D is null iff O_CARRIER_ID is null", "order lines", func(wRange string) string {
+		return fmt.Sprintf("SELECT COUNT(*) FROM order_line AS l JOIN orders AS o "+
+			"ON o.o_w_id = l.ol_w_id AND o.o_d_id = l.ol_d_id AND o.o_id = l.ol_o_id "+
+			"WHERE l.ol_w_id %s AND ((o.o_carrier_id IS NULL AND l.ol_delivery_d IS NOT NULL) "+
+			"OR (o.o_carrier_id IS NOT NULL AND l.ol_delivery_d IS NULL))", wRange)
+	}},
+	{"CC10", "C_BALANCE = sum(delivered OL_AMOUNT) - sum(H_AMOUNT)", "customers", func(wRange string) string {
+		return fmt.Sprintf("SELECT COUNT(*) FROM customer AS c "+
+			"LEFT JOIN ("+deliveredAmounts+") AS dl "+
+			"ON dl.w_id = c.c_w_id AND dl.d_id = c.c_d_id AND dl.c_id = c.c_id "+
+			"LEFT JOIN (SELECT h_c_w_id, h_c_d_id, h_c_id, SUM(h_amount) AS amount FROM history "+
+			"WHERE h_c_w_id %[1]s GROUP BY h_c_w_id, h_c_d_id, h_c_id) AS h "+
+			"ON h.h_c_w_id = c.c_w_id AND h.h_c_d_id = c.c_d_id AND h.h_c_id = c.c_id "+
+			"WHERE c.c_w_id %[1]s AND "+
+			"ABS(c.c_balance - (COALESCE(dl.amount, 0) - COALESCE(h.amount, 0) - %[2]g)) > %[3]g",
+			wRange, customerYtdPayment, moneyTolerance)
+	}},
+	{"CC12", "C_BALANCE + C_YTD_PAYMENT = sum(delivered OL_AMOUNT)", "customers", func(wRange string) string {
+		return fmt.Sprintf("SELECT COUNT(*) FROM customer AS c "+
+			"LEFT JOIN ("+deliveredAmounts+") AS dl "+
+			"ON dl.w_id = c.c_w_id AND dl.d_id = c.c_d_id AND dl.c_id = c.c_id "+
+			"WHERE c.c_w_id %[1]s AND ABS(c.c_balance + c.c_ytd_payment - COALESCE(dl.amount, 0)) > %[2]g",
+			wRange, moneyTolerance)
+	}},
+}
+
+func runSQLCheck(ctx context.Context, b *bench.Bench, sc sqlCheck, wRange string) ConsistencyCheck {
+	c := ConsistencyCheck{Condition: sc.condition, Description: sc.description, Status: consistencyPass}
+
+	n, err := qint(ctx, b, sc.query(wRange))
+
+	switch {
+	case err != nil:
+		c.Status, c.Detail = consistencyError, err.Error()
+	case n > 0:
+		c.Status, c.Violations = consistencyFail, n
+		c.Detail = fmt.Sprintf("%d %s", n, sc.unit)
+	}
+
+	return c
+}
@@ -0,0 +1,143 @@
+package tpcc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// populated returns the aggregates of a freshly loaded single-warehouse
+// population with one payment of amount into district 1 (mirrored into W_YTD,
+// D_YTD and history the way Payment writes them).
+func populated(amount float64) (map[int64]*warehouseAudit, map[districtKey]*districtAudit) {
+	whs := map[int64]*warehouseAudit{1: {ytd: warehouseYTD + amount, hSum: amount}}
+	dists := map[districtKey]*districtAudit{}
+
+	for d := int64(1); d <= districtsPerWarehouse; d++ {
+		dists[districtKey{1, d}] = &districtAudit{
+			ytd:     districtYTD,
+			nextOID: districtNextOID,
+			oMax:    customersPerDistrict,
+			olCnt:   customersPerDistrict * olCntFixed,
+			olRows:  customersPerDistrict * olCntFixed,
+			noMax:   customersPerDistrict,
+			noMin:   ordersDelivered + 1,
+			noCnt:   ordersUndelivered,
+		}
+	}
+
+	dists[districtKey{1, 1}].ytd += amount
+	dists[districtKey{1, 1}].hSum = amount
+
+	return whs, dists
+}
+
+func statuses(checks []ConsistencyCheck) map[string]string {
+	got := map[string]string{}
+	for _, c := range checks {
+		got[c.Condition] = c.Status
+	}
+
+	return got
+}
+
+func TestAggregateChecksPassOnConsistentPopulation(t *testing.T) {
+	whs, dists := populated(42.5)
+
+	checks := aggregateChecks(whs, dists)
+	if len(checks) != 6 {
+		t.Fatalf("got %d checks, want 6", len(checks))
+	}
+
+	for _, c := range checks {
+		if c.Status != consistencyPass {
+			t.Errorf("%s %s: %s", c.Condition, c.Status, c.Detail)
+		}
+	}
+}
+
+func TestAggregateChecksCatchLostUpdates(t *testing.T) {
+	whs, dists := populated(42.5)
+
+	// A Payment whose district update was lost: W_YTD and history moved, D_YTD didn't.
+	dists[districtKey{1, 3}].hSum = 10
+	whs[1].ytd += 10
+	whs[1].hSum += 10
+
+	// Two New-Orders that both claimed D_NEXT_O_ID 3001.
+	dists[districtKey{1, 7}].nextOID++
+	dists[districtKey{1, 7}].olCnt += 10
+
+	// A Delivery that removed a new_order row from the middle of the queue.
+	dists[districtKey{1, 9}].noCnt--
+
+	got := statuses(aggregateChecks(whs, dists))
+	want := map[string]string{
+		"CC1": consistencyFail, "CC2": consistencyFail, "CC3": consistencyFail,
+		"CC4": consistencyFail, "CC8": consistencyPass, "CC9": consistencyFail,
+	}
+
+	for cond, status := range want {
+		if got[cond] != status {
+			t.Errorf("%s = %s, want %s", cond, got[cond], status)
+		}
+	}
+
+	for _, c := range aggregateChecks(whs, dists) {
+		if c.Condition == "CC2" && (c.Violations != 1 || !strings.Contains(c.Detail, "district 1/7")) {
+			t.Fatalf("CC2 = %+v", c)
+		}
+	}
+}
+
+func TestConsistentVerdict(t *testing.T) {
+	pass := ConsistencyCheck{Status: consistencyPass}
+	skip := ConsistencyCheck{Status: consistencySkipped}
+	fail := ConsistencyCheck{Status: consistencyFail}
+	errd := ConsistencyCheck{Status: consistencyError}
+
+	if v := consistent(nil); v != nil {
+		t.Fatalf("no checks = %v, want nil", *v)
+	}
+
+	if v := consistent([]ConsistencyCheck{pass, skip}); v == nil || !*v {
+		t.Fatal("passing audit is not consistent")
+	}
+
+	if v := consistent([]ConsistencyCheck{errd, fail}); v == nil || *v {
+		t.Fatal("a failure must outweigh an error")
+	}
+
+	if v := consistent([]ConsistencyCheck{pass, errd}); v != nil {
+		t.Fatalf("errored audit = %v, want nil", *v)
+	}
+}
+
+func TestReportTextIncludesConsistency(t *testing.T) {
+	checks := aggregateChecks(populated(0))
+	checks[2].Status, checks[2].Detail = consistencyFail, "1 of 10 districts"
+
+	report := Report{Workload: "tpcc/tx", Consistent: consistent(checks), Consistency: checks}
+
+	for _, want := range []string{"consistency (§3.3.2): FAIL", "CC3", "FAIL — 1 of 10 districts"} {
+		if !strings.Contains(report.text(), want) {
+			t.Fatalf("text() missing %q:\n%s", want, report.text())
+		}
+	}
+}
+
+func TestAuditErrorNamesFailedConditions(t *testing.T) {
+	checks := aggregateChecks(populated(0))
+	if err := auditError(checks); err != nil {
+		t.Fatalf("passing audit: %v", err)
+	}
+
+	checks[0].Status, checks[0].Detail = consistencyFail, "1 of 1 warehouses"
+	checks[1].Status = consistencyError
+
+	err := auditError(checks)
+	if !errors.Is(err, errValidateConsistency) || !strings.Contains(err.Error(), "1 condition(s) failed") ||
+		!strings.Contains(err.Error(), "CC1 W_YTD = sum(D_YTD): 1 of 1 warehouses") {
+		t.Fatalf("auditError = %v", err)
+	}
+}
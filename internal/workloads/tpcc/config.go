@@ -46,7 +46,7 @@ var (
 // txMix is the §5.2.3 weighted dispatch: new_order/payment/order_status/delivery/stock_level.
 var txWeights = []int{45, 43, 4, 4, 4}
 
-// resolveIsolation mirrors tpcc_common: pg/mysql→repeatable_read, pico→none, ydb→serializable.
+// resolveIsolation mirrors tpcc_common: pg/mysql→repeatable_read, pico→none, ydb/sqlite→serializable.
 func resolveIsolation(dt bench.DriverTypeName, override bench.TxIsolationName) bench.TxIsolationName {
 	if override != "" {
 		return override
@@ -55,7 +55,7 @@ func resolveIsolation(dt bench.DriverTypeName, override bench.TxIsolationName) b
 	switch dt {
 	case bench.DriverPicodata:
 		return bench.IsoNone
-	case bench.DriverYDB:
+	case bench.DriverYDB, bench.DriverSQLite:
 		return bench.IsoSerializable
 	default: // postgres, mysql
 		return bench.IsoRepeatableRead
@@ -74,6 +74,8 @@ func sqlFile(dt bench.DriverTypeName, override string) string {
 		return "pico.sql"
 	case bench.DriverYDB:
 		return "ydb.sql"
+	case bench.DriverSQLite:
+		return "sqlite.sql"
 	default:
 		return "pg.sql"
 	}
@@ -128,6 +128,7 @@ func TestDriverDerivedDefaults(t *testing.T) {
 		{bench.DriverMySQL, bench.IsoRepeatableRead},
 		{bench.DriverPicodata, bench.IsoNone},
 		{bench.DriverYDB, bench.IsoSerializable},
+		{bench.DriverSQLite, bench.IsoSerializable},
 	}
 	for _, tt := range isolationTests {
 		if got := resolveIsolation(tt.driver, ""); got != tt.want {
@@ -147,6 +148,7 @@ func TestDriverDerivedDefaults(t *testing.T) {
 		{bench.DriverMySQL, "mysql.sql"},
 		{bench.DriverPicodata, "pico.sql"},
 		{bench.DriverYDB, "ydb.sql"},
+		{bench.DriverSQLite, "sqlite.sql"},
 	}
 	for _, tt := range sqlTests {
 		if got := sqlFile(tt.driver, ""); got != tt.want {
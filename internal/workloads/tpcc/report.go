@@ -163,6 +163,11 @@ type Report struct {
 	Statistical          Statistical `json:"statistical"`
 	Steadiness           Steadiness  `json:"steadiness"`
 	Note                 string      `json:"note,omitempty"`
+
+	// Consistent and Consistency carry the post-run §3.3.2 audit; both are absent
+	// when validate_consistency did not run.
+	Consistent  *bool              `json:"consistent,omitempty"`
+	Consistency []ConsistencyCheck `json:"consistency,omitempty"`
 }
 
 // TxReport is the per-transaction-type observation and, when applicable, its
@@ -580,6 +585,8 @@ func (r *Report) text() string {
 		fmt.Fprintln(&b)
 	}
 
+	r.consistencyText(&b)
+
 	if !r.ComplianceApplicable {
 		return b.String()
 	}
@@ -611,6 +618,25 @@ func (r *Report) text() string {
 	return b.String()
 }
 
+// consistencyText renders the post-run audit, one line per condition.
+func (r *Report) consistencyText(b *strings.Builder) {
+	if len(r.Consistency) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "consistency (§3.3.2): %s\n", passFail(r.Consistent))
+
+	for _, c := range r.Consistency {
+		fmt.Fprintf(b, "  %-4s %-52s %s", c.Condition, c.Description, strings.ToUpper(c.Status))
+
+		if c.Detail != "" {
+			fmt.Fprintf(b, " — %s", c.Detail)
+		}
+
+		fmt.Fprintln(b)
+	}
+}
+
 // passFail renders a verdict pointer as PASS, FAIL, or - when absent.
 func passFail(v *bool) string {
 	switch {
@@ -627,7 +653,7 @@ func passFail(v *bool) string {
 // report, and writes the human block to stderr plus one JSON line to stdout.
 // Emitting is report-only: a non-compliant or unavailable report never changes the
 // process exit status.
-func (w *workload) emitComplianceReport(b *bench.Bench) {
+func (w *workload) emitComplianceReport(b *bench.Bench, consistency []ConsistencyCheck) {
 	metrics, err := b.CollectedMetrics()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "tpcc: compliance report skipped: %v\n", err)
@@ -673,6 +699,8 @@ func (w *workload) emitComplianceReport(b *bench.Bench) {
 		return
 	}
 
+	report.Consistent, report.Consistency = consistent(consistency), consistency
+
 	fmt.Fprint(os.Stderr, report.text())
 
 	if err := json.NewEncoder(os.Stdout).Encode(map[string]Report{"compliance": report}); err != nil {
@@ -0,0 +1,69 @@
+package tpcc
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	_ "github.com/stroppy-io/stroppy/pkg/driver/sqlite"
+)
+
+// TestSQLiteEndToEnd runs the whole tpcc/tx lifecycle against an embedded
+// SQLite file: the load, validate_population, the five-transaction mix and
+// the post-run validate_consistency audit, opted into through the step list,
+// all have to pass.
+func TestSQLiteEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads a full warehouse")
+	}
+
+	unsetEnv(
+		t,
+		"SCALE_FACTOR", "WAREHOUSES", "RETRY_ATTEMPTS", "TX_ISOLATION", "SQL_FILE", "LOAD_WORKERS",
+		"EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION",
+	)
+
+	t.Setenv("STROPPY_NO_STEPS", "")
+	t.Setenv("STROPPY_STEPS", "drop_schema,create_schema,load_data,create_indexes,create_foreign_keys,"+
+		"analyze,validate_population,workload,validate_consistency")
+
+	path := filepath.Join(t.TempDir(), "tpcc.db")
+
+	err := bench.Run(
+		context.Background(),
+		"tpcc/tx",
+		map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_SQLITE, Url: path}},
+		nil,
+		bench.ParamInputs{CLI: map[string]string{
+			"load-workers": "2",
+			"executor":     "shared-iterations",
+			"vus":          "4",
+			"iterations":   "300",
+		}},
+		zap.NewNop(),
+		&bench.MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("tpcc/tx sqlite run: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var orders int64
+	if err := db.QueryRow("SELECT COUNT(*) FROM orders").Scan(&orders); err != nil {
+		t.Fatal(err)
+	}
+
+	if orders <= 30000 {
+		t.Fatalf("orders = %d, want the 30000 loaded plus the run's New-Orders", orders)
+	}
+}
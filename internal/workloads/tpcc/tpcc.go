@@ -24,7 +24,7 @@ import (
 var txNames = []string{"new_order", "payment", "order_status", "delivery", "stock_level"}
 
 var (
-	errProcsDriverUnsupported   = errors.New("tpcc/procs only supports postgres and mysql; use tpcc/tx for picodata/ydb")
+	errProcsDriverUnsupported   = errors.New("tpcc/procs only supports postgres and mysql; use tpcc/tx for picodata/ydb/sqlite")
 	errDistrictNotFound         = errors.New("new_order: district not found")
 	errNewOrderCustomerMissing  = errors.New("new_order: customer not found")
 	errNewOrderWarehouseMissing = errors.New("new_order: warehouse not found")
@@ -106,7 +106,7 @@ func (w *workload) Define(d *bench.Def) error {
 	w.retryAttempts = d.Param.Int("retry-attempts", 3, "Maximum transaction attempts.").Value()
 	w.pgUnlogged = d.Param.Bool("pg-unlogged", false, "Use unlogged PostgreSQL tables while loading.").Value()
 	w.iso = bench.TxIsolationName(d.Param.String("tx-isolation", "", "Transaction isolation override.").Value())
-	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.").Value()
+	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.", bench.LocalPath()).Value()
 	w.loadWorkers = d.Param.Int("load-workers", 1, "Workers used to load each table.").Value()
 	w.loadWorkers = max(w.loadWorkers, 1)
 
@@ -142,7 +142,9 @@ func (w *workload) renderDDL(s string) string {
 
 func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
 	w.driverType = b.DriverTypeName()
-	if w.variant == "procs" && (w.driverType == bench.DriverPicodata || w.driverType == bench.DriverYDB) {
+	procsless := w.driverType == bench.DriverPicodata || w.driverType == bench.DriverYDB ||
+		w.driverType == bench.DriverSQLite
+	if w.variant == "procs" && procsless {
 		return errProcsDriverUnsupported
 	}
 
@@ -237,7 +239,8 @@ func (w *workload) initConfig() {
 	w.sql = mustLoadSQL(w.driverType, w.sqlFile)
 	w.isPicodata = w.driverType == bench.DriverPicodata
 	w.isYdb = w.driverType == bench.DriverYDB
-	w.hasReturning = w.driverType == bench.DriverPostgres || w.driverType == bench.DriverYDB
+	w.hasReturning = w.driverType == bench.DriverPostgres || w.driverType == bench.DriverYDB ||
+		w.driverType == bench.DriverSQLite
 }
 
 // initMetrics wires the per-transaction counters and duration trends.
@@ -346,10 +349,27 @@ func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
 	})
 }
 
-func (w *workload) Teardown(_ context.Context, b *bench.Bench) error {
-	w.emitComplianceReport(b)
+// Teardown emits the compliance report and, when --steps selects it, audits the
+// post-run population first (validate_consistency). The audit is opt-in: it
+// scans every table, and a measured run should not fail on it unless asked. A
+// failed condition fails the run once the report is out; a Setup that never
+// finished has nothing to audit.
+func (w *workload) Teardown(ctx context.Context, b *bench.Bench) error {
+	var (
+		consistency []ConsistencyCheck
+		err         error
+	)
+
+	if !w.measureStart.IsZero() {
+		err = b.StepOptIn("validate_consistency", func() error {
+			consistency = auditConsistency(ctx, b, w.warehouseStart, w.wIDMax)
+			return auditError(consistency)
+		})
+	}
 
-	return nil
+	w.emitComplianceReport(b, consistency)
+
+	return err
 }
 
 // recordSteady buckets a New-Order completion into the paced steady-state time
@@ -1063,9 +1083,9 @@ func (w *workload) delivery(ctx context.Context, b *bench.Bench, vs *vuState) er
 					return err
 				}
 
-				olTotal := int64(0)
+				olTotal := 0.0
 				if len(sumRow) > 0 && sumRow[0] != nil {
-					olTotal = toInt64(sumRow[0])
+					olTotal = toFloat64(sumRow[0])
 				}
 
 				if err := tx.Exec(ctx, w.q("workload_tx_delivery", "update_customer"), map[string]any{
@@ -0,0 +1,214 @@
+package tpcds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/datagen/tpcdsgen"
+)
+
+// Data maintenance modes. none keeps the historical query-only iteration;
+// sequential runs one refresh set after VU 1's query stream (the spec's
+// throughput test / data maintenance / throughput test order over
+// iterations); concurrent applies refresh sets back to back for as long as
+// VU 1's query stream runs, to measure query degradation under maintenance.
+const (
+	maintenanceNone       = "none"
+	maintenanceSequential = "sequential"
+	maintenanceConcurrent = "concurrent"
+)
+
+var (
+	errUnknownMaintenance           = errors.New("MAINTENANCE must be none, sequential or concurrent")
+	errMaintenanceSetMustBePositive = errors.New("MAINTENANCE_SET must be >= 1")
+	errMaintenanceSQLMissing        = errors.New("data maintenance function has no SQL in the dialect file")
+)
+
+// specMaintenance reports whether runMaintenance applies the spec's data
+// maintenance. It does not: it applies a refresh load of fact rows generated
+// past the loaded tickets. The spec's version needs dsdgen's s_* update-set
+// tables, the delete.dat date ranges and the inventory LF_I/DF_I, none of
+// which the generator port has, so full mode reports no QphDS.
+const specMaintenance = false
+
+// loadFunctions are the LF_* fact-table inserts, each sales table before its
+// returns. Inventory (LF_I/DF_I) is not part of the port.
+var loadFunctions = [...]struct{ name, table string }{
+	{"lf_ss", "store_sales"}, {"lf_sr", "store_returns"},
+	{"lf_cs", "catalog_sales"}, {"lf_cr", "catalog_returns"},
+	{"lf_ws", "web_sales"}, {"lf_wr", "web_returns"},
+}
+
+// deleteFunctions are the DF_* sales-channel deletes; each names its section
+// in the query SQL file.
+var deleteFunctions = [...]string{"df_ss", "df_cs", "df_ws"}
+
+type functionMetrics struct {
+	duration *bench.Metric
+	runs     *bench.Metric
+	errors   *bench.Metric
+}
+
+// initMaintenanceMetrics wires duration/runs/errors for every maintenance
+// function.
+func initMaintenanceMetrics(b *bench.Bench) map[string]*functionMetrics {
+	m := make(map[string]*functionMetrics, len(loadFunctions)+len(deleteFunctions))
+
+	names := make([]string, 0, len(loadFunctions)+len(deleteFunctions))
+	for _, lf := range loadFunctions {
+		names = append(names, lf.name)
+	}
+
+	for _, name := range append(names, deleteFunctions[:]...) {
+		m[name] = &functionMetrics{
+			duration: b.Trend("tpcds_" + name + "_duration"),
+			runs:     b.Counter("tpcds_" + name + "_runs"),
+			errors:   b.Counter("tpcds_" + name + "_errors"),
+		}
+	}
+
+	return m
+}
+
+// reserveMaintenanceSet hands out the next refresh set. VUs share the
+// workload, so no two maintenance runs insert the same tickets.
+func (w *workload) reserveMaintenanceSet() int {
+	return w.maintenanceSet + int(w.maintenanceSetsUsed.Add(1)) - 1
+}
+
+// runMaintenance applies refresh set `set`: every LF_* insert, then every
+// DF_* delete. A failed function is logged and counted; the rest still run.
+// It returns how many functions failed.
+func (w *workload) runMaintenance(ctx context.Context, b *bench.Bench, set int) int {
+	var failures int
+
+	for _, lf := range loadFunctions {
+		err := w.timeFunction(b, lf.name, set, func() error {
+			_, err := b.InsertTpcdsRefresh(ctx, lf.table, w.scaleFactor, set, w.loadWorkers)
+
+			return err
+		})
+		if err != nil {
+			failures++
+		}
+	}
+
+	windows, err := tpcdsgen.DeleteWindows(set)
+	if err != nil {
+		// Every DF_* function fails with the windows it could not get.
+		for _, name := range deleteFunctions {
+			_ = w.timeFunction(b, name, set, func() error { return err })
+		}
+
+		return failures + len(deleteFunctions)
+	}
+
+	for _, name := range deleteFunctions {
+		err := w.timeFunction(b, name, set, func() error { return w.deleteWindows(ctx, b, name, windows) })
+		if err != nil {
+			failures++
+		}
+	}
+
+	return failures
+}
+
+// deleteWindows runs one DF_* function: for each sold-date window, delete the
+// channel's returns of the sales in the window, then the sales, in one
+// transaction.
+func (w *workload) deleteWindows(
+	ctx context.Context, b *bench.Bench, name string, windows []tpcdsgen.DateWindow,
+) error {
+	deleteReturns, okReturns := w.querySQL.Query(name, "delete_returns")
+	deleteSales, okSales := w.querySQL.Query(name, "delete_sales")
+
+	if !okReturns || !okSales {
+		return fmt.Errorf("%w: %s", errMaintenanceSQLMissing, name)
+	}
+
+	for _, window := range windows {
+		args := map[string]any{"first_date_sk": window.First, "last_date_sk": window.Last}
+
+		err := b.BeginTx(ctx, bench.BeginOpts{Name: name}, func(tx *bench.TxX) error {
+			if err := tx.Exec(ctx, deleteReturns, args); err != nil {
+				return err
+			}
+
+			return tx.Exec(ctx, deleteSales, args)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// timeFunction runs one maintenance function, records it into its metrics
+// and logs the outcome.
+func (w *workload) timeFunction(b *bench.Bench, name string, set int, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	if fm := w.maintenanceMetrics[name]; fm != nil {
+		fm.runs.Add(1)
+		fm.duration.Add(float64(elapsed.Milliseconds()))
+
+		if err != nil {
+			fm.errors.Add(1)
+		}
+	}
+
+	if err != nil {
+		w.observed.fail()
+	}
+
+	lg := b.Logger().Sugar()
+	if err != nil {
+		lg.Infof("[tpcds] %s set %d: error in %dms %v", name, set, elapsed.Milliseconds(), err)
+	} else {
+		lg.Infof("[tpcds] %s set %d: ok in %dms", name, set, elapsed.Milliseconds())
+	}
+
+	return err
+}
+
+// runStreamUnderMaintenance runs queries while refresh sets are applied one
+// after another on the side; at least one set always completes. It returns
+// the query stream's elapsed time, the queries and maintenance functions
+// that failed, and the sets applied.
+func (w *workload) runStreamUnderMaintenance(
+	ctx context.Context, b *bench.Bench, stream string, queries []namedQuery,
+) (time.Duration, int, int) {
+	var (
+		wg                  sync.WaitGroup
+		sets, maintFailures int
+	)
+
+	done := make(chan struct{})
+
+	wg.Go(func() {
+		for {
+			maintFailures += w.runMaintenance(ctx, b, w.reserveMaintenanceSet())
+			sets++
+
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	})
+
+	elapsed, failures := w.runStream(ctx, b, stream, queries)
+
+	close(done)
+	wg.Wait()
+
+	return elapsed, failures + maintFailures, sets
+}
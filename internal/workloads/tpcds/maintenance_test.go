@@ -0,0 +1,51 @@
+package tpcds
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+func TestMaintenanceSQLSections(t *testing.T) {
+	for _, driver := range []bench.DriverTypeName{
+		bench.DriverPostgres, bench.DriverMySQL, bench.DriverPicodata, bench.DriverYDB,
+	} {
+		_, file := dialectFiles(driver, "", "")
+
+		sql, err := bench.LoadSQL(preset, file)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		for _, name := range deleteFunctions {
+			for _, query := range []string{"delete_returns", "delete_sales"} {
+				body, ok := sql.Query(name, query)
+				if !ok {
+					t.Fatalf("%s: missing %s/%s", file, name, query)
+				}
+
+				if !strings.Contains(body, ":first_date_sk") || !strings.Contains(body, ":last_date_sk") {
+					t.Fatalf("%s: %s/%s does not bind the date window", file, name, query)
+				}
+			}
+		}
+
+		// The maintenance sections must not leak into the query stream.
+		isDelete := func(name string) bool { return strings.HasPrefix(name, "delete_") }
+		if slices.ContainsFunc(sql.Names(""), isDelete) {
+			t.Fatalf("%s: delete statements listed as queries", file)
+		}
+	}
+}
+
+func TestReserveMaintenanceSet(t *testing.T) {
+	w := &workload{maintenanceSet: 4}
+
+	for want := 4; want <= 6; want++ {
+		if got := w.reserveMaintenanceSet(); got != want {
+			t.Fatalf("reserveMaintenanceSet() = %d, want %d", got, want)
+		}
+	}
+}
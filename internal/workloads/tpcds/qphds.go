@@ -0,0 +1,389 @@
+package tpcds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+// Run modes. queries keeps the historical one-stream-per-VU iteration; the
+// others run the spec's performance tests inside one iteration.
+const (
+	modeQueries    = "queries"
+	modePower      = "power"
+	modeThroughput = "throughput"
+	modeFull       = "full"
+)
+
+// Reasons a full run has no QphDS. qphdsNonSpecMaintenance holds until data
+// maintenance applies dsdgen's update sets (see specMaintenance).
+const (
+	qphdsNoLoad             = "load not measured (run the load_data step in the same run)"
+	qphdsNonSpecMaintenance = "data maintenance is a refresh load, not the spec's update sets"
+)
+
+// queryCount is the number of TPC-DS queries (Q in the QphDS metric). The
+// four two-part queries count once.
+const queryCount = 99
+
+// queryTiming is one statement's successful executions across every stream.
+type queryTiming struct {
+	Name          string  `json:"name"`
+	Runs          int     `json:"runs"`
+	MinSeconds    float64 `json:"min_seconds"`
+	MedianSeconds float64 `json:"median_seconds"`
+	MaxSeconds    float64 `json:"max_seconds"`
+}
+
+// performance is one iteration of a power, throughput or full run.
+type performance struct {
+	power       time.Duration
+	streams     int
+	throughput  []time.Duration // TT1, TT2
+	maintenance []time.Duration // DM1, DM2
+	failures    int             // queries and maintenance functions that failed
+}
+
+// observations collects every query execution and the last performance run
+// for the end-of-run report. VUs and streams record concurrently.
+type observations struct {
+	mu       sync.Mutex
+	names    []string // first-seen order
+	seconds  map[string][]float64
+	failures int
+	perf     *performance
+}
+
+func (o *observations) query(name string, elapsed time.Duration, failed bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.seconds == nil {
+		o.seconds = make(map[string][]float64)
+	}
+
+	if _, seen := o.seconds[name]; !seen {
+		o.names = append(o.names, name)
+		o.seconds[name] = nil
+	}
+
+	if failed {
+		o.failures++
+
+		return
+	}
+
+	o.seconds[name] = append(o.seconds[name], elapsed.Seconds())
+}
+
+func (o *observations) fail() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.failures++
+}
+
+func (o *observations) performance(perf performance) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.perf = &perf
+}
+
+// Report is the machine-readable TPC-DS end-of-run report. The timings of a
+// test that did not run are omitted. QphDSOmitted says why a full run has no
+// score.
+type Report struct {
+	Workload           string        `json:"workload"`
+	Mode               string        `json:"mode"`
+	ScaleFactor        float64       `json:"scale_factor"`
+	Queries            []queryTiming `json:"queries"`
+	LoadSeconds        *float64      `json:"load_seconds,omitempty"`
+	PowerSeconds       *float64      `json:"power_seconds,omitempty"`
+	Streams            int           `json:"streams,omitempty"`
+	ThroughputSeconds  []float64     `json:"throughput_seconds,omitempty"`
+	MaintenanceSeconds []float64     `json:"maintenance_seconds,omitempty"`
+	QphDS              *float64      `json:"qphds,omitempty"`
+	QphDSOmitted       string        `json:"qphds_omitted,omitempty"`
+	Failures           int           `json:"failures"`
+	Valid              bool          `json:"valid"`
+}
+
+// runPerformance runs the tests of the configured mode: the power test, then
+// throughput test 1 and, in full mode, data maintenance 1, throughput test 2
+// and data maintenance 2 (the spec's scored sequence after the load).
+func (w *workload) runPerformance(ctx context.Context, b *bench.Bench) error {
+	perf := performance{streams: w.streams}
+
+	if w.mode == modePower || w.mode == modeFull {
+		queries, stream, err := w.resolveQueries(b)
+		if err != nil {
+			return err
+		}
+
+		var failures int
+
+		perf.power, failures = w.runStream(ctx, b, stream, queries)
+		perf.failures += failures
+	}
+
+	if w.mode == modeThroughput || w.mode == modeFull {
+		if err := w.throughputTest(ctx, b, 1, &perf); err != nil {
+			return err
+		}
+	}
+
+	if w.mode == modeFull {
+		w.timedMaintenance(ctx, b, &perf)
+
+		if err := w.throughputTest(ctx, b, w.streams+1, &perf); err != nil {
+			return err
+		}
+
+		w.timedMaintenance(ctx, b, &perf)
+	}
+
+	w.observed.performance(perf)
+
+	return nil
+}
+
+// throughputTest runs w.streams generated query streams, first.., concurrently
+// and adds to perf the elapsed time from the first stream's start to the last
+// stream's end and the queries that failed. Streams are rendered before the
+// clock starts.
+func (w *workload) throughputTest(ctx context.Context, b *bench.Bench, first int, perf *performance) error {
+	streams := make([][]namedQuery, w.streams)
+
+	for i := range streams {
+		queries, err := generateStream(string(w.driver), w.scaleFactor, w.seed, first+i)
+		if err != nil {
+			return err
+		}
+
+		streams[i] = queries
+	}
+
+	var (
+		wg       sync.WaitGroup
+		failures atomic.Int64
+	)
+
+	start := time.Now()
+
+	for i, queries := range streams {
+		wg.Go(func() {
+			_, failed := w.runStream(ctx, b, strconv.Itoa(first+i), queries)
+			failures.Add(int64(failed))
+		})
+	}
+
+	wg.Wait()
+
+	perf.throughput = append(perf.throughput, time.Since(start))
+	perf.failures += int(failures.Load())
+
+	return nil
+}
+
+// timedMaintenance applies one refresh set and adds to perf how long it took
+// and the maintenance functions that failed.
+func (w *workload) timedMaintenance(ctx context.Context, b *bench.Bench, perf *performance) {
+	start := time.Now()
+	failures := w.runMaintenance(ctx, b, w.reserveMaintenanceSet())
+
+	perf.maintenance = append(perf.maintenance, time.Since(start))
+	perf.failures += failures
+}
+
+// emitReport writes the end-of-run report: text to stderr, JSON to stdout.
+// Runs that executed no query (load only) have nothing to report.
+func (w *workload) emitReport() {
+	report, ok := w.report()
+	if !ok {
+		return
+	}
+
+	fmt.Fprint(os.Stderr, report.text())
+
+	if err := json.NewEncoder(os.Stdout).Encode(map[string]Report{"qphds": report}); err != nil {
+		fmt.Fprintf(os.Stderr, "tpcds: report JSON output failed: %v\n", err)
+	}
+}
+
+func (w *workload) report() (Report, bool) {
+	o := &w.observed
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.names) == 0 && o.perf == nil {
+		return Report{}, false
+	}
+
+	report := Report{
+		Workload:    w.Name(),
+		Mode:        w.mode,
+		ScaleFactor: w.scaleFactor,
+		Queries:     make([]queryTiming, 0, len(o.names)),
+		Failures:    o.failures,
+		Valid:       o.failures == 0,
+	}
+
+	for _, name := range o.names {
+		report.Queries = append(report.Queries, timingOf(name, o.seconds[name]))
+	}
+
+	// A performance run is judged by its own tests, as TPC-H judges QphH.
+	if o.perf != nil {
+		report.Failures = o.perf.failures
+		report.Valid = o.perf.failures == 0
+	}
+
+	if w.loadElapsed > 0 {
+		load := w.loadElapsed.Seconds()
+		report.LoadSeconds = &load
+	}
+
+	if perf := o.perf; perf != nil {
+		if perf.power > 0 {
+			power := perf.power.Seconds()
+			report.PowerSeconds = &power
+		}
+
+		if len(perf.throughput) > 0 {
+			report.Streams = perf.streams
+		}
+
+		for _, elapsed := range perf.throughput {
+			report.ThroughputSeconds = append(report.ThroughputSeconds, elapsed.Seconds())
+		}
+
+		for _, elapsed := range perf.maintenance {
+			report.MaintenanceSeconds = append(report.MaintenanceSeconds, elapsed.Seconds())
+		}
+
+		switch {
+		case w.mode != modeFull:
+		case !specMaintenance:
+			report.QphDSOmitted = qphdsNonSpecMaintenance
+		case w.loadElapsed == 0:
+			report.QphDSOmitted = qphdsNoLoad
+		default:
+			qphds := qphdsAtSF(w.scaleFactor, perf, w.loadElapsed)
+			report.QphDS = &qphds
+		}
+	}
+
+	return report, true
+}
+
+// timingOf summarizes one statement's successful execution times.
+func timingOf(name string, seconds []float64) queryTiming {
+	timing := queryTiming{Name: name, Runs: len(seconds)}
+	if len(seconds) == 0 {
+		return timing
+	}
+
+	sorted := slices.Clone(seconds)
+	slices.Sort(sorted)
+
+	timing.MinSeconds = sorted[0]
+	timing.MaxSeconds = sorted[len(sorted)-1]
+
+	if mid := len(sorted) / 2; len(sorted)%2 == 1 {
+		timing.MedianSeconds = sorted[mid]
+	} else {
+		timing.MedianSeconds = (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return timing
+}
+
+// qphdsAtSF is the spec's QphDS@SF = SF × Q / ⁴√(T_PT × T_TT × T_DM × T_LD)
+// with Q = Sq × 99, T_PT = T_Power × Sq, T_TT = T_TT1 + T_TT2,
+// T_DM = T_DM1 + T_DM2 and T_LD = 0.01 × Sq × T_Load, all in hours. Each
+// interval is rounded up to 0.1 s. The score needs all four terms, so a run
+// without a measured load gets none.
+func qphdsAtSF(sf float64, perf *performance, load time.Duration) float64 {
+	streams := float64(perf.streams)
+	hours := func(elapsed time.Duration) float64 {
+		return max(math.Ceil(elapsed.Seconds()*10)/10, 0.1) / 3600
+	}
+
+	var tt, dm float64
+	for _, elapsed := range perf.throughput {
+		tt += hours(elapsed)
+	}
+
+	for _, elapsed := range perf.maintenance {
+		dm += hours(elapsed)
+	}
+
+	terms := []float64{hours(perf.power) * streams, tt, dm, 0.01 * streams * hours(load)}
+
+	var logSum float64
+	for _, term := range terms {
+		logSum += math.Log(term)
+	}
+
+	return math.Floor(sf * streams * queryCount / math.Exp(logSum/float64(len(terms))))
+}
+
+// text renders the human-readable report block written to stderr.
+func (r *Report) text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== TPC-DS report (%s, mode=%s, SF=%g) ===\n", r.Workload, r.Mode, r.ScaleFactor)
+
+	if len(r.Queries) > 0 {
+		fmt.Fprintf(&b, "%-12s %5s %10s %10s %10s\n", "query", "runs", "min", "median", "max")
+
+		for _, q := range r.Queries {
+			fmt.Fprintf(&b, "%-12s %5d %9.3fs %9.3fs %9.3fs\n",
+				q.Name, q.Runs, q.MinSeconds, q.MedianSeconds, q.MaxSeconds)
+		}
+	}
+
+	if r.LoadSeconds != nil {
+		fmt.Fprintf(&b, "load:        %10.1fs\n", *r.LoadSeconds)
+	}
+
+	if r.PowerSeconds != nil {
+		fmt.Fprintf(&b, "power test:  %10.1fs\n", *r.PowerSeconds)
+	}
+
+	for i, seconds := range r.ThroughputSeconds {
+		fmt.Fprintf(&b, "throughput %d: %9.1fs (%d streams)\n", i+1, seconds, r.Streams)
+	}
+
+	for i, seconds := range r.MaintenanceSeconds {
+		fmt.Fprintf(&b, "maintenance %d: %8.1fs\n", i+1, seconds)
+	}
+
+	if r.QphDS != nil {
+		fmt.Fprintf(&b, "QphDS@SF:    %10.0f\n", *r.QphDS)
+	}
+
+	if r.QphDSOmitted != "" {
+		fmt.Fprintf(&b, "QphDS@SF:    not computed, %s\n", r.QphDSOmitted)
+	}
+
+	if r.Valid {
+		fmt.Fprintln(&b, "result: VALID (every query and maintenance function succeeded)")
+	} else {
+		fmt.Fprintf(&b, "result: INVALID — %d queries or maintenance functions failed\n", r.Failures)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,96 @@
+package tpcds
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimingOf(t *testing.T) {
+	odd := timingOf("query_1", []float64{3, 1, 2})
+	if odd.Runs != 3 || odd.MinSeconds != 1 || odd.MedianSeconds != 2 || odd.MaxSeconds != 3 {
+		t.Fatalf("odd timing = %+v", odd)
+	}
+
+	even := timingOf("query_2", []float64{4, 1, 2, 3})
+	if even.MedianSeconds != 2.5 {
+		t.Fatalf("even median = %v, want 2.5", even.MedianSeconds)
+	}
+
+	if none := timingOf("query_3", nil); none.Runs != 0 || none.MaxSeconds != 0 {
+		t.Fatalf("empty timing = %+v", none)
+	}
+}
+
+func TestQphDSAtSF(t *testing.T) {
+	perf := &performance{
+		power:       time.Hour,
+		streams:     4,
+		throughput:  []time.Duration{2 * time.Hour, 2 * time.Hour},
+		maintenance: []time.Duration{30 * time.Minute, 30 * time.Minute},
+	}
+
+	// T_PT = 4 h, T_TT = 4 h, T_DM = 1 h, T_LD = 0.01 × 4 × 25 h = 1 h:
+	// ⁴√16 = 2, so QphDS = 100 × 4 × 99 / 2.
+	if got := qphdsAtSF(100, perf, 25*time.Hour); got != 19800 {
+		t.Fatalf("QphDS = %v, want 19800", got)
+	}
+
+}
+
+func TestReport(t *testing.T) {
+	w := &workload{mode: modeFull, scaleFactor: 1}
+	if _, ok := w.report(); ok {
+		t.Fatal("a run with no queries must not report")
+	}
+
+	w.observed.query("query_1", time.Second, false)
+	w.observed.query("query_1", 3*time.Second, false)
+	w.observed.query("query_2", time.Second, true)
+	w.observed.performance(performance{
+		power:       time.Minute,
+		streams:     2,
+		throughput:  []time.Duration{time.Minute, time.Minute},
+		maintenance: []time.Duration{time.Second, time.Second},
+		failures:    1,
+	})
+
+	report, ok := w.report()
+	if !ok {
+		t.Fatal("no report")
+	}
+
+	if len(report.Queries) != 2 || report.Queries[0].Runs != 2 || report.Queries[1].Runs != 0 {
+		t.Fatalf("queries = %+v", report.Queries)
+	}
+
+	if report.Valid || report.Failures != 1 {
+		t.Fatalf("valid=%t failures=%d, want invalid with 1 failure", report.Valid, report.Failures)
+	}
+
+	if report.PowerSeconds == nil || len(report.ThroughputSeconds) != 2 {
+		t.Fatalf("performance timings missing: %+v", report)
+	}
+
+	// Full mode times the spec sequence but scores nothing while data
+	// maintenance is not the spec's, measured load or not.
+	for _, load := range []time.Duration{0, time.Hour} {
+		w.loadElapsed = load
+
+		report, _ = w.report()
+		if report.QphDS != nil || report.QphDSOmitted != qphdsNonSpecMaintenance {
+			t.Fatalf("load %v: a run over non-spec data maintenance must not score: %+v", load, report)
+		}
+	}
+
+	if text := report.text(); !strings.Contains(text, "not computed") || !strings.Contains(text, "INVALID") {
+		t.Fatalf("text report:\n%s", text)
+	}
+
+	// The scored run's failures decide validity, maintenance functions included.
+	w.observed.performance(performance{power: time.Minute, streams: 1, failures: 2})
+
+	if report, _ = w.report(); report.Valid || report.Failures != 2 {
+		t.Fatalf("valid=%t failures=%d, want the performance run's 2 failures", report.Valid, report.Failures)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/stroppy-io/stroppy/pkg/answers"
 	"github.com/stroppy-io/stroppy/pkg/bench"
 	"github.com/stroppy-io/stroppy/third_party/gotpcds/dsqgen"
 )
@@ -14,8 +17,11 @@ import (
 var (
 	errScaleFactorMustBePositive = errors.New("SCALE_FACTOR must be positive")
 	errUnknownDialect            = errors.New("dsqgen: unknown dialect")
+	errUnknownMode               = errors.New("MODE must be queries, power, throughput or full")
+	errStreamsMustBePositive     = errors.New("STREAMS must be >= 1")
 	errYdbBakedOnly              = errors.New("[tpcds] ydb supports the baked query set (power test) only; " +
-		"STREAMS>1 and QUERY_STREAM need the in-process generator, which does not target YQL yet")
+		"STREAMS>1, QUERY_STREAM and the throughput/full modes need the in-process generator, " +
+		"which does not target YQL yet")
 )
 
 type workload struct {
@@ -32,11 +38,25 @@ type workload struct {
 	schemaFile    string
 	sqlFile       string
 	validateForce bool
+	dumpAnswers   string
 
+	mode       string
 	throughput bool
 	streams    int
 	seed       int64
 	genStream  int // QUERY_STREAM value (<0 = unset → baked)
+
+	maintenance         string
+	maintenanceSet      int
+	maintenanceSetsUsed atomic.Int64 // refresh sets handed out so far, see reserveMaintenanceSet
+	maintenanceMetrics  map[string]*functionMetrics
+
+	queryDuration *bench.Metric
+	queryRuns     *bench.Metric
+	queryErrors   *bench.Metric
+	loadElapsed   time.Duration // load_data step; 0 when it did not run
+	observed      observations
+	answers       *answers.Writer // nil unless --dump-answers
 }
 
 type namedQuery struct {
@@ -53,6 +73,8 @@ func (w *workload) Define(d *bench.Def) error {
 	w.loadWorkers = d.Param.Int("load-workers", 0, "Workers used to load each table.").Value()
 	w.useUnlogged = d.Param.Bool("pg-unlogged", false, "Use unlogged PostgreSQL tables while loading.").Value()
 	w.ydbStoreMode = d.Param.String("ydb-store-mode", "column", "YDB table store mode.").Value()
+	w.mode = d.Param.String("mode", modeQueries,
+		"queries, or timed spec tests per iteration: power, throughput or full.").Value()
 	w.streams = d.Param.Int("streams", 1, "Number of query streams.").Value()
 	w.seed = int64(d.Param.Int("query-seed", 19620718, "Query generator seed.").Value())
 
@@ -63,8 +85,16 @@ func (w *workload) Define(d *bench.Def) error {
 		w.genStream = -1
 	}
 
-	w.schemaFile = d.Param.String("schema-file", "", "Schema SQL file override.").Value()
-	w.sqlFile = d.Param.String("sql-file", "", "Query SQL file override.").Value()
+	w.maintenance = d.Param.String("maintenance", maintenanceNone,
+		"Refresh load in place of data maintenance: none, sequential (after VU 1's stream) or "+
+			"concurrent (during it).").Value()
+	w.maintenanceSet = d.Param.Int("maintenance-set", 1, "First refresh set applied by data maintenance.").Value()
+
+	w.schemaFile = d.Param.String("schema-file", "", "Schema SQL file override.", bench.LocalPath()).Value()
+	w.sqlFile = d.Param.String("sql-file", "", "Query SQL file override.", bench.LocalPath()).Value()
+	w.dumpAnswers = d.Param.String("dump-answers", "",
+		"Write every query's result set to this NDJSON file, for stroppy diff-answers.",
+		bench.LocalPath()).Value()
 	validateForce := d.Param.Bool("validate-force", false, "Validate answers outside scale factor 1.")
 
 	w.validateForce = validateForce.Value()
@@ -80,6 +110,26 @@ func (w *workload) Define(d *bench.Def) error {
 		return fmt.Errorf("%w, got %v", errScaleFactorMustBePositive, w.scaleFactor)
 	}
 
+	switch w.mode {
+	case modeQueries, modePower, modeThroughput, modeFull:
+	default:
+		return fmt.Errorf("%w, got %q", errUnknownMode, w.mode)
+	}
+
+	if w.streams < 1 {
+		return fmt.Errorf("%w, got %d", errStreamsMustBePositive, w.streams)
+	}
+
+	switch w.maintenance {
+	case maintenanceNone, maintenanceSequential, maintenanceConcurrent:
+	default:
+		return fmt.Errorf("%w, got %q", errUnknownMaintenance, w.maintenance)
+	}
+
+	if w.maintenanceSet < 1 {
+		return fmt.Errorf("%w, got %d", errMaintenanceSetMustBePositive, w.maintenanceSet)
+	}
+
 	return nil
 }
 
@@ -91,16 +141,31 @@ func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
 
 	// Query source. Throughput (STREAMS>1) or an explicit QUERY_STREAM selects the
 	// in-process generator; otherwise the baked canonical set. ydb runs the baked set
-	// only — the generator targets ANSI/pg/MySQL, not YQL.
-	w.throughput = w.streams > 1
+	// only — the generator targets ANSI/pg/MySQL, not YQL. The throughput and full
+	// modes run their streams inside one iteration instead of one per VU.
+	w.throughput = w.mode == modeQueries && w.streams > 1
+	generated := w.throughput || w.genStream >= 0 || w.mode == modeThroughput || w.mode == modeFull
 
-	if w.driver == bench.DriverYDB && (w.throughput || w.genStream >= 0) {
+	if w.driver == bench.DriverYDB && generated {
 		return errYdbBakedOnly
 	}
 
 	schemaFile, queryFile := dialectFiles(w.driver, w.schemaFile, w.sqlFile)
 	w.schemaSQL = mustLoad(preset, schemaFile)
 	w.querySQL = mustLoad(preset, queryFile)
+	w.maintenanceMetrics = initMaintenanceMetrics(b)
+	w.queryDuration = b.Trend("tpcds_query_duration")
+	w.queryRuns = b.Counter("tpcds_query_runs")
+	w.queryErrors = b.Counter("tpcds_query_errors")
+
+	if w.dumpAnswers != "" {
+		dump, err := answers.Create(w.dumpAnswers)
+		if err != nil {
+			return err
+		}
+
+		w.answers = dump
+	}
 
 	if err := w.runSteps(ctx, b); err != nil {
 		return err
@@ -129,12 +194,16 @@ func (w *workload) runSteps(ctx context.Context, b *bench.Bench) error {
 	}
 
 	if err := addStep("load_data", func() error {
+		start := time.Now()
+
 		for _, table := range tpcdsTables {
 			if _, err := b.InsertTpcds(ctx, table, w.scaleFactor, w.loadWorkers); err != nil {
 				return fmt.Errorf("load %s: %w", table, err)
 			}
 		}
 
+		w.loadElapsed = time.Since(start)
+
 		return nil
 	}); err != nil {
 		return err
@@ -177,40 +246,134 @@ func (w *workload) runSteps(ctx context.Context, b *bench.Bench) error {
 
 func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
 	return b.StepSilent("workload", func() error {
-		queries, err := w.resolveQueries(b)
+		if w.mode != modeQueries {
+			return w.runPerformance(ctx, b)
+		}
+
+		queries, stream, err := w.resolveQueries(b)
 		if err != nil {
 			return err
 		}
-		// The measured pass runs queries raw (no planner SETs), matching tpcds.ts: the
-		// set_timeout/preconfigure_db session setup is a validate-pass concern (applied
-		// inside the validation tx above) and is unnecessary for the throughput pass.
+
 		lg := b.Logger().Sugar()
+		// Data maintenance runs on VU 1 only: one refresh stream per run, as in
+		// the spec, however many query streams there are.
+		maintainer := w.maintenance != maintenanceNone && b.VUID() == 1
 
-		for _, q := range queries {
-			start := time.Now()
-			err := b.Exec(ctx, q.sql, nil)
+		if maintainer && w.maintenance == maintenanceConcurrent {
+			elapsed, failures, sets := w.runStreamUnderMaintenance(ctx, b, stream, queries)
+			lg.Infof("[tpcds] stream: %d queries in %dms, %d failed, under maintenance (%d refresh sets)",
+				len(queries), elapsed.Milliseconds(), failures, sets)
 
-			ms := time.Since(start).Milliseconds()
-			if err != nil {
-				lg.Infof("[tpcds] %s: error in %dms %v", q.name, ms, err)
+			return nil
+		}
 
-				continue
-			}
+		elapsed, failures := w.runStream(ctx, b, stream, queries)
+		lg.Infof("[tpcds] stream: %d queries in %dms, %d failed", len(queries), elapsed.Milliseconds(), failures)
 
-			lg.Infof("[tpcds] %s: ok in %dms", q.name, ms)
+		if maintainer {
+			w.runMaintenance(ctx, b, w.reserveMaintenanceSet())
 		}
 
 		return nil
 	})
 }
 
-func (*workload) Teardown(_ context.Context, b *bench.Bench) error {
+// runStream executes queries once each, in order, records each into the
+// per-query metrics (tagged query and stream) and the end-of-run report, and
+// returns the stream's elapsed time and how many queries failed. With
+// --dump-answers the queries fetch their rows and each result set is dumped.
+//
+// The measured pass runs queries raw (no planner SETs), matching tpcds.ts: the
+// set_timeout/preconfigure_db session setup is a validate-pass concern (applied
+// inside the validation tx in Setup) and is unnecessary for the throughput pass.
+func (w *workload) runStream(
+	ctx context.Context, b *bench.Bench, stream string, queries []namedQuery,
+) (time.Duration, int) {
+	lg := b.Logger().Sugar()
+	streamStart := time.Now()
+
+	var failures int
+
+	for _, q := range queries {
+		start := time.Now()
+		rows, err := w.runQuery(ctx, b, q.sql)
+		elapsed := time.Since(start)
+		w.recordQuery(q.name, stream, elapsed, err != nil)
+		w.dumpAnswer(b, q.name, stream, rows, err)
+
+		ms := elapsed.Milliseconds()
+		if err != nil {
+			failures++
+
+			lg.Infof("[tpcds] %s: error in %dms %v", q.name, ms, err)
+
+			continue
+		}
+
+		lg.Infof("[tpcds] %s: ok in %dms", q.name, ms)
+	}
+
+	return time.Since(streamStart), failures
+}
+
+// runQuery executes one query, reading its rows only when answers are dumped.
+func (w *workload) runQuery(ctx context.Context, b *bench.Bench, sql string) ([][]any, error) {
+	if w.answers == nil {
+		return nil, b.Exec(ctx, sql, nil)
+	}
+
+	return b.QueryRows(ctx, sql, nil)
+}
+
+// dumpAnswer writes one query's result set to the answer dump, if any. The
+// seed keys generated streams only; the baked set has fixed substitutions.
+func (w *workload) dumpAnswer(b *bench.Bench, name, stream string, rows [][]any, err error) {
+	if w.answers == nil {
+		return
+	}
+
+	rec := answers.Record{Workload: w.Name(), Query: name, Stream: stream}
+	if stream != bakedStream {
+		rec.Seed = w.seed
+	}
+
+	if dumpErr := w.answers.WriteResult(rec, rows, err); dumpErr != nil {
+		b.Logger().Sugar().Warnf("[tpcds] %s: %v", name, dumpErr)
+	}
+}
+
+// recordQuery records one query execution into its metrics and the report.
+func (w *workload) recordQuery(name, stream string, elapsed time.Duration, failed bool) {
+	tags := []string{"query", name, "stream", stream}
+
+	w.queryRuns.Add(1, tags...)
+	w.queryDuration.Add(float64(elapsed.Milliseconds()), tags...)
+
+	if failed {
+		w.queryErrors.Add(1, tags...)
+	}
+
+	w.observed.query(name, elapsed, failed)
+}
+
+func (w *workload) Teardown(_ context.Context, b *bench.Bench) error {
+	w.emitReport()
+
+	if w.answers != nil {
+		return w.answers.Close()
+	}
+
 	return nil
 }
 
-// resolveQueries returns this VU's query list. Throughput: VU N runs generated stream N.
-// Explicit QUERY_STREAM: that stream. Otherwise the baked canonical set.
-func (w *workload) resolveQueries(b *bench.Bench) ([]namedQuery, error) {
+// bakedStream tags the baked canonical query set, which is no generated stream.
+const bakedStream = "baked"
+
+// resolveQueries returns this VU's query list and its stream tag. Throughput:
+// VU N runs generated stream N. Explicit QUERY_STREAM: that stream. Otherwise
+// the baked canonical set.
+func (w *workload) resolveQueries(b *bench.Bench) ([]namedQuery, string, error) {
 	if w.genStream < 0 && !w.throughput {
 		names := w.querySQL.Names("")
 
@@ -221,7 +384,7 @@ func (w *workload) resolveQueries(b *bench.Bench) ([]namedQuery, error) {
 			}
 		}
 
-		return out, nil
+		return out, bakedStream, nil
 	}
 
 	var streamIdx int
@@ -231,7 +394,9 @@ func (w *workload) resolveQueries(b *bench.Bench) ([]namedQuery, error) {
 		streamIdx = w.genStream
 	}
 
-	return generateStream(string(w.driver), w.scaleFactor, w.seed, streamIdx)
+	queries, err := generateStream(string(w.driver), w.scaleFactor, w.seed, streamIdx)
+
+	return queries, strconv.Itoa(streamIdx), err
 }
 
 // generateStream renders one TPC-DS query stream in-process (port of
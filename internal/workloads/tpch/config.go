@@ -5,10 +5,30 @@ import (
 	"time"
 
 	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/third_party/gotpc/qgen"
 )
 
 const preset = "tpch"
 
+// Query parameter sources.
+const (
+	queryParamsQgen       = "qgen"
+	queryParamsValidation = "validation"
+)
+
+// defaultQueryParams keeps the queries mode on the §2.4 validation set, as
+// before qgen was ported; the spec tests draw a distinct stream each.
+func defaultQueryParams(mode string) string {
+	if mode == modeQueries {
+		return queryParamsValidation
+	}
+
+	return queryParamsQgen
+}
+
+// iterationSeedStride separates the qgen seeds of consecutive iterations.
+const iterationSeedStride = 1_000_003
+
 // tpchTables is the load order: parents before children (FK consistency).
 var tpchTables = [8]string{"region", "nation", "part", "supplier", "partsupp", "customer", "orders", "lineitem"}
 
@@ -33,6 +53,8 @@ func sqlFile(dt bench.DriverTypeName, override string) string {
 		return "pico.sql"
 	case bench.DriverYDB:
 		return "ydb.sql"
+	case bench.DriverSQLite:
+		return "sqlite.sql"
 	default:
 		return "pg.sql"
 	}
@@ -59,7 +81,7 @@ func shiftDate(iso string, days, months, years int) string {
 	return t.AddDate(years, months, days).Format("2006-01-02")
 }
 
-// queryParams builds the §2.4 pinned parameter set. q11 fraction is SF-dependent.
+// queryParams builds the §2.4 validation parameter set. q11 fraction is SF-dependent.
 func queryParams(scaleFactor float64) map[string]map[string]any {
 	m := map[string]map[string]any{
 		"q1":  {"delta": 90},
@@ -92,6 +114,50 @@ func queryParams(scaleFactor float64) map[string]map[string]any {
 	return m
 }
 
+// qgenParamNames names each query's qgen parameters (:1..:n) the way the
+// dialect files bind them.
+var qgenParamNames = [qgen.QueryCount][]string{
+	{"delta"},
+	{"size", "type", "region"},
+	{"segment", "date"},
+	{"date"},
+	{"region", "date"},
+	{"date", "discount", "quantity"},
+	{"nation1", "nation2"},
+	{"nation", "region", "type"},
+	{"color"},
+	{"date"},
+	{"nation", "fraction"},
+	{"shipmode1", "shipmode2", "date"},
+	{"word1", "word2"},
+	{"date"},
+	{"date"},
+	{"brand", "type_prefix", "s1", "s2", "s3", "s4", "s5", "s6", "s7", "s8"},
+	{"brand", "container"},
+	{"quantity"},
+	{"q1", "q2", "q3", "brand1", "brand2", "brand3"},
+	{"color", "date", "nation"},
+	{"nation"},
+	{"cc1", "cc2", "cc3", "cc4", "cc5", "cc6", "cc7"},
+}
+
+// qgenParams draws one query stream's parameters, keyed like queryParams.
+func qgenParams(scaleFactor float64, seed int64, stream int) map[string]map[string]any {
+	drawn := qgen.Stream(scaleFactor, seed, stream)
+	m := make(map[string]map[string]any, len(queryNames))
+
+	for i, name := range queryNames {
+		p := make(map[string]any, len(qgenParamNames[i]))
+		for j, param := range qgenParamNames[i] {
+			p[param] = drawn[i][j]
+		}
+
+		m[name] = p
+	}
+
+	return m
+}
+
 // withEndDates adds date_1m/date_3m/date_1y derived from `date` for picodata/ydb
 // (which lack date+interval expressions). No-op on pg/mysql or when no `date` param.
 func withEndDates(p map[string]any, needsEndDates bool) map[string]any {
@@ -15,6 +15,7 @@ func TestSQLFileDefaults(t *testing.T) {
 		{bench.DriverMySQL, "mysql.sql"},
 		{bench.DriverPicodata, "pico.sql"},
 		{bench.DriverYDB, "ydb.sql"},
+		{bench.DriverSQLite, "sqlite.sql"},
 	}
 	for _, tt := range tests {
 		if got := sqlFile(tt.driver, ""); got != tt.want {
@@ -26,3 +27,59 @@ func TestSQLFileDefaults(t *testing.T) {
 		t.Fatalf("SQL override = %s, want custom.sql", got)
 	}
 }
+
+func TestDefaultQueryParams(t *testing.T) {
+	for mode, want := range map[string]string{
+		modeQueries:    queryParamsValidation,
+		modePower:      queryParamsQgen,
+		modeThroughput: queryParamsQgen,
+		modeFull:       queryParamsQgen,
+	} {
+		if got := defaultQueryParams(mode); got != want {
+			t.Errorf("defaultQueryParams(%s) = %s, want %s", mode, got, want)
+		}
+	}
+}
+
+func TestQgenParamsMatchValidationNames(t *testing.T) {
+	validation := queryParams(1)
+	drawn := qgenParams(1, 42, 0)
+
+	for _, name := range queryNames {
+		if len(drawn[name]) != len(validation[name]) {
+			t.Fatalf("%s: qgen params %v, validation params %v", name, drawn[name], validation[name])
+		}
+
+		for param := range validation[name] {
+			if _, ok := drawn[name][param]; !ok {
+				t.Fatalf("%s: qgen does not draw %q", name, param)
+			}
+		}
+	}
+}
+
+func TestStreamParams(t *testing.T) {
+	w := &workload{scaleFactor: 1, queryParams: queryParamsQgen, querySeed: 7, isPicodata: true, needsEndDates: true}
+	w.validation = w.finalizeParams(queryParams(1))
+
+	first, second := w.nextQuerySeed(), w.nextQuerySeed()
+	if first != 7 || second != 7+iterationSeedStride {
+		t.Fatalf("iteration seeds = %d, %d", first, second)
+	}
+
+	params := w.streamParams(first, 0)
+
+	delta := params["q1"]["delta"].(int)
+	if want := shiftDate("1998-12-01", -delta, 0, 0); params["q1"]["shipdate_cutoff"] != want {
+		t.Fatalf("q1 shipdate_cutoff = %v, want %s for delta %d", params["q1"]["shipdate_cutoff"], want, delta)
+	}
+
+	if _, ok := params["q4"]["date_3m"]; !ok {
+		t.Fatal("qgen params must carry the pico/ydb end dates")
+	}
+
+	w.queryParams = queryParamsValidation
+	if got := w.streamParams(first, 0)["q1"]["delta"]; got != 90 {
+		t.Fatalf("validation q1 delta = %v, want 90", got)
+	}
+}
@@ -0,0 +1,300 @@
+package tpch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/answers"
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+// Run modes. queries keeps the historical one-pass q1..q22 iteration; the
+// others run the spec §5.3 performance tests and report the §5.4 metrics.
+const (
+	modeQueries    = "queries"
+	modePower      = "power"
+	modeThroughput = "throughput"
+	modeFull       = "full"
+)
+
+// streamOrders are the query orderings of TPC-H Appendix A: stream 0 is the
+// power test, streams 1.. are the throughput test's query streams. Streams
+// past the table reuse it cyclically.
+var streamOrders = [...][22]int{
+	{14, 2, 9, 20, 6, 17, 18, 8, 21, 13, 3, 22, 16, 4, 11, 15, 1, 10, 19, 5, 7, 12},
+	{21, 3, 18, 5, 11, 7, 6, 20, 17, 12, 16, 15, 13, 10, 2, 8, 14, 19, 9, 22, 1, 4},
+	{6, 17, 14, 16, 19, 10, 9, 2, 15, 8, 5, 22, 12, 7, 13, 18, 1, 4, 20, 3, 11, 21},
+	{8, 5, 4, 6, 17, 7, 1, 18, 22, 14, 9, 10, 15, 11, 20, 2, 21, 19, 13, 16, 12, 3},
+	{5, 21, 14, 19, 15, 17, 12, 6, 4, 9, 8, 16, 11, 2, 10, 18, 1, 13, 7, 22, 3, 20},
+	{21, 15, 4, 6, 7, 16, 19, 18, 14, 22, 11, 13, 3, 1, 2, 5, 8, 20, 12, 17, 10, 9},
+	{10, 3, 15, 13, 6, 8, 9, 7, 4, 11, 22, 18, 12, 1, 5, 16, 2, 14, 19, 20, 17, 21},
+	{18, 8, 20, 21, 2, 4, 22, 17, 1, 11, 9, 19, 3, 13, 5, 7, 10, 16, 6, 14, 15, 12},
+	{19, 1, 15, 17, 5, 8, 9, 12, 14, 7, 4, 3, 20, 16, 6, 22, 10, 13, 2, 21, 18, 11},
+	{8, 13, 2, 20, 17, 3, 6, 21, 18, 11, 19, 10, 15, 4, 22, 1, 7, 12, 9, 14, 5, 16},
+	{6, 15, 18, 17, 12, 1, 7, 2, 22, 13, 21, 10, 14, 9, 3, 16, 20, 19, 11, 4, 8, 5},
+}
+
+func streamOrder(stream int) [22]int { return streamOrders[stream%len(streamOrders)] }
+
+// minimumStreams is the §5.3.4 table: the fewest throughput query streams a
+// result at a given scale factor may use.
+var minimumStreams = []struct {
+	scaleFactor float64
+	streams     int
+}{
+	{1, 2}, {10, 3}, {30, 4}, {100, 5}, {300, 6}, {1000, 7},
+	{3000, 8}, {10000, 9}, {30000, 10}, {100000, 11},
+}
+
+// defaultStreams returns the §5.3.4 minimum for sf. Scale factors below 1
+// are not valid for a result and get the SF 1 minimum.
+func defaultStreams(sf float64) int {
+	streams := minimumStreams[0].streams
+	for _, m := range minimumStreams {
+		if sf >= m.scaleFactor {
+			streams = m.streams
+		}
+	}
+
+	return streams
+}
+
+// timing is one measured interval of the power test.
+type timing struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// Report is the machine-readable TPC-H performance report (spec §5.4). The
+// metrics of a test that did not run are omitted.
+type Report struct {
+	Workload          string   `json:"workload"`
+	Mode              string   `json:"mode"`
+	ScaleFactor       float64  `json:"scale_factor"`
+	PowerIntervals    []timing `json:"power_intervals,omitempty"`
+	PowerAtSize       *float64 `json:"power_at_size,omitempty"`
+	Streams           int      `json:"streams,omitempty"`
+	ThroughputSeconds float64  `json:"throughput_seconds,omitempty"`
+	ThroughputAtSize  *float64 `json:"throughput_at_size,omitempty"`
+	QphHAtSize        *float64 `json:"qphh_at_size,omitempty"`
+	Failures          int      `json:"failures"`
+	Valid             bool     `json:"valid"`
+}
+
+// reserveRefreshSets hands out n consecutive dbgen update sets. VUs share the
+// workload, so every test refreshes with sets no other test has used.
+func (w *workload) reserveRefreshSets(n int) int {
+	return w.refreshSet + int(w.refreshSetsUsed.Add(int64(n))) - n
+}
+
+// runPerformance runs the power and/or throughput test for the configured
+// mode and emits the report.
+func (w *workload) runPerformance(ctx context.Context, b *bench.Bench, seed int64) {
+	report := Report{Workload: w.Name(), Mode: w.mode, ScaleFactor: w.scaleFactor}
+
+	if w.mode == modePower || w.mode == modeFull {
+		intervals, failures := w.powerTest(ctx, b, w.streamParams(seed, 0), w.answerKey(seed, 0))
+		report.PowerIntervals = intervals
+		report.Failures += failures
+
+		power := powerAtSize(w.scaleFactor, intervals)
+		report.PowerAtSize = &power
+	}
+
+	if w.mode == modeThroughput || w.mode == modeFull {
+		elapsed, failures := w.throughputTest(ctx, b, seed)
+		report.Streams = w.streams
+		report.ThroughputSeconds = elapsed.Seconds()
+		report.Failures += failures
+
+		throughput := throughputAtSize(w.scaleFactor, w.streams, elapsed)
+		report.ThroughputAtSize = &throughput
+	}
+
+	if report.PowerAtSize != nil && report.ThroughputAtSize != nil {
+		qphh := math.Sqrt(*report.PowerAtSize * *report.ThroughputAtSize)
+		report.QphHAtSize = &qphh
+	}
+
+	report.Valid = report.Failures == 0
+
+	fmt.Fprint(os.Stderr, report.text())
+
+	if err := json.NewEncoder(os.Stdout).Encode(map[string]Report{"qphh": report}); err != nil {
+		fmt.Fprintf(os.Stderr, "tpch: report JSON output failed: %v\n", err)
+	}
+}
+
+// powerTest is §5.3.3: RF1, query stream 0, RF2, one after another. It
+// returns the 24 timing intervals and how many of them failed.
+func (w *workload) powerTest(
+	ctx context.Context, b *bench.Bench, params map[string]map[string]any, key answers.Record,
+) ([]timing, int) {
+	set := w.reserveRefreshSets(1)
+	intervals := make([]timing, 0, len(queryNames)+len(refreshNames))
+
+	var failures int
+
+	elapsed, err := w.runRefresh(ctx, b, "rf1", set)
+	intervals = append(intervals, timing{Name: "rf1", Seconds: elapsed.Seconds()})
+
+	if err != nil {
+		failures++
+	}
+
+	for _, q := range streamOrder(0) {
+		name := queryNames[q-1]
+
+		elapsed, ok := w.runQuery(ctx, b, name, params[name], key)
+		intervals = append(intervals, timing{Name: name, Seconds: elapsed.Seconds()})
+
+		if !ok {
+			failures++
+		}
+	}
+
+	elapsed, err = w.runRefresh(ctx, b, "rf2", set)
+	intervals = append(intervals, timing{Name: "rf2", Seconds: elapsed.Seconds()})
+
+	if err != nil {
+		failures++
+	}
+
+	return intervals, failures
+}
+
+// throughputTest is §5.3.4: w.streams query streams run concurrently with one
+// refresh stream that runs an RF1/RF2 pair per query stream. Each stream draws
+// its own parameters from seed. It returns Ts, from the start of the first
+// stream to the end of the last, and how many queries or refresh functions
+// failed.
+func (w *workload) throughputTest(ctx context.Context, b *bench.Bench, seed int64) (time.Duration, int) {
+	firstSet := w.reserveRefreshSets(w.streams)
+
+	var (
+		wg       sync.WaitGroup
+		failures atomic.Int64
+	)
+
+	start := time.Now()
+
+	for stream := 1; stream <= w.streams; stream++ {
+		params := w.streamParams(seed, stream)
+		key := w.answerKey(seed, stream)
+
+		wg.Go(func() {
+			for _, q := range streamOrder(stream) {
+				name := queryNames[q-1]
+				if _, ok := w.runQuery(ctx, b, name, params[name], key); !ok {
+					failures.Add(1)
+				}
+			}
+		})
+	}
+
+	wg.Go(func() {
+		for set := firstSet; set < firstSet+w.streams; set++ {
+			for _, name := range refreshNames {
+				if _, err := w.runRefresh(ctx, b, name, set); err != nil {
+					failures.Add(1)
+				}
+			}
+		}
+	})
+
+	wg.Wait()
+
+	return time.Since(start), int(failures.Load())
+}
+
+// powerAtSize is §5.4.1: 3600 × SF over the geometric mean of the power
+// test's intervals. Intervals are rounded up to 0.1 s (§5.3.7.7), and query
+// intervals shorter than the longest one / 1000 are raised to that (§5.4.1.4).
+func powerAtSize(sf float64, intervals []timing) float64 {
+	if len(intervals) == 0 {
+		return 0
+	}
+
+	rounded := make([]float64, len(intervals))
+
+	var longestQuery float64
+
+	for i, interval := range intervals {
+		rounded[i] = math.Ceil(interval.Seconds*10) / 10
+		if rounded[i] <= 0 {
+			rounded[i] = 0.1
+		}
+
+		if !isRefresh(interval.Name) {
+			longestQuery = max(longestQuery, rounded[i])
+		}
+	}
+
+	var logSum float64
+
+	for i, interval := range intervals {
+		seconds := rounded[i]
+		if !isRefresh(interval.Name) {
+			seconds = max(seconds, longestQuery/1000)
+		}
+
+		logSum += math.Log(seconds)
+	}
+
+	return 3600 * sf / math.Exp(logSum/float64(len(intervals)))
+}
+
+// throughputAtSize is §5.4.2: S × 22 × 3600 / Ts × SF.
+func throughputAtSize(sf float64, streams int, elapsed time.Duration) float64 {
+	seconds := math.Ceil(elapsed.Seconds()*10) / 10
+	if seconds <= 0 {
+		return 0
+	}
+
+	return float64(streams*len(queryNames)) * 3600 / seconds * sf
+}
+
+func isRefresh(name string) bool { return name == "rf1" || name == "rf2" }
+
+// text renders the human-readable report block written to stderr.
+func (r *Report) text() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "=== TPC-H performance report (%s, mode=%s, SF=%g) ===\n", r.Workload, r.Mode, r.ScaleFactor)
+
+	if len(r.PowerIntervals) > 0 {
+		fmt.Fprintln(&b, "power test intervals:")
+
+		for _, interval := range r.PowerIntervals {
+			fmt.Fprintf(&b, "  %-4s %10.3fs\n", interval.Name, interval.Seconds)
+		}
+	}
+
+	if r.PowerAtSize != nil {
+		fmt.Fprintf(&b, "Power@Size:      %.2f\n", *r.PowerAtSize)
+	}
+
+	if r.ThroughputAtSize != nil {
+		fmt.Fprintf(&b, "Throughput@Size: %.2f (%d streams, Ts=%.1fs)\n",
+			*r.ThroughputAtSize, r.Streams, r.ThroughputSeconds)
+	}
+
+	if r.QphHAtSize != nil {
+		fmt.Fprintf(&b, "QphH@Size:       %.2f\n", *r.QphHAtSize)
+	}
+
+	if r.Valid {
+		fmt.Fprintln(&b, "result: VALID (every query and refresh function succeeded)")
+	} else {
+		fmt.Fprintf(&b, "result: INVALID — %d queries or refresh functions failed\n", r.Failures)
+	}
+
+	return b.String()
+}
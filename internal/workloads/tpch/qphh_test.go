@@ -0,0 +1,95 @@
+package tpch
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+func TestStreamOrdersArePermutations(t *testing.T) {
+	for stream, order := range streamOrders {
+		var seen [23]bool
+
+		for _, q := range order {
+			if q < 1 || q > 22 || seen[q] {
+				t.Fatalf("stream %d: %v is not a permutation of 1..22", stream, order)
+			}
+
+			seen[q] = true
+		}
+	}
+
+	if streamOrder(len(streamOrders)) != streamOrders[0] {
+		t.Fatal("streams past the table must reuse it cyclically")
+	}
+}
+
+func TestDefaultStreams(t *testing.T) {
+	for sf, want := range map[float64]int{0.01: 2, 1: 2, 10: 3, 99: 4, 100: 5, 1000: 7, 100000: 11} {
+		if got := defaultStreams(sf); got != want {
+			t.Errorf("defaultStreams(%v) = %d, want %d", sf, got, want)
+		}
+	}
+}
+
+func TestPowerAtSize(t *testing.T) {
+	intervals := make([]timing, 0, 24)
+	for _, name := range append(queryNames[:], refreshNames[:]...) {
+		intervals = append(intervals, timing{Name: name, Seconds: 2})
+	}
+
+	// Every interval 2 s: the geometric mean is 2, so Power@Size = 3600 × SF / 2.
+	if got := powerAtSize(10, intervals); math.Abs(got-18000) > 1e-6 {
+		t.Fatalf("powerAtSize = %v, want 18000", got)
+	}
+
+	// One 5000 s query lifts every query interval to 5 s (§5.4.1.4); the
+	// refresh intervals keep their 2 s.
+	intervals[0].Seconds = 5000
+
+	want := 3600 / math.Exp((math.Log(5000)+21*math.Log(5)+2*math.Log(2))/24)
+	if got := powerAtSize(1, intervals); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("powerAtSize with a long query = %v, want %v", got, want)
+	}
+}
+
+func TestThroughputAtSize(t *testing.T) {
+	// 2 streams × 22 queries in one hour at SF 1.
+	if got := throughputAtSize(1, 2, time.Hour); got != 44 {
+		t.Fatalf("throughputAtSize = %v, want 44", got)
+	}
+}
+
+func TestRefreshSQLInEveryDialect(t *testing.T) {
+	for _, driver := range []bench.DriverTypeName{
+		bench.DriverPostgres, bench.DriverMySQL, bench.DriverPicodata, bench.DriverYDB,
+	} {
+		sql := mustLoadSQL(driver, "")
+
+		for _, entry := range [][2]string{
+			{"rf1", "insert_order"}, {"rf1", "insert_lineitem"}, {"rf2", "delete_lineitem"}, {"rf2", "delete_order"},
+		} {
+			if _, ok := sql.Query(entry[0], entry[1]); !ok {
+				t.Errorf("%s: missing %s/%s", driver, entry[0], entry[1])
+			}
+		}
+	}
+}
+
+func TestReserveRefreshSets(t *testing.T) {
+	w := &workload{refreshSet: 3}
+
+	if first := w.reserveRefreshSets(1); first != 3 {
+		t.Fatalf("first power set = %d, want 3", first)
+	}
+
+	if first := w.reserveRefreshSets(2); first != 4 {
+		t.Fatalf("first throughput set = %d, want 4", first)
+	}
+
+	if first := w.reserveRefreshSets(1); first != 6 {
+		t.Fatalf("next set = %d, want 6", first)
+	}
+}
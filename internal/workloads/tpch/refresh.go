@@ -0,0 +1,118 @@
+package tpch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/datagen/tpchgen"
+)
+
+// refreshNames are the two refresh functions (spec §2.5), timed like queries.
+var refreshNames = [2]string{"rf1", "rf2"}
+
+var errRefreshSQLMissing = errors.New("refresh function has no SQL in the dialect file")
+
+// rf1 inserts the new orders of dbgen update set `set`, one order and its
+// lineitems per transaction (§2.5.2).
+func (w *workload) rf1(ctx context.Context, b *bench.Bench, set int) error {
+	insertOrder, okOrder := w.sql.Query("rf1", "insert_order")
+	insertLine, okLine := w.sql.Query("rf1", "insert_lineitem")
+
+	if !okOrder || !okLine {
+		return fmt.Errorf("%w: rf1", errRefreshSQLMissing)
+	}
+
+	orderColumns, err := tpchgen.Columns("orders")
+	if err != nil {
+		return err
+	}
+
+	lineColumns, err := tpchgen.Columns("lineitem")
+	if err != nil {
+		return err
+	}
+
+	for order := range tpchgen.UpdateOrders(w.scaleFactor, set) {
+		err := b.BeginTx(ctx, bench.BeginOpts{Name: "rf1"}, func(tx *bench.TxX) error {
+			if err := tx.Exec(ctx, insertOrder, rowArgs(orderColumns, order.Order)); err != nil {
+				return err
+			}
+
+			for _, line := range order.Lines {
+				if err := tx.Exec(ctx, insertLine, rowArgs(lineColumns, line)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("rf1 set %d: %w", set, err)
+		}
+	}
+
+	return nil
+}
+
+// rf2 deletes the old orders of dbgen update set `set` and their lineitems,
+// one order per transaction (§2.5.3).
+func (w *workload) rf2(ctx context.Context, b *bench.Bench, set int) error {
+	deleteLines, okLines := w.sql.Query("rf2", "delete_lineitem")
+	deleteOrder, okOrder := w.sql.Query("rf2", "delete_order")
+
+	if !okLines || !okOrder {
+		return fmt.Errorf("%w: rf2", errRefreshSQLMissing)
+	}
+
+	for key := range tpchgen.DeleteKeys(w.scaleFactor, set) {
+		args := map[string]any{"orderkey": key}
+
+		err := b.BeginTx(ctx, bench.BeginOpts{Name: "rf2"}, func(tx *bench.TxX) error {
+			if err := tx.Exec(ctx, deleteLines, args); err != nil {
+				return err
+			}
+
+			return tx.Exec(ctx, deleteOrder, args)
+		})
+		if err != nil {
+			return fmt.Errorf("rf2 set %d: %w", set, err)
+		}
+	}
+
+	return nil
+}
+
+// runRefresh times one refresh function into its metrics and returns the
+// elapsed interval.
+func (w *workload) runRefresh(ctx context.Context, b *bench.Bench, name string, set int) (time.Duration, error) {
+	fn := w.rf1
+	if name == "rf2" {
+		fn = w.rf2
+	}
+
+	start := time.Now()
+	err := fn(ctx, b, set)
+	elapsed := time.Since(start)
+	w.recordAttempt(name, float64(elapsed.Milliseconds()), err != nil)
+
+	lg := b.Logger().Sugar()
+	if err != nil {
+		lg.Infof("[tpch] %s set %d: error in %dms %v", name, set, elapsed.Milliseconds(), err)
+	} else {
+		lg.Infof("[tpch] %s set %d: ok in %dms", name, set, elapsed.Milliseconds())
+	}
+
+	return elapsed, err
+}
+
+func rowArgs(columns []string, row []any) map[string]any {
+	args := make(map[string]any, len(columns))
+	for i, column := range columns {
+		args[column] = row[i]
+	}
+
+	return args
+}
@@ -0,0 +1,94 @@
+package tpch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/answers"
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	_ "github.com/stroppy-io/stroppy/pkg/driver/sqlite"
+)
+
+// TestSQLiteEndToEnd runs one power test (RF1, q1..q22, RF2) against an
+// embedded SQLite file at SF=0.01 and checks every query of the answer dump
+// ran without error and q1 returned its four flag/status groups.
+func TestSQLiteEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads a TPC-H database")
+	}
+
+	unsetEnv(
+		t,
+		"SCALE_FACTOR", "LOAD_WORKERS", "SQL_FILE", "MODE", "QUERY_PARAMS", "DUMP_ANSWERS",
+		"EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION",
+	)
+
+	dir := t.TempDir()
+	dumpPath := filepath.Join(dir, "answers.ndjson")
+
+	err := bench.Run(
+		context.Background(),
+		"tpch/tx",
+		map[int]*stroppy.DriverConfig{0: {
+			DriverType: stroppy.DriverConfig_DRIVER_TYPE_SQLITE,
+			Url:        filepath.Join(dir, "tpch.db"),
+		}},
+		nil,
+		bench.ParamInputs{CLI: map[string]string{
+			"scale-factor": "0.01",
+			"load-workers": "2",
+			"mode":         modePower,
+			"query-params": queryParamsValidation,
+			"dump-answers": dumpPath,
+			"executor":     "shared-iterations",
+			"vus":          "1",
+			"iterations":   "1",
+		}},
+		zap.NewNop(),
+		&bench.MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("tpch/tx sqlite run: %v", err)
+	}
+
+	dump, err := answers.Load(dumpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, msg := range dump.Errors {
+		t.Errorf("%s: %s", key, msg)
+	}
+
+	if len(dump.Rows) != len(queryNames) {
+		t.Fatalf("dump holds %d result sets, want %d", len(dump.Rows), len(queryNames))
+	}
+
+	if q1 := dump.Rows["q1 stream=validation"]; len(q1) != 4 {
+		t.Fatalf("q1 returned %d groups, want 4", len(q1))
+	}
+}
+
+func unsetEnv(t *testing.T, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		value, present := os.LookupEnv(name)
+		if err := os.Unsetenv(name); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			if present {
+				_ = os.Setenv(name, value)
+			} else {
+				_ = os.Unsetenv(name)
+			}
+		})
+	}
+}
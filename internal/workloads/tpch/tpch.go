@@ -1,20 +1,31 @@
 // Package tpch is the Go-native port of workloads/tpch/tx.ts: the relational load of
 // the 8 TPC-H tables via the ported dbgen generator (bench.InsertTpch) plus the q1–q22
-// business queries run once with §2.4 pinned defaults, and SF=1 answer validation
-// (postgres only). Supports pg/mysql/pico/ydb dialect files; date shifts for pico/ydb
-// are precomputed client-side.
+// business queries with qgen-drawn or §2.4 validation parameters, and SF=1 answer validation
+// (postgres only). The power and throughput tests (§5.3) add the RF1/RF2 refresh
+// functions over dbgen update sets and report QphH@Size. Supports pg/mysql/pico/ydb
+// dialect files; date shifts for pico/ydb are precomputed client-side.
 package tpch
 
 import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/stroppy-io/stroppy/pkg/answers"
 	"github.com/stroppy-io/stroppy/pkg/bench"
 )
 
-var errScaleFactorMustBePositive = errors.New("SCALE_FACTOR must be positive")
+var (
+	errScaleFactorMustBePositive = errors.New("SCALE_FACTOR must be positive")
+	errUnknownMode               = errors.New("mode must be queries, power, throughput or full")
+	errStreamsNegative           = errors.New("streams must not be negative")
+	errRefreshSetMustBePositive  = errors.New("refresh-set must be at least 1")
+	errUnknownQueryParams        = errors.New("query-params must be qgen or validation")
+	errQueryStreamNegative       = errors.New("query-stream must not be negative")
+)
 
 type workload struct {
 	sql           *bench.SQL
@@ -27,9 +38,20 @@ type workload struct {
 	ydbColumn     bool
 	ydbStoreMode  string
 	sqlFile       string
-
-	params map[string]map[string]any // final per-query params (end dates + q1 cutoff precomputed)
-	m      map[string]*queryMetrics
+	mode          string
+	streams       int
+	refreshSet    int
+	queryParams   string
+	querySeed     int64
+	queryStream   int
+	dumpAnswers   string
+
+	refreshSetsUsed atomic.Int64 // update sets handed out so far, see reserveRefreshSets
+	iterations      atomic.Int64 // iterations started, see nextQuerySeed
+
+	validation map[string]map[string]any // §2.4 validation params (end dates + q1 cutoff precomputed)
+	m          map[string]*queryMetrics
+	answers    *answers.Writer // nil unless --dump-answers
 }
 
 type queryMetrics struct {
@@ -48,12 +70,52 @@ func (w *workload) Define(d *bench.Def) error {
 	w.loadWorkers = d.Param.Int("load-workers", 0, "Workers used to load each table.").Value()
 	w.useUnlogged = d.Param.Bool("pg-unlogged", false, "Use unlogged PostgreSQL tables while loading.").Value()
 	w.ydbStoreMode = d.Param.String("ydb-store-mode", "column", "YDB table store mode.").Value()
-	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.").Value()
+	w.sqlFile = d.Param.String("sql-file", "", "SQL dialect file override.", bench.LocalPath()).Value()
+	w.mode = d.Param.String("mode", modeQueries,
+		"queries, or a spec test per iteration: power, throughput or full (both, with QphH@Size).").Value()
+	w.streams = d.Param.Int("streams", 0, "Throughput test query streams; 0 uses the spec minimum for the SF.").Value()
+	w.refreshSet = d.Param.Int("refresh-set", 1,
+		"First dbgen update set RF1/RF2 use; raise it to refresh a database already refreshed.").Value()
+	w.queryParams = d.Param.String("query-params", defaultQueryParams(w.mode),
+		"Query substitution parameters: qgen (drawn per stream and iteration) or validation (§2.4 defaults).",
+		bench.DerivedDefault("validation in queries mode; qgen in power, throughput and full"),
+	).Value()
+	w.querySeed = int64(d.Param.Int("query-seed", 19620718, "Query parameter generator seed.").Value())
+	w.queryStream = d.Param.Int("query-stream", 0, "Query stream whose parameters the queries mode draws.").Value()
+	w.dumpAnswers = d.Param.String("dump-answers", "",
+		"Write every query's result set to this NDJSON file, for stroppy diff-answers.",
+		bench.LocalPath()).Value()
 
 	if w.scaleFactor <= 0 {
 		return fmt.Errorf("%w, got %v", errScaleFactorMustBePositive, w.scaleFactor)
 	}
 
+	switch w.mode {
+	case modeQueries, modePower, modeThroughput, modeFull:
+	default:
+		return fmt.Errorf("%w, got %q", errUnknownMode, w.mode)
+	}
+
+	if w.streams < 0 {
+		return fmt.Errorf("%w, got %d", errStreamsNegative, w.streams)
+	}
+
+	if w.streams == 0 {
+		w.streams = defaultStreams(w.scaleFactor)
+	}
+
+	if w.refreshSet < 1 {
+		return fmt.Errorf("%w, got %d", errRefreshSetMustBePositive, w.refreshSet)
+	}
+
+	if w.queryParams != queryParamsQgen && w.queryParams != queryParamsValidation {
+		return fmt.Errorf("%w, got %q", errUnknownQueryParams, w.queryParams)
+	}
+
+	if w.queryStream < 0 {
+		return fmt.Errorf("%w, got %d", errQueryStreamNegative, w.queryStream)
+	}
+
 	return nil
 }
 
@@ -62,12 +124,21 @@ func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
 
 	w.initConfig()
 
-	// Per-query metrics (22 × 4).
+	// Per-query and per-refresh-function metrics (24 × 4).
 	w.m = w.initMetrics(b)
 
-	// Final per-query params: base §2.4 values, with pico/ydb end dates and the
-	// q1 picodata shipdate_cutoff precomputed once.
-	w.params = w.buildParams()
+	if w.dumpAnswers != "" {
+		dump, err := answers.Create(w.dumpAnswers)
+		if err != nil {
+			return err
+		}
+
+		w.answers = dump
+	}
+
+	// §2.4 validation params, with pico/ydb end dates and the q1 picodata
+	// shipdate_cutoff precomputed once. Answer validation always uses them.
+	w.validation = w.finalizeParams(queryParams(w.scaleFactor))
 
 	runSection := func(name string) error {
 		for _, q := range w.sql.Section(name) {
@@ -119,7 +190,7 @@ func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
 
 	addStep("analyze", func() error { return runSection("analyze") })
 	addStep("validate_answers", func() error {
-		validateAnswers(ctx, b, w.sql, w.params, w.scaleFactor, w.driverType)
+		validateAnswers(ctx, b, w.sql, w.validation, w.scaleFactor, w.driverType)
 
 		return nil
 	})
@@ -142,10 +213,10 @@ func (w *workload) initConfig() {
 	w.sql = mustLoadSQL(w.driverType, w.sqlFile)
 }
 
-// initMetrics wires the per-query duration/counters (22 × 4).
+// initMetrics wires the per-query and per-refresh-function duration/counters (24 × 4).
 func (w *workload) initMetrics(b *bench.Bench) map[string]*queryMetrics {
-	m := make(map[string]*queryMetrics, len(queryNames))
-	for _, name := range queryNames {
+	m := make(map[string]*queryMetrics, len(queryNames)+len(refreshNames))
+	for _, name := range append(queryNames[:], refreshNames[:]...) {
 		m[name] = &queryMetrics{
 			duration:     b.Trend("tpch_" + name + "_duration"),
 			runs:         b.Counter("tpch_" + name + "_runs"),
@@ -157,11 +228,10 @@ func (w *workload) initMetrics(b *bench.Bench) map[string]*queryMetrics {
 	return m
 }
 
-// buildParams assembles per-query params: base §2.4 values, with pico/ydb end
-// dates and the q1 picodata shipdate_cutoff precomputed once.
-func (w *workload) buildParams() map[string]map[string]any {
+// finalizeParams copies per-query params and adds the pico/ydb end dates and
+// the q1 picodata shipdate_cutoff derived from them.
+func (w *workload) finalizeParams(base map[string]map[string]any) map[string]map[string]any {
 	params := make(map[string]map[string]any, len(queryNames))
-	base := queryParams(w.scaleFactor)
 
 	for _, name := range queryNames {
 		p := map[string]any{}
@@ -170,8 +240,8 @@ func (w *workload) buildParams() map[string]map[string]any {
 		}
 
 		p = withEndDates(p, w.needsEndDates)
-		if name == "q1" && w.isPicodata {
-			p["shipdate_cutoff"] = shiftDate("1998-12-01", -90, 0, 0)
+		if delta, ok := p["delta"].(int); ok && name == "q1" && w.isPicodata {
+			p["shipdate_cutoff"] = shiftDate("1998-12-01", -delta, 0, 0)
 		}
 
 		params[name] = p
@@ -180,40 +250,82 @@ func (w *workload) buildParams() map[string]map[string]any {
 	return params
 }
 
+// streamParams returns the per-query params of one query stream: drawn by
+// qgen from seed, or the validation set when query-params is validation.
+func (w *workload) streamParams(seed int64, stream int) map[string]map[string]any {
+	if w.queryParams == queryParamsValidation {
+		return w.validation
+	}
+
+	return w.finalizeParams(qgenParams(w.scaleFactor, seed, stream))
+}
+
+// nextQuerySeed returns the qgen seed of the next iteration, so every
+// iteration draws new parameters while a run stays reproducible.
+func (w *workload) nextQuerySeed() int64 {
+	return w.querySeed + (w.iterations.Add(1)-1)*iterationSeedStride
+}
+
 func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
 	return b.StepSilent("workload", func() error {
-		w.runQueries(ctx, b)
+		seed := w.nextQuerySeed()
+
+		if w.mode == modeQueries {
+			w.runQueries(ctx, b, w.streamParams(seed, w.queryStream), w.answerKey(seed, w.queryStream))
+		} else {
+			w.runPerformance(ctx, b, seed)
+		}
 
 		return nil
 	})
 }
 
-// runQueries executes q1..q22 once each with pinned defaults, draining rows and
+// runQueries executes q1..q22 once each with the given params, draining rows and
 // recording per-query timing/error metrics. Rows are discarded (throughput pass).
-func (w *workload) runQueries(ctx context.Context, b *bench.Bench) {
-	lg := b.Logger().Sugar()
-
+func (w *workload) runQueries(
+	ctx context.Context, b *bench.Bench, params map[string]map[string]any, key answers.Record,
+) {
 	for _, name := range queryNames {
-		body, ok := w.sql.Query(name, "body")
-		if !ok {
-			lg.Infof("[tpch] %s: skipped (no body in SQL file)", name)
+		w.runQuery(ctx, b, name, params[name], key)
+	}
+}
 
-			continue
-		}
+// runQuery executes one query and records its attempt, and its result set
+// under key when answers are dumped. ok is false when the query failed or the
+// SQL file has no body for it.
+func (w *workload) runQuery(
+	ctx context.Context, b *bench.Bench, name string, params map[string]any, key answers.Record,
+) (time.Duration, bool) {
+	lg := b.Logger().Sugar()
 
-		start := time.Now()
-		_, err := b.QueryRows(ctx, body, w.params[name])
-		elapsed := time.Since(start).Milliseconds()
-		w.recordAttempt(name, float64(elapsed), err != nil)
+	body, ok := w.sql.Query(name, "body")
+	if !ok {
+		lg.Infof("[tpch] %s: skipped (no body in SQL file)", name)
 
-		if err != nil {
-			lg.Infof("[tpch] %s: error in %dms %v", name, elapsed, err)
+		return 0, false
+	}
+
+	start := time.Now()
+	rows, err := b.QueryRows(ctx, body, params)
+	elapsed := time.Since(start)
+	w.recordAttempt(name, float64(elapsed.Milliseconds()), err != nil)
 
-			continue
+	if w.answers != nil {
+		key.Query = name
+		if dumpErr := w.answers.WriteResult(key, rows, err); dumpErr != nil {
+			lg.Warnf("[tpch] %s: %v", name, dumpErr)
 		}
+	}
 
-		lg.Infof("[tpch] %s: ok in %dms", name, elapsed)
+	if err != nil {
+		lg.Infof("[tpch] %s: error in %dms %v", name, elapsed.Milliseconds(), err)
+
+		return elapsed, false
 	}
+
+	lg.Infof("[tpch] %s: ok in %dms", name, elapsed.Milliseconds())
+
+	return elapsed, true
 }
 
 func (w *workload) recordAttempt(name string, elapsedMs float64, failed bool) {
@@ -231,6 +343,20 @@ func (w *workload) recordAttempt(name string, elapsedMs float64, failed bool) {
 	}
 }
 
-func (*workload) Teardown(_ context.Context, b *bench.Bench) error {
+func (w *workload) Teardown(_ context.Context, b *bench.Bench) error {
+	if w.answers != nil {
+		return w.answers.Close()
+	}
+
 	return nil
 }
+
+// answerKey names the parameter set of one query stream in an answer dump:
+// the qgen stream and seed, or the fixed validation set.
+func (w *workload) answerKey(seed int64, stream int) answers.Record {
+	if w.queryParams == queryParamsValidation {
+		return answers.Record{Workload: w.Name(), Stream: queryParamsValidation}
+	}
+
+	return answers.Record{Workload: w.Name(), Stream: strconv.Itoa(stream), Seed: seed}
+}
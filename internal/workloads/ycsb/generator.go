@@ -0,0 +1,66 @@
+package ycsb
+
+import (
+	"strconv"
+
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// Request distributions, named as in YCSB's requestdistribution property.
+const (
+	distUniform = "uniform"
+	distZipfian = "zipfian"
+	distLatest  = "latest"
+)
+
+const (
+	zipfianTheta = 0.99 // YCSB ZipfianGenerator.ZIPFIAN_CONSTANT
+
+	// scrambledItems and scrambledZeta are YCSB ScrambledZipfianGenerator's
+	// ITEM_COUNT and its precomputed zeta(ITEM_COUNT, 0.99): the popularity
+	// curve is drawn over a fixed 10^10 items and hashed onto the keyspace,
+	// so it never has to be recomputed as records are inserted.
+	scrambledItems = 10_000_000_000
+	scrambledZeta  = 26.46902820178302
+
+	fnvOffsetBasis64 = 0xCBF29CE484222325
+	fnvPrime64       = 1099511628211
+
+	keyPrefix = "user"
+)
+
+// scrambled is YCSB's ScrambledZipfianGenerator: zipfian popularity over
+// the fixed scrambledItems range, hashed onto [0, n) so the hot items are
+// spread over the keyspace instead of clustered at its start.
+var scrambled = gen.NewZipfianWithZeta(scrambledItems, zipfianTheta, scrambledZeta)
+
+func scrambledNext(u float64, n uint64) uint64 {
+	return fnvHash64(scrambled.Next(u)) % n
+}
+
+// fnvHash64 is YCSB's Utils.fnvhash64: FNV-1a over the 8 little-endian
+// bytes of v, then the absolute value of the signed result.
+func fnvHash64(v uint64) uint64 {
+	h := uint64(fnvOffsetBasis64)
+	for range 8 {
+		h ^= v & 0xff
+		h *= fnvPrime64
+		v >>= 8
+	}
+
+	if signed := int64(h); signed < 0 { //nolint:gosec // G115: reinterpreting the hash as Java's long
+		return uint64(-signed)
+	}
+
+	return h
+}
+
+// appendKey appends the usertable key of record keyNum: "user" plus the
+// hashed record number, as YCSB builds keys with insertorder=hashed.
+func appendKey(dst []byte, keyNum uint64) []byte {
+	return strconv.AppendUint(append(dst, keyPrefix...), fnvHash64(keyNum), 10)
+}
+
+func recordKey(keyNum uint64) string {
+	return string(appendKey(nil, keyNum))
+}
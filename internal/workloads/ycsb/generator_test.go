@@ -0,0 +1,37 @@
+package ycsb
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestRecordKeyMatchesYCSB(t *testing.T) {
+	// Keys YCSB's CoreWorkload builds for records 0, 1 and 12345.
+	for keyNum, want := range map[uint64]string{
+		0:     "user6284781860667377211",
+		1:     "user8517097267634966620",
+		12345: "user1792800413050876852",
+	} {
+		if got := recordKey(keyNum); got != want {
+			t.Errorf("recordKey(%d) = %s, want %s", keyNum, got, want)
+		}
+	}
+}
+
+func TestScrambledStaysInRange(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	seen := map[uint64]bool{}
+
+	for range 10_000 {
+		n := scrambledNext(rng.Float64(), 100)
+		if n >= 100 {
+			t.Fatalf("draw %d outside [0, 100)", n)
+		}
+
+		seen[n] = true
+	}
+
+	if len(seen) < 50 {
+		t.Fatalf("only %d of 100 keys drawn", len(seen))
+	}
+}
@@ -0,0 +1,68 @@
+package ycsb
+
+import (
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+const (
+	seedUsertable   = 0x9C5B
+	usertableDomain = "ycsb/usertable@1"
+	maxKeyLen       = len(keyPrefix) + 20 // "user" + a uint64 in decimal
+	loadBatchRows   = 64
+)
+
+// usertableRequest builds the typed insert request for the initial records
+// 0..recordCount-1.
+func usertableRequest(recordCount int64, fieldCount, fieldLength, workers int) *driver.InsertRequest {
+	return &driver.InsertRequest{
+		Table: table, Method: driver.InsertNative, Workers: workers,
+		Source: usertableSource(gen.New(seedUsertable), recordCount, fieldCount, fieldLength),
+	}
+}
+
+// usertableSource returns the indexed source for usertable. Entity e is
+// record e: its key is the hashed record number and every field is
+// fieldLength random [A-Za-z] characters, each field on its own stream.
+func usertableSource(root gen.Root, recordCount int64, fieldCount, fieldLength int) *gen.IndexedSource {
+	domain := root.Domain(usertableDomain)
+
+	b := gen.NewSchemaBuilder()
+	keyCol := b.Bytes(keyColumn, maxKeyLen)
+	fieldCols := make([]gen.Column, fieldCount)
+	fieldDraws := make([]gen.Field, fieldCount)
+
+	for i := range fieldCount {
+		fieldCols[i] = b.Bytes(fieldName(i), fieldLength)
+		fieldDraws[i] = domain.Field(fieldName(i))
+	}
+
+	schema := b.Build()
+
+	fn := func(r gen.Row, entity uint64) error {
+		var buf [maxKeyLen]byte
+
+		key := appendKey(buf[:0], entity)
+
+		dst, err := r.Bytes(keyCol, len(key))
+		if err != nil {
+			return err
+		}
+
+		copy(dst, key)
+
+		for i, col := range fieldCols {
+			dst, err := r.Bytes(col, fieldLength)
+			if err != nil {
+				return err
+			}
+
+			draw := fieldDraws[i].At(entity)
+			gen.Alpha.Fill(&draw, dst)
+		}
+
+		return nil
+	}
+
+	return gen.NewIndexedSource(schema, root, usertableDomain, recordCount, loadBatchRows, fn)
+}
@@ -0,0 +1,78 @@
+package ycsb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+)
+
+const (
+	table     = "usertable"
+	keyColumn = "ycsb_key"
+)
+
+// statements are the usertable DDL and operation SQL for one dialect. They
+// are built here rather than read from a dialect file because the column
+// list follows --field-count.
+type statements struct {
+	drop    string
+	create  string
+	read    string
+	insert  string
+	update  []string // one per field: YCSB updates a single random field
+	scanFmt string   // %d is the scan length
+}
+
+// columnTypes returns the key and field column types of a dialect.
+func columnTypes(dt bench.DriverTypeName) (key, field string) {
+	if dt == bench.DriverYDB {
+		return "Utf8", "Utf8"
+	}
+
+	return "VARCHAR(255)", "TEXT"
+}
+
+func fieldName(i int) string { return "field" + strconv.Itoa(i) }
+
+func newStatements(dt bench.DriverTypeName, fieldCount int) *statements {
+	keyType, fieldType := columnTypes(dt)
+
+	fields := make([]string, fieldCount)
+	defs := make([]string, 0, fieldCount+2)
+	params := make([]string, fieldCount)
+	defs = append(defs, keyColumn+" "+keyType+" NOT NULL")
+
+	for i := range fieldCount {
+		fields[i] = fieldName(i)
+		params[i] = ":" + fields[i]
+		defs = append(defs, fields[i]+" "+fieldType)
+	}
+
+	defs = append(defs, "PRIMARY KEY ("+keyColumn+")")
+	columns := strings.Join(fields, ", ")
+
+	s := &statements{
+		drop:   "DROP TABLE IF EXISTS " + table,
+		create: "CREATE TABLE " + table + " (\n    " + strings.Join(defs, ",\n    ") + "\n)",
+		read:   fmt.Sprintf("SELECT %s FROM %s WHERE %s = :key", columns, table, keyColumn),
+		insert: fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (:key, %s)",
+			table, keyColumn, columns, strings.Join(params, ", ")),
+		update: make([]string, fieldCount),
+		scanFmt: fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s >= :key ORDER BY %s LIMIT %%d",
+			keyColumn, columns, table, keyColumn, keyColumn),
+	}
+
+	for i, field := range fields {
+		s.update[i] = fmt.Sprintf("UPDATE %s SET %s = :value WHERE %s = :key", table, field, keyColumn)
+	}
+
+	return s
+}
+
+// scan returns the scan statement for length records. The length is inlined:
+// LIMIT placeholders are not portable across the supported dialects.
+func (s *statements) scan(length int) string {
+	return fmt.Sprintf(s.scanFmt, length)
+}
@@ -0,0 +1,340 @@
+// Package ycsb is a Go-native port of the YCSB core workloads, registered as
+// ycsb/a through ycsb/f:
+//
+//   - a: 50% read, 50% update (update heavy)
+//   - b: 95% read, 5% update (read mostly)
+//   - c: 100% read (read only)
+//   - d: 95% read, 5% insert, latest distribution (read latest)
+//   - e: 95% scan, 5% insert (short ranges)
+//   - f: 50% read, 50% read-modify-write
+//
+// All six share one usertable (a key plus --field-count text fields of
+// --field-length characters) loaded through a gen.IndexedSource, the
+// uniform/zipfian/latest request distributions and per-operation duration
+// trends, so the numbers line up with a YCSB run against the same engine.
+package ycsb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+var (
+	errRecordCountMustBePositive   = errors.New("RECORD_COUNT must be >= 1")
+	errFieldCountMustBePositive    = errors.New("FIELD_COUNT must be >= 1")
+	errFieldLengthMustBePositive   = errors.New("FIELD_LENGTH must be >= 1")
+	errMaxScanLengthMustBePositive = errors.New("MAX_SCAN_LENGTH must be >= 1")
+	errUnknownDistribution         = errors.New("REQUEST_DISTRIBUTION must be uniform, zipfian or latest")
+)
+
+type operation int
+
+const (
+	opRead operation = iota
+	opUpdate
+	opInsert
+	opScan
+	opReadModifyWrite
+	opCount
+)
+
+var opNames = [opCount]string{"read", "update", "insert", "scan", "read_modify_write"}
+
+// mix is one core workload's operation proportions and default request
+// distribution, as in YCSB's workloads/workload[a-f] property files.
+type mix struct {
+	proportions  [opCount]float64
+	distribution string
+}
+
+var mixes = map[string]mix{
+	"a": {proportions: [opCount]float64{opRead: 0.5, opUpdate: 0.5}, distribution: distZipfian},
+	"b": {proportions: [opCount]float64{opRead: 0.95, opUpdate: 0.05}, distribution: distZipfian},
+	"c": {proportions: [opCount]float64{opRead: 1}, distribution: distZipfian},
+	"d": {proportions: [opCount]float64{opRead: 0.95, opInsert: 0.05}, distribution: distLatest},
+	"e": {proportions: [opCount]float64{opScan: 0.95, opInsert: 0.05}, distribution: distZipfian},
+	"f": {proportions: [opCount]float64{opRead: 0.5, opReadModifyWrite: 0.5}, distribution: distZipfian},
+}
+
+// pick maps a uniform draw u in [0, 1) to an operation.
+func (m *mix) pick(u float64) operation {
+	last := opRead
+
+	for op, p := range m.proportions {
+		if p == 0 {
+			continue
+		}
+
+		last = operation(op)
+		if u < p {
+			return last
+		}
+
+		u -= p
+	}
+
+	return last
+}
+
+// alphabet is what generated field values are drawn from, matching the
+// load's gen.Alpha.
+const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+type opMetrics struct {
+	duration *bench.Metric
+	errors   *bench.Metric
+}
+
+type workload struct {
+	core string
+	mix  mix
+
+	recordCount   int64
+	fieldCount    int
+	fieldLength   int
+	maxScanLength int
+	distribution  string
+	loadWorkers   int
+
+	driverType bench.DriverTypeName
+	stmts      *statements
+	metrics    [opCount]opMetrics
+	latestBase gen.Zipfian // zeta over the loaded records, copied per VU (latest only)
+
+	nextInsert atomic.Int64 // record number the next insert takes
+	inserted   atomic.Int64 // inserts acknowledged so far
+
+	vuStates sync.Map // uint64 -> *vuState
+}
+
+func init() {
+	for _, core := range []string{"a", "b", "c", "d", "e", "f"} {
+		bench.Register(func() bench.Workload { return &workload{core: core, mix: mixes[core]} })
+	}
+}
+
+func (w *workload) Name() string { return "ycsb/" + w.core }
+
+func (w *workload) Define(d *bench.Def) error {
+	w.recordCount = int64(d.Param.Int("record-count", 1000, "Records loaded into usertable.").Value())
+	w.fieldCount = d.Param.Int("field-count", 10, "Fields per record.").Value()
+	w.fieldLength = d.Param.Int("field-length", 100, "Characters per field.").Value()
+	w.maxScanLength = d.Param.Int("max-scan-length", 100, "Longest scan, in records (lengths are uniform).").Value()
+	w.distribution = d.Param.String("request-distribution", w.mix.distribution,
+		"Key distribution: uniform, zipfian or latest.").Value()
+	w.loadWorkers = max(d.Param.Int("load-workers", 1, "Workers used to load usertable.").Value(), 1)
+
+	switch {
+	case w.recordCount < 1:
+		return fmt.Errorf("%w, got %d", errRecordCountMustBePositive, w.recordCount)
+	case w.fieldCount < 1:
+		return fmt.Errorf("%w, got %d", errFieldCountMustBePositive, w.fieldCount)
+	case w.fieldLength < 1:
+		return fmt.Errorf("%w, got %d", errFieldLengthMustBePositive, w.fieldLength)
+	case w.maxScanLength < 1:
+		return fmt.Errorf("%w, got %d", errMaxScanLengthMustBePositive, w.maxScanLength)
+	}
+
+	switch w.distribution {
+	case distUniform, distZipfian, distLatest:
+	default:
+		return fmt.Errorf("%w, got %q", errUnknownDistribution, w.distribution)
+	}
+
+	return nil
+}
+
+func (w *workload) Setup(ctx context.Context, b *bench.Bench) error {
+	w.driverType = b.DriverTypeName()
+	w.stmts = newStatements(w.driverType, w.fieldCount)
+
+	for op, name := range opNames {
+		w.metrics[op] = opMetrics{
+			duration: b.Trend("ycsb_" + name + "_duration"),
+			errors:   b.Counter("ycsb_" + name + "_errors"),
+		}
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{"drop_schema", func() error { return b.Exec(ctx, w.stmts.drop, nil) }},
+		{"create_schema", func() error { return b.Exec(ctx, w.stmts.create, nil) }},
+		{"load_data", func() error {
+			_, err := b.Insert(ctx, usertableRequest(w.recordCount, w.fieldCount, w.fieldLength, w.loadWorkers))
+
+			return err
+		}},
+		{"analyze", func() error { return w.analyze(ctx, b) }},
+	}
+
+	for _, s := range steps {
+		if err := b.Step(s.name, s.fn); err != nil {
+			return err
+		}
+	}
+
+	w.nextInsert.Store(w.recordCount)
+
+	if w.distribution == distLatest {
+		w.latestBase = gen.NewZipfian(uint64(w.recordCount), zipfianTheta)
+	}
+
+	return nil
+}
+
+// analyze refreshes planner statistics after the load on the engines that
+// keep them client-triggerable.
+func (w *workload) analyze(ctx context.Context, b *bench.Bench) error {
+	switch w.driverType {
+	case bench.DriverPostgres:
+		return b.Exec(ctx, "ANALYZE "+table, nil)
+	case bench.DriverMySQL:
+		return b.Exec(ctx, "ANALYZE TABLE "+table, nil)
+	default:
+		return nil
+	}
+}
+
+func (w *workload) Iterate(ctx context.Context, b *bench.Bench) error {
+	vs := w.vuState(b.VUID())
+	op := w.mix.pick(vs.rng.Float64())
+
+	return b.StepSilent("workload", func() error {
+		start := time.Now()
+		err := w.run(ctx, b, vs, op)
+
+		// Fractional milliseconds: key-value operations are often sub-ms.
+		w.metrics[op].duration.Add(float64(time.Since(start)) / float64(time.Millisecond))
+
+		if err != nil {
+			w.metrics[op].errors.Add(1)
+
+			return fmt.Errorf("%s: %w", opNames[op], err)
+		}
+
+		return nil
+	})
+}
+
+func (w *workload) run(ctx context.Context, b *bench.Bench, vs *vuState, op operation) error {
+	switch op {
+	case opUpdate:
+		return w.update(ctx, b, vs, w.chooseKey(vs))
+	case opInsert:
+		return w.insert(ctx, b, vs)
+	case opScan:
+		length := vs.rng.IntN(w.maxScanLength) + 1
+		_, err := b.QueryRows(ctx, w.stmts.scan(length), map[string]any{"key": w.chooseKey(vs)})
+
+		return err
+	case opReadModifyWrite:
+		key := w.chooseKey(vs)
+		if _, err := b.QueryRows(ctx, w.stmts.read, map[string]any{"key": key}); err != nil {
+			return err
+		}
+
+		return w.update(ctx, b, vs, key)
+	default:
+		_, err := b.QueryRows(ctx, w.stmts.read, map[string]any{"key": w.chooseKey(vs)})
+
+		return err
+	}
+}
+
+// update overwrites one random field, as YCSB does with writeallfields=false.
+func (w *workload) update(ctx context.Context, b *bench.Bench, vs *vuState, key string) error {
+	field := vs.rng.IntN(w.fieldCount)
+
+	return b.Exec(ctx, w.stmts.update[field], map[string]any{"key": key, "value": vs.value(w.fieldLength)})
+}
+
+// insert adds the next record past every loaded or inserted one.
+func (w *workload) insert(ctx context.Context, b *bench.Bench, vs *vuState) error {
+	keyNum := w.nextInsert.Add(1) - 1
+
+	args := make(map[string]any, w.fieldCount+1)
+	args["key"] = recordKey(uint64(keyNum)) //nolint:gosec // G115: record numbers are non-negative
+
+	for i := range w.fieldCount {
+		args[fieldName(i)] = vs.value(w.fieldLength)
+	}
+
+	if err := b.Exec(ctx, w.stmts.insert, args); err != nil {
+		return err
+	}
+
+	w.inserted.Add(1)
+
+	return nil
+}
+
+// chooseKey draws the key of an existing record under the request
+// distribution. The keyspace grows with acknowledged inserts; an insert
+// still in flight elsewhere can be drawn and read as no rows, as in YCSB.
+func (w *workload) chooseKey(vs *vuState) string {
+	n := uint64(w.recordCount + w.inserted.Load()) //nolint:gosec // G115: both counts are non-negative
+
+	var keyNum uint64
+
+	switch w.distribution {
+	case distUniform:
+		keyNum = vs.rng.Uint64N(n)
+	case distLatest:
+		// YCSB SkewedLatestGenerator: zipfian distance back from the newest record.
+		vs.latest.Grow(n)
+		keyNum = n - 1 - vs.latest.Next(vs.rng.Float64())
+	default:
+		keyNum = scrambledNext(vs.rng.Float64(), n)
+	}
+
+	return recordKey(keyNum)
+}
+
+func (w *workload) Teardown(context.Context, *bench.Bench) error {
+	return nil
+}
+
+// --- per-VU state ---
+
+type vuState struct {
+	rng    *rand.Rand
+	latest gen.Zipfian // this VU's copy of latestBase, grown as inserts land
+	buf    []byte
+}
+
+func (w *workload) vuState(vuid uint64) *vuState {
+	if v, ok := w.vuStates.Load(vuid); ok {
+		vs, _ := v.(*vuState) //nolint:errcheck // vuStates only stores *vuState values
+
+		return vs
+	}
+
+	vs := &vuState{
+		rng:    rand.New(rand.NewPCG(vuid, vuid*0x9e3779b97f4a7c15)), //nolint:gosec // G404: benchmark RNG
+		latest: w.latestBase,
+	}
+	actual, _ := w.vuStates.LoadOrStore(vuid, vs)
+	stored, _ := actual.(*vuState) //nolint:errcheck // vuStates only stores *vuState values
+
+	return stored
+}
+
+// value draws one field value of length characters.
+func (v *vuState) value(length int) string {
+	v.buf = v.buf[:0]
+	for range length {
+		v.buf = append(v.buf, alphabet[v.rng.IntN(len(alphabet))])
+	}
+
+	return string(v.buf)
+}
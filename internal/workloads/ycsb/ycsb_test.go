@@ -0,0 +1,134 @@
+package ycsb
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+func TestMixesMatchCoreWorkloads(t *testing.T) {
+	for core, m := range mixes {
+		var sum float64
+		for _, p := range m.proportions {
+			sum += p
+		}
+
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("workload %s proportions sum to %v", core, sum)
+		}
+	}
+
+	m := mixes["f"]
+	rng := rand.New(rand.NewPCG(5, 6))
+
+	var counts [opCount]int
+	for range 10_000 {
+		counts[m.pick(rng.Float64())]++
+	}
+
+	if counts[opRead]+counts[opReadModifyWrite] != 10_000 || counts[opRead] < 4500 || counts[opRead] > 5500 {
+		t.Fatalf("workload f op counts = %v", counts)
+	}
+
+	c := mixes["c"]
+	if got := c.pick(0.999999); got != opRead {
+		t.Fatalf("workload c picked %s", opNames[got])
+	}
+}
+
+func TestStatements(t *testing.T) {
+	pg := newStatements(bench.DriverPostgres, 3)
+	if !strings.Contains(pg.create, "field2 TEXT") || strings.Contains(pg.create, "field3") {
+		t.Fatalf("pg create:\n%s", pg.create)
+	}
+
+	if pg.insert != "INSERT INTO usertable (ycsb_key, field0, field1, field2) VALUES (:key, :field0, :field1, :field2)" {
+		t.Fatalf("insert = %s", pg.insert)
+	}
+
+	if len(pg.update) != 3 || pg.update[1] != "UPDATE usertable SET field1 = :value WHERE ycsb_key = :key" {
+		t.Fatalf("update = %v", pg.update)
+	}
+
+	if got := pg.scan(7); !strings.HasSuffix(got, "ORDER BY ycsb_key LIMIT 7") {
+		t.Fatalf("scan = %s", got)
+	}
+
+	if ydb := newStatements(bench.DriverYDB, 1); !strings.Contains(ydb.create, "field0 Utf8") {
+		t.Fatalf("ydb create:\n%s", ydb.create)
+	}
+}
+
+func TestUsertableSource(t *testing.T) {
+	const records, fields, length = 200, 4, 16
+
+	src := usertableSource(gen.New(seedUsertable), records, fields, length)
+	if got := src.Schema().ColumnNames(); len(got) != fields+1 || got[0] != keyColumn {
+		t.Fatalf("columns = %v", got)
+	}
+
+	first := drain(t, src, 0)
+	if len(first) != records {
+		t.Fatalf("rows = %d, want %d", len(first), records)
+	}
+
+	for i, row := range first {
+		if row[0] != recordKey(uint64(i)) {
+			t.Fatalf("row %d key = %s, want %s", i, row[0], recordKey(uint64(i)))
+		}
+
+		for _, field := range row[1:] {
+			if len(field) != length {
+				t.Fatalf("row %d field %q, want %d characters", i, field, length)
+			}
+		}
+	}
+
+	// A suffix load is identical to the same rows of a full load.
+	suffix := drain(t, src, records/2)
+	for i, row := range suffix {
+		if strings.Join(row, ",") != strings.Join(first[records/2+i], ",") {
+			t.Fatalf("row %d differs between a full and a suffix load", records/2+i)
+		}
+	}
+}
+
+func drain(t *testing.T, src gen.BatchSource, start int64) [][]string {
+	t.Helper()
+
+	cur, err := src.Prepare(start, -1, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows [][]string
+
+	for {
+		b, err := cur.Next()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scratch := make([]any, src.Schema().Columns())
+		for i := range b.Len() {
+			b.MaterializeRow(i, scratch)
+
+			row := make([]string, len(scratch))
+			for c, v := range scratch {
+				row[c], _ = v.(string)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+}
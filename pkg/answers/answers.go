@@ -0,0 +1,155 @@
+// Package answers dumps query result sets and compares two dumps. A dump is
+// NDJSON, one Record per query execution; workloads write one with
+// --dump-answers and `stroppy diff-answers` checks a dump from the engine
+// under test against one from a reference engine (usually PostgreSQL) run at
+// the same scale factor, seed and streams.
+//
+// Cells are normalized to text the same way the SF1 answer validators do, so
+// dumps from different drivers compare on values rather than on Go types.
+package answers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is one query execution: its result set, or the error it failed with.
+// Stream and Seed identify the parameter set the query ran with, so the same
+// query from different streams or iterations is compared separately.
+type Record struct {
+	Workload string     `json:"workload,omitempty"`
+	Query    string     `json:"query"`
+	Stream   string     `json:"stream,omitempty"`
+	Seed     int64      `json:"seed,omitempty"`
+	Rows     [][]string `json:"rows,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// Key names the record's query and parameter set in a diff report.
+func (r *Record) Key() string {
+	key := r.Query
+	if r.Stream != "" {
+		key += " stream=" + r.Stream
+	}
+
+	if r.Seed != 0 {
+		key += " seed=" + strconv.FormatInt(r.Seed, 10)
+	}
+
+	return key
+}
+
+// Writer appends records to a dump file. It is safe for concurrent use.
+type Writer struct {
+	mu   sync.Mutex
+	file *os.File
+	out  *bufio.Writer
+	enc  *json.Encoder
+}
+
+// Create truncates or creates the dump file at path.
+func Create(path string) (*Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create answer dump: %w", err)
+	}
+
+	out := bufio.NewWriter(file)
+
+	return &Writer{file: file, out: out, enc: json.NewEncoder(out)}, nil
+}
+
+// Write appends one record.
+func (w *Writer) Write(rec *Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.enc.Encode(rec); err != nil {
+		return fmt.Errorf("write answer dump: %w", err)
+	}
+
+	return nil
+}
+
+// WriteResult appends the outcome of one query: its normalized rows, or err.
+func (w *Writer) WriteResult(rec Record, rows [][]any, err error) error {
+	if err != nil {
+		rec.Error = err.Error()
+	} else {
+		rec.Rows = Cells(rows)
+	}
+
+	return w.Write(&rec)
+}
+
+// Close flushes and closes the dump file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.out.Flush(); err != nil {
+		_ = w.file.Close()
+
+		return fmt.Errorf("flush answer dump: %w", err)
+	}
+
+	return w.file.Close()
+}
+
+// Cells normalizes a driver result set to text cells.
+func Cells(rows [][]any) [][]string {
+	out := make([][]string, len(rows))
+
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, v := range row {
+			cells[j] = NormalizeCell(v)
+		}
+
+		out[i] = cells
+	}
+
+	return out
+}
+
+// NormalizeCell coerces a DB value to a comparison string (dates → ISO date,
+// nil → ""), matching the TPC-H and TPC-DS answer validators.
+func NormalizeCell(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(x)
+	case []byte:
+		return strings.TrimSpace(string(x))
+	case bool:
+		if x {
+			return "t"
+		}
+
+		return "f"
+	case float64:
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return ""
+		}
+
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int32:
+		return strconv.FormatInt(int64(x), 10)
+	case int:
+		return strconv.Itoa(x)
+	case time.Time:
+		return x.UTC().Format("2006-01-02")
+	default:
+		return fmt.Sprint(v)
+	}
+}
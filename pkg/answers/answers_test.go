@@ -0,0 +1,196 @@
+package answers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeDump(t *testing.T, records ...Record) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "answers.ndjson")
+
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range records {
+		if err := w.Write(&records[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestWriteResultNormalizesCells(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.ndjson")
+
+	w, err := Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(1998, 9, 2, 0, 0, 0, 0, time.UTC)
+	if err := w.WriteResult(Record{Query: "q1"}, [][]any{{" A ", int64(7), 1.5, nil, day, true}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.WriteResult(Record{Query: "q2"}, nil, errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"A", "7", "1.5", "", "1998-09-02", "t"}
+	if got := dump.Rows["q1"]; len(got) != 1 || len(got[0]) != len(want) {
+		t.Fatalf("q1 rows = %v", got)
+	}
+
+	for i, cell := range dump.Rows["q1"][0] {
+		if cell != want[i] {
+			t.Fatalf("cell %d = %q, want %q", i, cell, want[i])
+		}
+	}
+
+	if dump.Errors["q2"] != "boom" {
+		t.Fatalf("q2 error = %q", dump.Errors["q2"])
+	}
+}
+
+func TestCompareMultisetWithTolerance(t *testing.T) {
+	ref := writeDump(t,
+		Record{Query: "q1", Stream: "1", Seed: 7, Rows: [][]string{{"a", "1.000"}, {"", "2"}}},
+		Record{Query: "q2", Rows: [][]string{{"x"}}},
+		Record{Query: "q3", Error: "unsupported"},
+		Record{Query: "q10", Rows: [][]string{{"1"}}},
+	)
+	test := writeDump(t,
+		// Reordered rows and a numeric formatting difference still match.
+		Record{Query: "q1", Stream: "1", Seed: 7, Rows: [][]string{{"", "2.0001"}, {"a", "1"}}},
+		Record{Query: "q2", Rows: [][]string{{"y"}}},
+		Record{Query: "q3", Rows: [][]string{{"1"}}},
+		Record{Query: "q10", Error: "timeout"},
+	)
+
+	refDump, err := Load(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testDump, err := Load(test)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := Compare(refDump, testDump, DefaultTolerance)
+
+	want := []struct {
+		key    string
+		status Status
+	}{
+		{"q1 stream=1 seed=7", StatusOK},
+		{"q2", StatusDiff},
+		{"q3", StatusSkip},
+		{"q10", StatusDiff},
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+
+	for i, w := range want {
+		if results[i].Key != w.key || results[i].Status != w.status {
+			t.Fatalf("result %d = %s %s, want %s %s", i, results[i].Key, results[i].Status, w.key, w.status)
+		}
+	}
+
+	if len(results[1].Deltas) != 1 {
+		t.Fatalf("q2 deltas = %v, want one", results[1].Deltas)
+	}
+
+	strict := Compare(refDump, testDump, Tolerance{})
+	if strict[0].Status != StatusDiff {
+		t.Fatal("q1 must differ without tolerance")
+	}
+}
+
+func TestCompareNormalizesQueryNames(t *testing.T) {
+	ref := &Dump{
+		Rows:   map[string][][]string{"query_2": {{"x"}}, "q10": {{"1"}}},
+		Errors: map[string]string{"query_3 stream=1": "timeout"},
+	}
+	test := &Dump{
+		Rows:   map[string][][]string{"q2": {{"x"}}, "query_10": {{"1"}}, "q3 stream=1": {{"1"}}},
+		Errors: map[string]string{},
+	}
+
+	results := Compare(ref, test, DefaultTolerance)
+
+	want := []struct {
+		key    string
+		status Status
+	}{
+		{"query_2", StatusOK},
+		{"query_3 stream=1", StatusSkip},
+		{"q10", StatusOK},
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+
+	for i, w := range want {
+		if results[i].Key != w.key || results[i].Status != w.status {
+			t.Fatalf("result %d = %s %s, want %s %s", i, results[i].Key, results[i].Status, w.key, w.status)
+		}
+	}
+}
+
+func TestLoadLegacyDumpLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.log")
+	log := "noise\n" +
+		"__TPCDS_DUMP__\tquery_1\t[[\"a\",\"1\"]]\n" +
+		`time="t" level=info msg="__TPCDS_DUMP__\tquery_2\tERR:syntax" source=console` + "\n"
+
+	if err := os.WriteFile(path, []byte(log), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dump, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows := dump.Rows["query_1"]; len(rows) != 1 || rows[0][1] != "1" {
+		t.Fatalf("query_1 rows = %v", rows)
+	}
+
+	if dump.Errors["query_2"] != "syntax" {
+		t.Fatalf("query_2 error = %q", dump.Errors["query_2"])
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.log")
+	if err := os.WriteFile(empty, []byte("nothing\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(empty); !errors.Is(err, ErrNoAnswers) {
+		t.Fatalf("want ErrNoAnswers, got %v", err)
+	}
+}
@@ -0,0 +1,388 @@
+package answers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxLine       = 1 << 28 // longest dump line (one result set) accepted
+	initLine      = 1 << 20 // scanner starting buffer
+	maxDeltas     = 5       // mismatching rows described per query
+	legacyMarker  = "__TPCDS_DUMP__\t"
+	legacyErrPfx  = "ERR:"
+	legacyFields  = 2 // name + payload
+	cellSeparator = 0x01
+)
+
+// ErrNoAnswers is returned by Load for a file with no dump records.
+var ErrNoAnswers = errors.New("no answer records")
+
+// Tolerance bounds how far two numeric cells may differ and still match:
+// within Abs absolutely, or within Rel of the reference value (of at least 1).
+type Tolerance struct {
+	Abs float64
+	Rel float64
+}
+
+// DefaultTolerance absorbs decimal/float formatting drift and sub-cent
+// rounding, as in the TPC-DS validator.
+var DefaultTolerance = Tolerance{Abs: 0.01, Rel: 1e-3}
+
+// Dump is a loaded answer dump keyed by Record.Key. When a key repeats, the
+// last record wins.
+type Dump struct {
+	Rows   map[string][][]string
+	Errors map[string]string
+}
+
+// Load reads an NDJSON answer dump. Run logs holding the older
+// "__TPCDS_DUMP__\t<name>\t<json-rows|ERR:msg>" lines load too.
+func Load(path string) (*Dump, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dump := &Dump{Rows: map[string][][]string{}, Errors: map[string]string{}}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, initLine), maxLine)
+
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+
+		if strings.HasPrefix(strings.TrimSpace(text), "{") {
+			var rec Record
+			if err := json.Unmarshal([]byte(text), &rec); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+
+			dump.add(&rec)
+
+			continue
+		}
+
+		if err := dump.addLegacy(unwrapLogMsg(text)); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(dump.Rows) == 0 && len(dump.Errors) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoAnswers, path)
+	}
+
+	return dump, nil
+}
+
+func (d *Dump) add(rec *Record) {
+	key := rec.Key()
+	if rec.Error != "" {
+		d.Errors[key] = rec.Error
+		delete(d.Rows, key)
+
+		return
+	}
+
+	d.Rows[key] = rec.Rows
+	delete(d.Errors, key)
+}
+
+func (d *Dump) addLegacy(line string) error {
+	idx := strings.Index(line, legacyMarker)
+	if idx < 0 {
+		return nil
+	}
+
+	parts := strings.SplitN(line[idx+len(legacyMarker):], "\t", legacyFields)
+	if len(parts) != legacyFields {
+		return nil
+	}
+
+	rec := Record{Query: parts[0]}
+	if msg, ok := strings.CutPrefix(parts[1], legacyErrPfx); ok {
+		rec.Error = msg
+	} else if err := json.Unmarshal([]byte(parts[1]), &rec.Rows); err != nil {
+		return fmt.Errorf("query %s: %w", rec.Query, err)
+	}
+
+	d.add(&rec)
+
+	return nil
+}
+
+// logMsgRe extracts the quoted message field from a logrus text log line:
+//
+//	time="..." level=info msg="__TPCDS_DUMP__\tquery_1\t[[\"..\"]]" source=console
+var logMsgRe = regexp.MustCompile(`msg=("(?:[^"\\]|\\.)*")`)
+
+// unwrapLogMsg returns the unescaped msg field of a logrus record, else the
+// line unchanged (so bare legacy dump files also parse).
+func unwrapLogMsg(line string) string {
+	m := logMsgRe.FindStringSubmatch(line)
+	if m == nil {
+		return line
+	}
+
+	s, err := strconv.Unquote(m[1])
+	if err != nil {
+		return line
+	}
+
+	return s
+}
+
+// Status is the outcome of comparing one query.
+type Status string
+
+const (
+	// StatusOK means the result sets match as multisets.
+	StatusOK Status = "ok"
+	// StatusDiff means the results differ or only the tested engine failed.
+	StatusDiff Status = "diff"
+	// StatusSkip means the reference failed or one side lacks the query.
+	StatusSkip Status = "skip"
+)
+
+// Result is the comparison of one query.
+type Result struct {
+	Key      string
+	Status   Status
+	RefRows  int
+	TestRows int
+	// Detail explains a skip or a test-side error.
+	Detail string
+	// Deltas describe the first mismatching rows of a diff.
+	Deltas []string
+}
+
+// Compare diffs every query in either dump. Rows compare as a multiset:
+// both sides are sorted by a key with numbers rounded to 2 decimals, so tie
+// and NULL ordering differences between engines never read as mismatches,
+// then compared positionally with numeric cells matched within tol. Query
+// names match across spellings (q2, query_2, q02); results carry the name as
+// the reference spells it.
+func Compare(ref, test *Dump, tol Tolerance) []Result {
+	names := map[string]string{} // normalized key -> key as first seen, ref first
+	ref, test = ref.normalized(names), test.normalized(names)
+
+	keys := make([]string, 0, len(ref.Rows)+len(ref.Errors))
+	for _, m := range []map[string][][]string{ref.Rows, test.Rows} {
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, m := range []map[string]string{ref.Errors, test.Errors} {
+		for key := range m {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, compareKeys)
+	keys = slices.Compact(keys)
+
+	results := make([]Result, 0, len(keys))
+	for _, key := range keys {
+		result := compareOne(key, ref, test, tol)
+		result.Key = names[key]
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// normalized returns d keyed by normalizeKey, recording in names the original
+// spelling of each key not seen before.
+func (d *Dump) normalized(names map[string]string) *Dump {
+	out := &Dump{Rows: make(map[string][][]string, len(d.Rows)), Errors: make(map[string]string, len(d.Errors))}
+
+	for key, rows := range d.Rows {
+		out.Rows[normalizeKey(key, names)] = rows
+	}
+
+	for key, msg := range d.Errors {
+		out.Errors[normalizeKey(key, names)] = msg
+	}
+
+	return out
+}
+
+// normalizeKey spells a numbered query key as q<N><rest>, so every spelling
+// of one query sorts, compacts and looks up as one key.
+func normalizeKey(key string, names map[string]string) string {
+	normal := key
+	if n, rest := splitKey(key); n != math.MaxInt32 {
+		normal = "q" + strconv.Itoa(n) + rest
+	}
+
+	if _, seen := names[normal]; !seen {
+		names[normal] = key
+	}
+
+	return normal
+}
+
+func compareOne(key string, ref, test *Dump, tol Tolerance) Result {
+	if msg, failed := ref.Errors[key]; failed {
+		return Result{Key: key, Status: StatusSkip, Detail: "ref error: " + msg}
+	}
+
+	if msg, failed := test.Errors[key]; failed {
+		return Result{Key: key, Status: StatusDiff, Detail: "test error: " + msg}
+	}
+
+	refRows, inRef := ref.Rows[key]
+	testRows, inTest := test.Rows[key]
+
+	if !inRef || !inTest {
+		return Result{Key: key, Status: StatusSkip, Detail: fmt.Sprintf("present ref=%t test=%t", inRef, inTest)}
+	}
+
+	result := Result{Key: key, RefRows: len(refRows), TestRows: len(testRows)}
+
+	result.Deltas = compareRows(sortedRows(refRows), sortedRows(testRows), tol)
+	if len(result.Deltas) == 0 {
+		result.Status = StatusOK
+	} else {
+		result.Status = StatusDiff
+	}
+
+	return result
+}
+
+// compareRows compares two sorted row sets positionally.
+func compareRows(ref, test [][]string, tol Tolerance) []string {
+	var deltas []string
+
+	for i := 0; i < max(len(ref), len(test)) && len(deltas) < maxDeltas; i++ {
+		switch {
+		case i >= len(test):
+			deltas = append(deltas, fmt.Sprintf("row %d: missing in test", i))
+		case i >= len(ref):
+			deltas = append(deltas, fmt.Sprintf("row %d: extra in test", i))
+		default:
+			for c := range max(len(ref[i]), len(test[i])) {
+				if a, b := cell(ref[i], c), cell(test[i], c); !tol.match(a, b) {
+					deltas = append(deltas, fmt.Sprintf("row %d col %d: ref=%q test=%q", i, c, a, b))
+
+					break
+				}
+			}
+		}
+	}
+
+	return deltas
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+
+	return ""
+}
+
+var numericRe = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// match reports whether a test cell equals a reference cell, exactly or, for
+// two numbers, within the tolerance.
+func (t Tolerance) match(ref, test string) bool {
+	if ref == test {
+		return true
+	}
+
+	if !numericRe.MatchString(ref) || !numericRe.MatchString(test) {
+		return false
+	}
+
+	x, _ := strconv.ParseFloat(ref, 64)
+	y, _ := strconv.ParseFloat(test, 64)
+
+	diff := math.Abs(x - y)
+	if diff <= t.Abs {
+		return true
+	}
+
+	return diff/math.Max(math.Abs(x), 1) <= t.Rel
+}
+
+type keyedRow struct {
+	key string
+	row []string
+}
+
+func sortedRows(rows [][]string) [][]string {
+	keyed := make([]keyedRow, len(rows))
+	for i, row := range rows {
+		keyed[i] = keyedRow{key: rowKey(row), row: row}
+	}
+
+	slices.SortStableFunc(keyed, func(a, b keyedRow) int { return strings.Compare(a.key, b.key) })
+
+	out := make([][]string, len(rows))
+	for i, k := range keyed {
+		out[i] = k.row
+	}
+
+	return out
+}
+
+// rowKey rounds numeric cells to 2 decimals so near-equal rows sort together.
+func rowKey(cells []string) string {
+	var sb strings.Builder
+
+	for _, c := range cells {
+		if numericRe.MatchString(c) {
+			f, _ := strconv.ParseFloat(c, 64)
+			sb.WriteString(strconv.FormatFloat(f, 'f', 2, 64))
+		} else {
+			sb.WriteString(c)
+		}
+
+		sb.WriteByte(cellSeparator)
+	}
+
+	return sb.String()
+}
+
+var queryNumberRe = regexp.MustCompile(`^(query_|q)(\d+)(.*)$`)
+
+// compareKeys orders keys naturally, so query_2 and q2 sort before query_10
+// and q10; other names sort after numbered queries.
+func compareKeys(a, b string) int {
+	na, ra := splitKey(a)
+	nb, rb := splitKey(b)
+
+	if na != nb {
+		return na - nb
+	}
+
+	return strings.Compare(ra, rb)
+}
+
+func splitKey(key string) (int, string) {
+	m := queryNumberRe.FindStringSubmatch(key)
+	if m == nil {
+		return math.MaxInt32, key
+	}
+
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return math.MaxInt32, key
+	}
+
+	return n, m[3]
+}
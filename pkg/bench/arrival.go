@@ -0,0 +1,178 @@
+package bench
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// --- executor (constant-arrival-rate + ramping-arrival-rate) ---
+
+// arrivalSchedule yields the start offsets of an open-model scenario. Each
+// stage moves the rate linearly from the previous stage's rate to its own
+// target, so iteration k starts when the integrated rate reaches k.
+type arrivalSchedule struct {
+	stages []arrivalStage
+
+	stage     int
+	stageBase float64
+	next      float64
+}
+
+type arrivalStage struct {
+	start    time.Duration
+	duration time.Duration
+	from     float64 // iterations per second at stage start
+	to       float64 // iterations per second at stage end
+}
+
+func newArrivalSchedule(startRate int, stages []Stage, timeUnit time.Duration) *arrivalSchedule {
+	perSecond := func(rate int) float64 { return float64(rate) / timeUnit.Seconds() }
+
+	schedule := &arrivalSchedule{stages: make([]arrivalStage, 0, len(stages))}
+
+	var offset time.Duration
+
+	from := perSecond(startRate)
+	for _, stage := range stages {
+		to := perSecond(stage.Target)
+		schedule.stages = append(schedule.stages, arrivalStage{
+			start: offset, duration: stage.Duration, from: from, to: to,
+		})
+		offset += stage.Duration
+		from = to
+	}
+
+	return schedule
+}
+
+// total returns the scenario length covered by the schedule.
+func (s *arrivalSchedule) total() time.Duration {
+	if len(s.stages) == 0 {
+		return 0
+	}
+
+	last := s.stages[len(s.stages)-1]
+
+	return last.start + last.duration
+}
+
+// nextOffset returns the start offset of the next iteration, or false once the
+// schedule is exhausted.
+func (s *arrivalSchedule) nextOffset() (time.Duration, bool) {
+	for s.stage < len(s.stages) {
+		stage := s.stages[s.stage]
+		seconds := stage.duration.Seconds()
+		count := (stage.from + stage.to) / 2 * seconds
+
+		n := s.next - s.stageBase
+		if n < count {
+			s.next++
+
+			return stage.start + time.Duration(stageOffsetSeconds(stage, seconds, n)*float64(time.Second)), true
+		}
+
+		s.stageBase += count
+		s.stage++
+	}
+
+	return 0, false
+}
+
+// stageOffsetSeconds solves from*t + (to-from)*t²/(2*seconds) = n for t.
+func stageOffsetSeconds(stage arrivalStage, seconds, n float64) float64 {
+	slope := (stage.to - stage.from) / (2 * seconds)
+	if slope == 0 {
+		return n / stage.from
+	}
+
+	discriminant := max(stage.from*stage.from+4*slope*n, 0)
+
+	return (-stage.from + math.Sqrt(discriminant)) / (2 * slope)
+}
+
+// runArrivalScenario starts iterations on the schedule regardless of how long
+// earlier ones take. Idle VUs pick up each start; when none is idle a new VU is
+// allocated up to maxVUs, otherwise the iteration is dropped and counted.
+func runArrivalScenario(
+	ctx context.Context,
+	sc scenarioSpec,
+	schedule *arrivalSchedule,
+	startWorker func(vuid int, keep func() bool),
+) {
+	schedVU := &VU{root: root, scenario: sc.tag, ctx: ctx}
+	starts := make(chan time.Time)
+
+	allocated := 0
+
+	keep := func() bool {
+		select {
+		case scheduled, ok := <-starts:
+			if !ok {
+				return false
+			}
+
+			root.txMetrics.recordIterationLag(schedVU, time.Since(scheduled))
+
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	allocate := func() bool {
+		if allocated >= sc.maxVUs {
+			return false
+		}
+
+		allocated++
+		startWorker(allocated, keep)
+
+		return true
+	}
+
+	for range sc.vus {
+		allocate()
+	}
+
+	defer close(starts)
+
+	begin := time.Now()
+	timer := time.NewTimer(0)
+
+	defer timer.Stop()
+
+	for {
+		offset, ok := schedule.nextOffset()
+		if !ok {
+			return
+		}
+
+		scheduled := begin.Add(offset)
+		timer.Reset(time.Until(scheduled))
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case starts <- scheduled:
+			continue
+		default:
+		}
+
+		if !allocate() {
+			root.txMetrics.recordDroppedIteration(schedVU)
+
+			continue
+		}
+
+		select {
+		case starts <- scheduled:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
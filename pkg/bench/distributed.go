@@ -0,0 +1,338 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+// --- distributed runs (stroppy agent / run --agents) ---
+
+var errDistributedScenarios = errors.New(`named "scenarios" cannot be split across agents`)
+
+// RunHooks let the caller of Run gate and observe it from outside the process:
+// a stroppy agent holds every Setup at its coordinator's barrier and ships the
+// final metrics back.
+type RunHooks struct {
+	// Ready is called once every Setup succeeded, before the first scenario
+	// starts. Run waits for it and aborts with its error.
+	Ready func(ctx context.Context) error
+	// Collected receives the run's final metric data before the summary prints.
+	Collected func(MetricsPayload)
+}
+
+type runHooksKey struct{}
+
+// WithRunHooks returns ctx carrying hooks for the Run it is passed to.
+func WithRunHooks(ctx context.Context, hooks RunHooks) context.Context {
+	return context.WithValue(ctx, runHooksKey{}, hooks)
+}
+
+// RunHooksFrom returns the hooks WithRunHooks put on ctx, or zero hooks.
+func RunHooksFrom(ctx context.Context) RunHooks {
+	hooks, _ := ctx.Value(runHooksKey{}).(RunHooks)
+
+	return hooks
+}
+
+// MetricsPayload is a run's final metric data in a form that crosses process
+// boundaries. Names carry no metrics prefix; tags are the series attributes.
+type MetricsPayload struct {
+	Metrics []PayloadMetric `json:"metrics"`
+}
+
+// PayloadMetric is one metric of a MetricsPayload.
+type PayloadMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Unit        string         `json:"unit,omitempty"`
+	Kind        string         `json:"kind"` // "counter", "gauge", "trend" or "exponential"
+	Cumulative  bool           `json:"cumulative"`
+	Points      []PayloadPoint `json:"points"`
+}
+
+// PayloadPoint is one series of a PayloadMetric. Counters and gauges fill
+// Value; trends fill Count, Sum and the bucket layout of their kind.
+type PayloadPoint struct {
+	Tags    map[string]string `json:"tags,omitempty"`
+	Value   float64           `json:"value,omitempty"`
+	Count   uint64            `json:"count,omitempty"`
+	Sum     float64           `json:"sum,omitempty"`
+	Bounds  []float64         `json:"bounds,omitempty"`
+	Buckets []uint64          `json:"buckets,omitempty"`
+
+	Scale          int32    `json:"scale,omitempty"`
+	ZeroCount      uint64   `json:"zero_count,omitempty"`
+	PositiveOffset int32    `json:"positive_offset,omitempty"`
+	Positive       []uint64 `json:"positive,omitempty"`
+	NegativeOffset int32    `json:"negative_offset,omitempty"`
+	Negative       []uint64 `json:"negative,omitempty"`
+}
+
+func newMetricsPayload(data metricdata.ResourceMetrics, prefix string) MetricsPayload {
+	var payload MetricsPayload
+
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			out := PayloadMetric{
+				Name:        strings.TrimPrefix(metric.Name, prefix),
+				Description: metric.Description,
+				Unit:        metric.Unit,
+			}
+
+			switch aggregation := metric.Data.(type) {
+			case metricdata.Sum[float64]:
+				out.Kind, out.Cumulative = "counter", aggregation.Temporality == metricdata.CumulativeTemporality
+				for _, point := range aggregation.DataPoints {
+					out.Points = append(out.Points, PayloadPoint{Tags: exportTags(point.Attributes), Value: point.Value})
+				}
+			case metricdata.Gauge[float64]:
+				out.Kind = "gauge"
+				for _, point := range aggregation.DataPoints {
+					out.Points = append(out.Points, PayloadPoint{Tags: exportTags(point.Attributes), Value: point.Value})
+				}
+			case metricdata.Histogram[float64]:
+				out.Kind, out.Cumulative = "trend", aggregation.Temporality == metricdata.CumulativeTemporality
+				for _, point := range aggregation.DataPoints {
+					out.Points = append(out.Points, PayloadPoint{
+						Tags: exportTags(point.Attributes), Count: point.Count, Sum: point.Sum,
+						Bounds: point.Bounds, Buckets: point.BucketCounts,
+					})
+				}
+			case metricdata.ExponentialHistogram[float64]:
+				out.Kind, out.Cumulative = "exponential", aggregation.Temporality == metricdata.CumulativeTemporality
+				for _, point := range aggregation.DataPoints {
+					out.Points = append(out.Points, PayloadPoint{
+						Tags: exportTags(point.Attributes), Count: point.Count, Sum: point.Sum,
+						Scale: point.Scale, ZeroCount: point.ZeroCount,
+						PositiveOffset: point.PositiveBucket.Offset, Positive: point.PositiveBucket.Counts,
+						NegativeOffset: point.NegativeBucket.Offset, Negative: point.NegativeBucket.Counts,
+					})
+				}
+			default:
+				continue
+			}
+
+			payload.Metrics = append(payload.Metrics, out)
+		}
+	}
+
+	return payload
+}
+
+// Tagged returns a copy of p with tag key=value added to every series, so
+// merged payloads keep their origin apart.
+func (p MetricsPayload) Tagged(key, value string) MetricsPayload {
+	out := MetricsPayload{Metrics: make([]PayloadMetric, len(p.Metrics))}
+
+	for i, metric := range p.Metrics {
+		metric.Points = slices.Clone(metric.Points)
+		for j, point := range metric.Points {
+			tags := maps.Clone(point.Tags)
+			if tags == nil {
+				tags = map[string]string{}
+			}
+
+			tags[key] = value
+			metric.Points[j].Tags = tags
+		}
+
+		out.Metrics[i] = metric
+	}
+
+	return out
+}
+
+// MergeMetrics joins payloads into one: the series of same-named metrics are
+// concatenated in payload order, so the summary aggregates them as one run.
+func MergeMetrics(payloads ...MetricsPayload) MetricsPayload {
+	var (
+		merged MetricsPayload
+		index  = map[string]int{}
+	)
+
+	for _, payload := range payloads {
+		for _, metric := range payload.Metrics {
+			i, ok := index[metric.Name]
+			if !ok {
+				index[metric.Name] = len(merged.Metrics)
+				metric.Points = slices.Clone(metric.Points)
+				merged.Metrics = append(merged.Metrics, metric)
+
+				continue
+			}
+
+			merged.Metrics[i].Points = append(merged.Metrics[i].Points, metric.Points...)
+		}
+	}
+
+	return merged
+}
+
+// resourceMetrics rebuilds the OTel metric data a payload was taken from.
+func (p MetricsPayload) resourceMetrics() metricdata.ResourceMetrics {
+	metrics := make([]metricdata.Metrics, 0, len(p.Metrics))
+
+	for _, metric := range p.Metrics {
+		temporality := metricdata.DeltaTemporality
+		if metric.Cumulative {
+			temporality = metricdata.CumulativeTemporality
+		}
+
+		out := metricdata.Metrics{Name: metric.Name, Description: metric.Description, Unit: metric.Unit}
+
+		switch metric.Kind {
+		case "counter":
+			sum := metricdata.Sum[float64]{Temporality: temporality, IsMonotonic: true}
+			for _, point := range metric.Points {
+				sum.DataPoints = append(sum.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: tagSet(point.Tags), Value: point.Value,
+				})
+			}
+
+			out.Data = sum
+		case "gauge":
+			gauge := metricdata.Gauge[float64]{}
+			for _, point := range metric.Points {
+				gauge.DataPoints = append(gauge.DataPoints, metricdata.DataPoint[float64]{
+					Attributes: tagSet(point.Tags), Value: point.Value,
+				})
+			}
+
+			out.Data = gauge
+		case "trend":
+			histogram := metricdata.Histogram[float64]{Temporality: temporality}
+			for _, point := range metric.Points {
+				histogram.DataPoints = append(histogram.DataPoints, metricdata.HistogramDataPoint[float64]{
+					Attributes: tagSet(point.Tags), Count: point.Count, Sum: point.Sum,
+					Bounds: point.Bounds, BucketCounts: point.Buckets,
+				})
+			}
+
+			out.Data = histogram
+		case "exponential":
+			histogram := metricdata.ExponentialHistogram[float64]{Temporality: temporality}
+			for _, point := range metric.Points {
+				histogram.DataPoints = append(histogram.DataPoints, metricdata.ExponentialHistogramDataPoint[float64]{
+					Attributes: tagSet(point.Tags), Count: point.Count, Sum: point.Sum,
+					Scale: point.Scale, ZeroCount: point.ZeroCount,
+					PositiveBucket: metricdata.ExponentialBucket{Offset: point.PositiveOffset, Counts: point.Positive},
+					NegativeBucket: metricdata.ExponentialBucket{Offset: point.NegativeOffset, Counts: point.Negative},
+				})
+			}
+
+			out.Data = histogram
+		default:
+			continue
+		}
+
+		metrics = append(metrics, out)
+	}
+
+	return metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: metrics}}}
+}
+
+func tagSet(tags map[string]string) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		kvs = append(kvs, attribute.String(key, tags[key]))
+	}
+
+	return attribute.NewSet(kvs...)
+}
+
+// ResolveParams defines workload name against inputs and returns every
+// resolved parameter value by name, run parameters included. A coordinator
+// reads the values it splits across agents from it. Runs with named scenarios
+// are rejected: their per-scenario values have no single resolution.
+func ResolveParams(name string, inputs ParamInputs) (map[string]any, error) {
+	plan, err := parseScenarioPlan(name, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("scenarios: %w", err)
+	}
+
+	if plan.named {
+		return nil, errDistributedScenarios
+	}
+
+	prepared, err := prepareScenario(plan.scenarios[0], false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(prepared.params))
+	for _, param := range prepared.params {
+		values[param.name] = param.value
+	}
+
+	return values, nil
+}
+
+// DistributedRun is the coordinator's view of a run its agents executed.
+type DistributedRun struct {
+	Workload string
+	Drivers  map[int]*stroppy.DriverConfig
+	Params   ParamInputs
+	Metrics  *MetricsConfig
+	// Data is the merged metric data of every agent.
+	Data MetricsPayload
+	// Started and Elapsed bound the measured phase: from the start barrier to
+	// the last agent finishing.
+	Started time.Time
+	Elapsed time.Duration
+}
+
+// SummarizeDistributed reports a distributed run the way Run reports its own:
+// the summary table, thresholds and summary export over the merged data. A
+// crossed threshold only fails a run that otherwise succeeded.
+func SummarizeDistributed(run DistributedRun, runErr error) error {
+	config := run.Metrics
+	if config == nil {
+		config = &MetricsConfig{}
+	}
+
+	thresholds, err := parseThresholds(config.Thresholds)
+	if err != nil {
+		return errors.Join(runErr, err)
+	}
+
+	var scenarios []*preparedScenario
+
+	plan, err := parseScenarioPlan(run.Workload, run.Params)
+	if err == nil && !plan.named {
+		if prepared, err := prepareScenario(plan.scenarios[0], false, nil); err == nil {
+			scenarios = append(scenarios, prepared)
+		}
+	}
+
+	data := run.Data.resourceMetrics()
+	sum := &summary{
+		collect: func(out *metricdata.ResourceMetrics) error {
+			*out = data
+
+			return nil
+		},
+		config: summaryConfig{
+			thresholds: thresholds,
+			exportPath: config.SummaryExport,
+			runID:      config.RunID,
+			workload:   run.Workload,
+			drivers:    run.Drivers,
+			scenarios:  scenarios,
+		},
+		created: run.Started,
+		began:   run.Started,
+		elapsed: run.Elapsed,
+	}
+
+	return sum.report(runErr)
+}
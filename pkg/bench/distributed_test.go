@@ -0,0 +1,86 @@
+package bench
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricsPayloadRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tags := attribute.NewSet(attribute.String("tx_name", "new_order"))
+	data := metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: []metricdata.Metrics{
+		{Name: "bench_iterations_total", Data: metricdata.Sum[float64]{
+			Temporality: metricdata.CumulativeTemporality, IsMonotonic: true,
+			DataPoints: []metricdata.DataPoint[float64]{{Value: 7}},
+		}},
+		{Name: "bench_tx_total_duration", Unit: "ms", Data: metricdata.Histogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.HistogramDataPoint[float64]{{
+				Attributes: tags, Count: 3, Sum: 12, Bounds: []float64{1, 10}, BucketCounts: []uint64{1, 1, 1},
+			}},
+		}},
+		{Name: "bench_iteration_duration", Data: metricdata.ExponentialHistogram[float64]{
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{{
+				Count: 2, Sum: 3, Scale: 4, ZeroCount: 0,
+				PositiveBucket: metricdata.ExponentialBucket{Offset: 3, Counts: []uint64{1, 0, 1}},
+			}},
+		}},
+	}}}}
+
+	raw, err := json.Marshal(newMetricsPayload(data, "bench_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload MetricsPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := payload.resourceMetrics().ScopeMetrics[0].Metrics
+	if len(metrics) != 3 || metrics[0].Name != "iterations_total" || metrics[1].Unit != "ms" {
+		t.Fatalf("metrics = %+v", metrics)
+	}
+
+	histogram := metrics[1].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if !histogram.Attributes.Equals(&tags) || histogram.Count != 3 || histogram.BucketCounts[2] != 1 {
+		t.Fatalf("histogram point = %+v", histogram)
+	}
+
+	exponential := metrics[2].Data.(metricdata.ExponentialHistogram[float64]).DataPoints[0]
+	if exponential.Scale != 4 || exponential.PositiveBucket.Offset != 3 || len(exponential.PositiveBucket.Counts) != 3 {
+		t.Fatalf("exponential point = %+v", exponential)
+	}
+}
+
+func TestMergeMetricsKeepsAgentsApart(t *testing.T) {
+	t.Parallel()
+
+	counter := func(value float64) MetricsPayload {
+		return MetricsPayload{Metrics: []PayloadMetric{{
+			Name: "iterations_total", Kind: "counter", Cumulative: true,
+			Points: []PayloadPoint{{Tags: map[string]string{"scenario": "default"}, Value: value}},
+		}}}
+	}
+
+	first := counter(3)
+	merged := MergeMetrics(first.Tagged("agent", "a:1"), counter(4).Tagged("agent", "b:1"))
+
+	if len(merged.Metrics) != 1 || len(merged.Metrics[0].Points) != 2 {
+		t.Fatalf("merged = %+v", merged)
+	}
+
+	second := merged.Metrics[0].Points[1]
+	if second.Value != 4 || second.Tags["agent"] != "b:1" || second.Tags["scenario"] != "default" {
+		t.Fatalf("second point = %+v", second)
+	}
+
+	if _, tagged := first.Metrics[0].Points[0].Tags["agent"]; tagged {
+		t.Fatal("Tagged modified its receiver")
+	}
+}
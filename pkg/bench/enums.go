@@ -25,6 +25,8 @@ const (
 	DriverYDB      DriverTypeName = "ydb"
 	DriverNoop     DriverTypeName = "noop"
 	DriverCSV      DriverTypeName = "csv"
+	DriverSQLite   DriverTypeName = "sqlite"
+	DriverGeneric  DriverTypeName = "generic_sql"
 )
 
 func ParseDriverType(s string) (stroppy.DriverConfig_DriverType, error) {
@@ -41,6 +43,10 @@ func ParseDriverType(s string) (stroppy.DriverConfig_DriverType, error) {
 		return stroppy.DriverConfig_DRIVER_TYPE_NOOP, nil
 	case "csv":
 		return stroppy.DriverConfig_DRIVER_TYPE_CSV, nil
+	case "sqlite":
+		return stroppy.DriverConfig_DRIVER_TYPE_SQLITE, nil
+	case "generic_sql":
+		return stroppy.DriverConfig_DRIVER_TYPE_GENERIC_SQL, nil
 	default:
 		return 0, fmt.Errorf("%w %q", errUnknownDriverType, s)
 	}
@@ -62,6 +68,10 @@ func DriverTypeNameFromProto(t stroppy.DriverConfig_DriverType) DriverTypeName {
 		return DriverNoop
 	case stroppy.DriverConfig_DRIVER_TYPE_CSV:
 		return DriverCSV
+	case stroppy.DriverConfig_DRIVER_TYPE_SQLITE:
+		return DriverSQLite
+	case stroppy.DriverConfig_DRIVER_TYPE_GENERIC_SQL:
+		return DriverGeneric
 	default:
 		return ""
 	}
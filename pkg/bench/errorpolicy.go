@@ -0,0 +1,177 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// --- iteration error policy + active VU accounting ---
+
+const (
+	onErrorContinue = "continue"
+	onErrorBackoff  = "backoff"
+	onErrorAbort    = "abort"
+
+	// errorBackoffCapFactor bounds the backoff pause at this multiple of error-backoff.
+	errorBackoffCapFactor = 30
+	// errorWindowBuckets is the resolution of the error-rate sliding window.
+	errorWindowBuckets = 10
+)
+
+var (
+	errAbortOnError      = errors.New("scenario aborted on iteration error")
+	errMaxErrorsReached  = errors.New("scenario aborted: max-errors reached")
+	errMaxErrorRateAbove = errors.New("scenario aborted: error rate above max-error-rate")
+)
+
+// errorPolicy decides what a VU does after a non-fatal iteration error and
+// when accumulated errors abort the scenario.
+type errorPolicy struct {
+	onError   string
+	backoff   time.Duration
+	maxErrors int64
+	maxRate   float64 // percent of iterations in window; 0 disables
+	window    time.Duration
+}
+
+// scenarioRun is the state the workers of one running scenario share.
+type scenarioRun struct {
+	tag    string
+	policy errorPolicy
+
+	errors atomic.Int64
+	rate   *errorWindow
+
+	activeMu sync.Mutex
+	active   int64
+	gaugeVU  *VU
+}
+
+func newScenarioRun(ctx context.Context, sc scenarioSpec) *scenarioRun {
+	run := &scenarioRun{
+		tag:     sc.tag,
+		policy:  sc.errors,
+		gaugeVU: &VU{root: root, scenario: sc.tag, ctx: ctx},
+	}
+
+	if sc.errors.maxRate > 0 {
+		run.rate = newErrorWindow(sc.errors.window, time.Now())
+	}
+
+	return run
+}
+
+// vuStarted and vuStopped keep the vus_active gauge at the number of VUs that
+// are running or ready to run an iteration.
+func (r *scenarioRun) vuStarted() { r.addActive(1) }
+func (r *scenarioRun) vuStopped() { r.addActive(-1) }
+
+func (r *scenarioRun) addActive(delta int64) {
+	r.activeMu.Lock()
+	defer r.activeMu.Unlock()
+
+	r.active += delta
+	root.txMetrics.recordActiveVUs(r.gaugeVU, r.active)
+}
+
+// observe records one iteration outcome and returns a fatal error once the
+// scenario's error thresholds are exceeded.
+func (r *scenarioRun) observe(failed bool, cause error) error {
+	if failed && r.policy.maxErrors > 0 {
+		if count := r.errors.Add(1); count >= r.policy.maxErrors {
+			return &FatalError{err: fmt.Errorf("%w (%d): %w", errMaxErrorsReached, count, cause)}
+		}
+	}
+
+	if r.rate == nil {
+		return nil
+	}
+
+	rate, ok := r.rate.observe(time.Now(), failed)
+	if ok && rate > r.policy.maxRate {
+		if cause == nil {
+			return &FatalError{err: fmt.Errorf("%w (%.1f%%)", errMaxErrorRateAbove, rate)}
+		}
+
+		return &FatalError{err: fmt.Errorf("%w (%.1f%%): %w", errMaxErrorRateAbove, rate, cause)}
+	}
+
+	return nil
+}
+
+// afterError applies on-error to a VU whose iteration just failed for the
+// consecutive-th time in a row. A non-nil result stops the VU.
+func (r *scenarioRun) afterError(ctx context.Context, consecutive int, cause error) error {
+	switch r.policy.onError {
+	case onErrorAbort:
+		return &FatalError{err: fmt.Errorf("%w: %w", errAbortOnError, cause)}
+	case onErrorBackoff:
+		base := r.policy.backoff.Seconds()
+
+		return sleepForRetry(ctx, backoffSeconds(consecutive, base, base*errorBackoffCapFactor))
+	default:
+		return nil
+	}
+}
+
+// errorWindow tracks the error rate over a sliding window split into
+// errorWindowBuckets buckets. The rate is only reported once the buckets span
+// a whole window, so a single early failure cannot abort the scenario.
+type errorWindow struct {
+	mu      sync.Mutex
+	start   time.Time
+	width   time.Duration // window / errorWindowBuckets
+	buckets [errorWindowBuckets]errorBucket
+}
+
+type errorBucket struct {
+	slot       int64
+	iterations int64
+	errors     int64
+}
+
+func newErrorWindow(window time.Duration, start time.Time) *errorWindow {
+	return &errorWindow{
+		start: start,
+		width: max(window/errorWindowBuckets, time.Millisecond),
+	}
+}
+
+// observe adds one iteration at now and returns the error percentage over the
+// window, with false until the first window is complete.
+func (w *errorWindow) observe(now time.Time, failed bool) (float64, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	elapsed := now.Sub(w.start)
+	slot := int64(elapsed / w.width)
+
+	bucket := &w.buckets[slot%errorWindowBuckets]
+	if bucket.slot != slot {
+		*bucket = errorBucket{slot: slot}
+	}
+
+	bucket.iterations++
+	if failed {
+		bucket.errors++
+	}
+
+	if slot < errorWindowBuckets-1 {
+		return 0, false
+	}
+
+	var iterations, errs int64
+
+	for _, b := range w.buckets {
+		if b.slot > slot-errorWindowBuckets && b.iterations > 0 {
+			iterations += b.iterations
+			errs += b.errors
+		}
+	}
+
+	return float64(errs) / float64(iterations) * percentScale, true
+}
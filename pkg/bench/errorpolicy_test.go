@@ -0,0 +1,133 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestErrorWindowReportsAfterFullWindow(t *testing.T) {
+	start := time.Unix(0, 0)
+	window := newErrorWindow(time.Second, start)
+
+	if _, ok := window.observe(start, true); ok {
+		t.Fatal("error rate reported before the first window elapsed")
+	}
+
+	window.observe(start.Add(500*time.Millisecond), false)
+	window.observe(start.Add(700*time.Millisecond), false)
+
+	rate, ok := window.observe(start.Add(950*time.Millisecond), false)
+	if !ok || rate != 25 {
+		t.Fatalf("rate = %v, %v; want 25, true", rate, ok)
+	}
+
+	// The failure at 0s has slid out of the window by 1.5s.
+	rate, ok = window.observe(start.Add(1500*time.Millisecond), false)
+	if !ok || rate != 0 {
+		t.Fatalf("rate = %v, %v; want 0, true", rate, ok)
+	}
+}
+
+func TestRunScenarioContinuesAfterIterationErrors(t *testing.T) {
+	installRuntimeTestRoot(t)
+
+	var calls atomic.Int64
+
+	err := runScenario(context.Background(), scenarioSpec{
+		executor:   "shared-iterations",
+		vus:        2,
+		iterations: 10,
+		errors:     errorPolicy{onError: onErrorContinue, window: time.Minute},
+	}, func(*VU) error {
+		calls.Add(1)
+
+		return errors.New("iteration")
+	})
+	if err != nil {
+		t.Fatalf("runScenario() error = %v, want nil", err)
+	}
+
+	if got := calls.Load(); got != 10 {
+		t.Fatalf("iterations = %d, want 10 (failing VUs keep running)", got)
+	}
+
+	metrics, err := (&Bench{root: root, vu: &VU{}}).CollectedMetrics()
+	if err != nil {
+		t.Fatalf("CollectedMetrics() error = %v", err)
+	}
+
+	if active := metrics["vus_active"].Total; active != 0 {
+		t.Fatalf("vus_active = %v after the scenario, want 0", active)
+	}
+}
+
+func TestRunScenarioErrorPolicyAborts(t *testing.T) {
+	sentinel := errors.New("iteration")
+
+	for _, tc := range []struct {
+		name   string
+		policy errorPolicy
+		want   error
+		calls  int64
+	}{
+		{"on-error abort", errorPolicy{onError: onErrorAbort, window: time.Minute}, errAbortOnError, 1},
+		{"max-errors", errorPolicy{onError: onErrorContinue, maxErrors: 3, window: time.Minute}, errMaxErrorsReached, 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			installRuntimeTestRoot(t)
+
+			var calls atomic.Int64
+
+			err := runScenario(context.Background(), scenarioSpec{
+				executor:   "shared-iterations",
+				vus:        1,
+				iterations: 100,
+				errors:     tc.policy,
+			}, func(*VU) error {
+				calls.Add(1)
+
+				return sentinel
+			})
+
+			if !errors.Is(err, tc.want) || !errors.Is(err, sentinel) || !IsFatalError(err) {
+				t.Fatalf("runScenario() error = %v, want fatal %v wrapping the iteration error", err, tc.want)
+			}
+
+			if got := calls.Load(); got != tc.calls {
+				t.Fatalf("iterations = %d, want %d", got, tc.calls)
+			}
+		})
+	}
+}
+
+func TestRunScenarioErrorBackoffPausesFailingVU(t *testing.T) {
+	installRuntimeTestRoot(t)
+
+	var calls atomic.Int64
+
+	start := time.Now()
+
+	err := runScenario(context.Background(), scenarioSpec{
+		executor:   "shared-iterations",
+		vus:        1,
+		iterations: 3,
+		errors:     errorPolicy{onError: onErrorBackoff, backoff: 20 * time.Millisecond, window: time.Minute},
+	}, func(*VU) error {
+		if calls.Add(1) < 3 {
+			return errors.New("iteration")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runScenario() error = %v, want nil", err)
+	}
+
+	// Two consecutive failures back off 20ms, then 40ms.
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("scenario finished after %s, want at least the 60ms of backoff", elapsed)
+	}
+}
@@ -0,0 +1,191 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// --- exponential trend histograms (configurable latency precision) ---
+
+const (
+	// exponentialTrendMaxSize bounds the buckets of one series. At 1% (scale
+	// 7, 128 buckets per doubling) that spans 64 doublings; a wider range makes
+	// the SDK halve the resolution rather than drop samples.
+	exponentialTrendMaxSize  = 8192
+	exponentialTrendMinScale = -10
+	exponentialTrendMaxScale = 20
+)
+
+var errLatencyPrecision = errors.New("latency precision must be a fraction between 0 and 1 or a percentage")
+
+// ParseLatencyPrecision parses a relative error such as "1%" or "0.01". An
+// empty value keeps the fixed duration buckets and returns 0.
+func ParseLatencyPrecision(raw string) (float64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+
+	scale := 1.0
+	if trimmed, ok := strings.CutSuffix(raw, "%"); ok {
+		raw, scale = strings.TrimSpace(trimmed), 100
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w, got %q", errLatencyPrecision, raw)
+	}
+
+	precision := value / scale
+	if err := validateLatencyPrecision(precision); err != nil {
+		return 0, err
+	}
+
+	return precision, nil
+}
+
+func validateLatencyPrecision(precision float64) error {
+	if math.IsNaN(precision) || precision < 0 || precision >= 1 {
+		return fmt.Errorf("%w, got %v", errLatencyPrecision, precision)
+	}
+
+	return nil
+}
+
+// exponentialTrendScale returns the coarsest scale whose relative bucket width,
+// 2^(2^-scale) - 1, is at most precision.
+func exponentialTrendScale(precision float64) int32 {
+	scale := int32(math.Ceil(-math.Log2(math.Log2(1 + precision))))
+
+	return min(max(scale, exponentialTrendMinScale), exponentialTrendMaxScale)
+}
+
+// latencyView records millisecond trends as base-2 exponential histograms, so
+// a quantile is off by at most precision instead of by the gap between two
+// fixed bucket bounds.
+func latencyView(precision float64) sdkmetric.View {
+	return sdkmetric.NewView(
+		sdkmetric.Instrument{Name: "*", Kind: sdkmetric.InstrumentKindHistogram, Unit: "ms"},
+		sdkmetric.Stream{Aggregation: sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  exponentialTrendMaxSize,
+			MaxScale: exponentialTrendScale(precision),
+		}},
+	)
+}
+
+// explicitTrends returns data with every exponential histogram rewritten as an
+// explicit-bucket histogram, so the summary, thresholds and CollectedMetrics
+// read one shape whichever way trends were recorded. data is not modified.
+func explicitTrends(data metricdata.ResourceMetrics) metricdata.ResourceMetrics {
+	scopes := make([]metricdata.ScopeMetrics, len(data.ScopeMetrics))
+
+	for i, scope := range data.ScopeMetrics {
+		scope.Metrics = slices.Clone(scope.Metrics)
+
+		for j, metric := range scope.Metrics {
+			if exponential, ok := metric.Data.(metricdata.ExponentialHistogram[float64]); ok {
+				scope.Metrics[j].Data = explicitHistogram(exponential)
+			}
+		}
+
+		scopes[i] = scope
+	}
+
+	data.ScopeMetrics = scopes
+
+	return data
+}
+
+// explicitHistogram maps every point onto one shared layout: all points are
+// downscaled to the coarsest scale among them, bounds[0] is 0 for the zero
+// bucket and each following bound is the upper edge of one exponential bucket.
+// Sharing the layout lets histogramSnapshot merge series bucket by bucket.
+func explicitHistogram(exponential metricdata.ExponentialHistogram[float64]) metricdata.Histogram[float64] {
+	histogram := metricdata.Histogram[float64]{
+		Temporality: exponential.Temporality,
+		DataPoints:  make([]metricdata.HistogramDataPoint[float64], len(exponential.DataPoints)),
+	}
+
+	if len(exponential.DataPoints) == 0 {
+		return histogram
+	}
+
+	scale := exponential.DataPoints[0].Scale
+	for _, point := range exponential.DataPoints {
+		scale = min(scale, point.Scale)
+	}
+
+	var (
+		lowest, highest int32
+		found           bool
+	)
+
+	for _, point := range exponential.DataPoints {
+		if len(point.PositiveBucket.Counts) == 0 {
+			continue
+		}
+
+		shift := point.Scale - scale
+		first := point.PositiveBucket.Offset >> shift
+		last := (point.PositiveBucket.Offset + int32(len(point.PositiveBucket.Counts)) - 1) >> shift
+
+		if !found || first < lowest {
+			lowest = first
+		}
+
+		if !found || last > highest {
+			highest = last
+		}
+
+		found = true
+	}
+
+	bounds := []float64{0}
+	if found {
+		for index := lowest; index <= highest; index++ {
+			bounds = append(bounds, exponentialUpperBound(index, scale))
+		}
+	}
+
+	for i, point := range exponential.DataPoints {
+		buckets := make([]uint64, len(bounds)+1)
+		buckets[0] = point.ZeroCount
+
+		for _, count := range point.NegativeBucket.Counts {
+			buckets[0] += count
+		}
+
+		shift := point.Scale - scale
+		for offset, count := range point.PositiveBucket.Counts {
+			index := (point.PositiveBucket.Offset + int32(offset)) >> shift
+			buckets[index-lowest+1] += count
+		}
+
+		histogram.DataPoints[i] = metricdata.HistogramDataPoint[float64]{
+			Attributes:   point.Attributes,
+			StartTime:    point.StartTime,
+			Time:         point.Time,
+			Count:        point.Count,
+			Bounds:       bounds,
+			BucketCounts: buckets,
+			Min:          point.Min,
+			Max:          point.Max,
+			Sum:          point.Sum,
+		}
+	}
+
+	return histogram
+}
+
+// exponentialUpperBound is the upper edge of bucket index at scale:
+// base^(index+1) with base = 2^(2^-scale).
+func exponentialUpperBound(index, scale int32) float64 {
+	return math.Exp2(float64(index+1) * math.Exp2(-float64(scale)))
+}
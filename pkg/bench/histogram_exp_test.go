@@ -0,0 +1,104 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestParseLatencyPrecision(t *testing.T) {
+	t.Parallel()
+
+	for raw, want := range map[string]float64{"": 0, "1%": 0.01, " 0.5 % ": 0.005, "0.001": 0.001} {
+		got, err := ParseLatencyPrecision(raw)
+		require.NoError(t, err, raw)
+		require.InDelta(t, want, got, 1e-15, raw)
+	}
+
+	for _, raw := range []string{"fast", "-1%", "100%", "1", "NaN"} {
+		_, err := ParseLatencyPrecision(raw)
+		require.True(t, errors.Is(err, errLatencyPrecision), "%q: %v", raw, err)
+	}
+}
+
+func TestExponentialTrendScale(t *testing.T) {
+	t.Parallel()
+
+	for _, precision := range []float64{0.1, 0.01, 0.001, 0.0001} {
+		scale := exponentialTrendScale(precision)
+		require.LessOrEqual(t, math.Exp2(math.Exp2(-float64(scale)))-1, precision)
+		// One scale coarser would already exceed the precision.
+		require.Greater(t, math.Exp2(math.Exp2(-float64(scale-1)))-1, precision)
+	}
+}
+
+func TestLatencyPrecisionBoundsQuantileError(t *testing.T) {
+	t.Parallel()
+
+	const precision = 0.01
+
+	provider, reader, prefix, err := newMeterProvider(context.Background(), &MetricsConfig{LatencyPrecision: precision})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, provider.Shutdown(context.Background())) })
+
+	registry := NewRegistry(provider.Meter("test"), prefix)
+	trend, err := registry.NewMetric("tx_total_duration", Trend)
+	require.NoError(t, err)
+
+	// 1..10000 × 0.37ms: the fixed buckets would report p99.9 as 5000ms.
+	for i := 1; i <= 10_000; i++ {
+		trend.add(context.Background(), float64(i)*0.37, attributes("tx_name", "new_order"))
+	}
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	_, exponential := data.ScopeMetrics[0].Metrics[0].Data.(metricdata.ExponentialHistogram[float64])
+	require.True(t, exponential, "duration trend should record an exponential histogram")
+
+	snapshot := histogramSnapshot(findHistogram(t, explicitTrends(data), prefix+"tx_total_duration").DataPoints)
+	require.Equal(t, uint64(10_000), snapshot.Count)
+	require.Len(t, snapshot.Buckets, len(snapshot.Bounds)+1)
+
+	for quantile, exact := range map[float64]float64{0.5: 5000 * 0.37, 0.99: 9900 * 0.37, 0.999: 9990 * 0.37} {
+		got := histogramQuantile(snapshot.Bounds, snapshot.Buckets, snapshot.Count, quantile)
+		require.GreaterOrEqual(t, got, exact, "p%v", quantile*100)
+		require.LessOrEqual(t, got, exact*(1+precision), "p%v", quantile*100)
+	}
+}
+
+func TestExplicitHistogramMergesScales(t *testing.T) {
+	t.Parallel()
+
+	fine := attribute.NewSet(attribute.String("tx_name", "a"))
+	coarse := attribute.NewSet(attribute.String("tx_name", "b"))
+
+	// Scale 1 buckets 2..3 are (2, 2.83] and (2.83, 4]; at scale 0 both fold
+	// into index 1, (2, 4], next to the coarse point's index 2, (4, 8].
+	histogram := explicitHistogram(metricdata.ExponentialHistogram[float64]{
+		DataPoints: []metricdata.ExponentialHistogramDataPoint[float64]{
+			{
+				Attributes: fine, Count: 4, Scale: 1, ZeroCount: 1,
+				PositiveBucket: metricdata.ExponentialBucket{Offset: 2, Counts: []uint64{1, 2}},
+			},
+			{
+				Attributes: coarse, Count: 3, Scale: 0,
+				PositiveBucket: metricdata.ExponentialBucket{Offset: 2, Counts: []uint64{3}},
+			},
+		},
+	})
+
+	require.Len(t, histogram.DataPoints, 2)
+	require.Equal(t, []float64{0, 4, 8}, histogram.DataPoints[0].Bounds)
+	require.Equal(t, []uint64{1, 3, 0, 0}, histogram.DataPoints[0].BucketCounts)
+	require.Equal(t, []uint64{0, 0, 3, 0}, histogram.DataPoints[1].BucketCounts)
+
+	snapshot := histogramSnapshot(histogram.DataPoints)
+	require.Equal(t, uint64(7), snapshot.Count)
+	require.Equal(t, []uint64{1, 3, 3, 0}, snapshot.Buckets)
+}
@@ -36,6 +36,10 @@ type txMetrics struct {
 	insertDuration   *metric
 	iterationDur     *metric
 	iterations       *metric
+	iterationErrors  *metric
+	droppedIters     *metric
+	iterationLag     *metric
+	activeVUs        *metric
 	txTotalDuration  *metric
 	txCommits        *metric
 	txErrors         *metric
@@ -52,12 +56,22 @@ type attributeCache struct {
 	overflow atomic.Pointer[metricAttributes]
 }
 
+type stepAttributeKey struct {
+	scenario string
+	driver   string
+	step     string
+}
+
 type tableAttributeKey struct {
-	step  string
-	table string
+	scenario string
+	driver   string
+	step     string
+	table    string
 }
 
 type txAttributeKey struct {
+	scenario  string
+	driver    string
 	step      string
 	action    string
 	name      string
@@ -65,7 +79,7 @@ type txAttributeKey struct {
 }
 
 type progressAttributeKey struct {
-	step, table, method, event, rowKind string
+	scenario, driver, step, table, method, event, rowKind string
 }
 
 func (m *txMetrics) ensureRegistered(vu *VU, lg *zap.Logger) {
@@ -102,6 +116,10 @@ func (m *txMetrics) ensureRegistered(vu *VU, lg *zap.Logger) {
 	m.insertDuration = newMetric("insert_duration", Trend)
 	m.iterationDur = newMetric("iteration_duration", Trend)
 	m.iterations = newMetric("iterations_total", Counter)
+	m.iterationErrors = newMetric("errors", Rate)
+	m.droppedIters = newMetric("dropped_iterations_total", Counter)
+	m.iterationLag = newMetric("iteration_lag_duration", Trend)
+	m.activeVUs = newMetric("vus_active", Gauge)
 	m.txTotalDuration = newMetric("tx_total_duration", Trend)
 	m.txCommits = newMetric("tx_commits_total", Counter)
 	m.txErrors = newMetric("tx_errors_total", Counter)
@@ -157,54 +175,82 @@ func cachedAttributes(cache *attributeCache, key any, tags ...string) metricAttr
 	return result
 }
 
-func (m *txMetrics) stepAttributes(step string) metricAttributes {
-	if step == "" {
-		return cachedAttributes(&m.stepAttrs, step)
+// scopeTags prefixes tags with the VU's scenario name and the driver index. The
+// implicit scenario of a single-scenario run has no name and a single-driver run
+// has no driver tag, so their series keep their original tags.
+func scopeTags(vu *VU, driver string, tags ...string) []string {
+	if vu.scenario == "" && driver == "" {
+		return tags
+	}
+
+	scoped := make([]string, 0, len(tags)+2*tagPairSize)
+	if vu.scenario != "" {
+		scoped = append(scoped, "scenario", vu.scenario)
+	}
+
+	if driver != "" {
+		scoped = append(scoped, "driver", driver)
+	}
+
+	return append(scoped, tags...)
+}
+
+func (m *txMetrics) stepAttributes(vu *VU, driver string) metricAttributes {
+	key := stepAttributeKey{scenario: vu.scenario, driver: driver, step: vu.stepTag}
+	if vu.stepTag == "" {
+		return cachedAttributes(&m.stepAttrs, key, scopeTags(vu, driver)...)
 	}
 
-	return cachedAttributes(&m.stepAttrs, step, "step", step)
+	return cachedAttributes(&m.stepAttrs, key, scopeTags(vu, driver, "step", vu.stepTag)...)
 }
 
-func (m *txMetrics) tableAttributes(step, table string) metricAttributes {
-	key := tableAttributeKey{step: step, table: table}
-	if step == "" {
-		return cachedAttributes(&m.tableAttrs, key, "table_name", table)
+func (m *txMetrics) tableAttributes(vu *VU, driver, table string) metricAttributes {
+	key := tableAttributeKey{scenario: vu.scenario, driver: driver, step: vu.stepTag, table: table}
+	if vu.stepTag == "" {
+		return cachedAttributes(&m.tableAttrs, key, scopeTags(vu, driver, "table_name", table)...)
 	}
 
-	return cachedAttributes(&m.tableAttrs, key, "step", step, "table_name", table)
+	return cachedAttributes(&m.tableAttrs, key, scopeTags(vu, driver, "step", vu.stepTag, "table_name", table)...)
 }
 
-func (m *txMetrics) txAttributes(step, action, name, isolation string) metricAttributes {
-	key := txAttributeKey{step: step, action: action, name: name, isolation: isolation}
+func (m *txMetrics) txAttributes(vu *VU, driver, action, name, isolation string) metricAttributes {
+	key := txAttributeKey{
+		scenario: vu.scenario, driver: driver, step: vu.stepTag,
+		action: action, name: name, isolation: isolation,
+	}
 
 	return cachedAttributes(
 		&m.txAttrs, key,
-		"step", step,
-		"tx_action", action,
-		"tx_name", name,
-		"tx_isolation", isolation,
+		scopeTags(vu, driver,
+			"step", vu.stepTag,
+			"tx_action", action,
+			"tx_name", name,
+			"tx_isolation", isolation,
+		)...,
 	)
 }
 
-func (m *txMetrics) progressAttributes(snapshot *insertprogress.Snapshot, step string) metricAttributes {
+func (m *txMetrics) progressAttributes(vu *VU, driver string, snapshot *insertprogress.Snapshot) metricAttributes {
 	key := progressAttributeKey{
-		step: step, table: snapshot.Table, method: snapshot.Method,
+		scenario: vu.scenario, driver: driver, step: vu.stepTag, table: snapshot.Table, method: snapshot.Method,
 		event: string(snapshot.Event), rowKind: snapshot.RowKind,
 	}
 
 	return cachedAttributes(
 		&m.progressAttrs, key,
-		"step", step,
-		"table_name", snapshot.Table,
-		"method", snapshot.Method,
-		"event", string(snapshot.Event),
-		"row_kind", snapshot.RowKind,
+		scopeTags(vu, driver,
+			"step", vu.stepTag,
+			"table_name", snapshot.Table,
+			"method", snapshot.Method,
+			"event", string(snapshot.Event),
+			"row_kind", snapshot.RowKind,
+		)...,
 	)
 }
 
-func (m *txMetrics) recordQueryResult(vu *VU, elapsed time.Duration, queryErr error) {
+func (m *txMetrics) recordQueryResult(vu *VU, driver string, elapsed time.Duration, queryErr error) {
 	m.ensureRegistered(vu, root.lg)
-	attrs := m.stepAttributes(vu.stepTag)
+	attrs := m.stepAttributes(vu, driver)
 	m.emit(vu, m.queryOperations, 1, attrs)
 
 	if queryErr != nil {
@@ -216,14 +262,14 @@ func (m *txMetrics) recordQueryResult(vu *VU, elapsed time.Duration, queryErr er
 	m.emit(vu, m.queryDuration, elapsed.Seconds()*millisPerSecond, attrs)
 }
 
-func (m *txMetrics) recordInsertResult(vu *VU, table string, elapsed time.Duration, insertErr error) {
+func (m *txMetrics) recordInsertResult(vu *VU, driver, table string, elapsed time.Duration, insertErr error) {
 	m.ensureRegistered(vu, root.lg)
 
 	if table == "" {
 		table = "unknown"
 	}
 
-	attrs := m.tableAttributes(vu.stepTag, table)
+	attrs := m.tableAttributes(vu, driver, table)
 	m.emit(vu, m.insertOperations, 1, attrs)
 
 	if insertErr != nil {
@@ -235,16 +281,46 @@ func (m *txMetrics) recordInsertResult(vu *VU, table string, elapsed time.Durati
 	m.emit(vu, m.insertDuration, elapsed.Seconds()*millisPerSecond, attrs)
 }
 
-func (m *txMetrics) recordIteration(vu *VU, elapsed time.Duration) {
+// recordIteration records one finished iteration. The errors Rate counts
+// every iteration and marks the failed ones, so errors.rate is the failed
+// fraction of iterations.
+func (m *txMetrics) recordIteration(vu *VU, elapsed time.Duration, failed bool) {
 	m.ensureRegistered(vu, root.lg)
-	attrs := m.stepAttributes(vu.stepTag)
+	attrs := m.stepAttributes(vu, "")
 	m.emit(vu, m.iterationDur, elapsed.Seconds()*millisPerSecond, attrs)
 	m.emit(vu, m.iterations, 1, attrs)
+
+	var failure float64
+	if failed {
+		failure = 1
+	}
+
+	m.emit(vu, m.iterationErrors, failure, attrs)
+}
+
+// recordDroppedIteration counts an arrival-rate start that found no idle VU
+// and no room to allocate one.
+func (m *txMetrics) recordDroppedIteration(vu *VU) {
+	m.ensureRegistered(vu, root.lg)
+	m.emit(vu, m.droppedIters, 1, m.stepAttributes(vu, ""))
+}
+
+// recordIterationLag records how late an arrival-rate iteration started
+// relative to its schedule.
+func (m *txMetrics) recordIterationLag(vu *VU, lag time.Duration) {
+	m.ensureRegistered(vu, root.lg)
+	m.emit(vu, m.iterationLag, max(lag, 0).Seconds()*millisPerSecond, m.stepAttributes(vu, ""))
+}
+
+// recordActiveVUs sets the vus_active gauge of the VU's scenario.
+func (m *txMetrics) recordActiveVUs(vu *VU, active int64) {
+	m.ensureRegistered(vu, root.lg)
+	m.emit(vu, m.activeVUs, float64(active), m.stepAttributes(vu, ""))
 }
 
 func (m *txMetrics) recordTxEnd(
 	vu *VU,
-	action, name string,
+	driver, action, name string,
 	isolation stroppy.TxIsolationLevel,
 	elapsed time.Duration,
 	queries int,
@@ -252,7 +328,7 @@ func (m *txMetrics) recordTxEnd(
 ) {
 	m.ensureRegistered(vu, root.lg)
 
-	attrs := m.txAttributes(vu.stepTag, action, name, txIsolationName(isolation))
+	attrs := m.txAttributes(vu, driver, action, name, txIsolationName(isolation))
 	if committed {
 		m.emit(vu, m.txCommits, 1, attrs)
 	} else {
@@ -263,10 +339,10 @@ func (m *txMetrics) recordTxEnd(
 	m.emit(vu, m.txQueriesPerTx, float64(queries), attrs)
 }
 
-func (m *txMetrics) recordInsertProgress(vu *VU, snapshot *insertprogress.Snapshot) {
+func (m *txMetrics) recordInsertProgress(vu *VU, driver string, snapshot *insertprogress.Snapshot) {
 	m.ensureRegistered(vu, root.lg)
 
-	attrs := m.progressAttributes(snapshot, vu.stepTag)
+	attrs := m.progressAttributes(vu, driver, snapshot)
 	if snapshot.DeltaRows > 0 {
 		m.emit(vu, m.progressRows, float64(snapshot.DeltaRows), attrs)
 	}
@@ -274,7 +350,7 @@ func (m *txMetrics) recordInsertProgress(vu *VU, snapshot *insertprogress.Snapsh
 	m.emit(vu, m.progressRPS, snapshot.CurrentRowsPerSecond, attrs)
 }
 
-func (m *txMetrics) recordInsert(vu *VU, table string, rows int64) {
+func (m *txMetrics) recordInsert(vu *VU, driver, table string, rows int64) {
 	m.ensureRegistered(vu, root.lg)
 
 	if table == "" {
@@ -285,12 +361,12 @@ func (m *txMetrics) recordInsert(vu *VU, table string, rows int64) {
 		rows = 0
 	}
 
-	m.emit(vu, m.insertRows, float64(rows), m.tableAttributes(vu.stepTag, table))
+	m.emit(vu, m.insertRows, float64(rows), m.tableAttributes(vu, driver, table))
 }
 
-func (m *txMetrics) record(vu *VU, action, name string, isolation stroppy.TxIsolationLevel) {
+func (m *txMetrics) record(vu *VU, driver, action, name string, isolation stroppy.TxIsolationLevel) {
 	m.ensureRegistered(vu, root.lg)
-	m.emit(vu, m.transactions, 1, m.txAttributes(vu.stepTag, action, name, txIsolationName(isolation)))
+	m.emit(vu, m.transactions, 1, m.txAttributes(vu, driver, action, name, txIsolationName(isolation)))
 }
 
 func txIsolationName(isolation stroppy.TxIsolationLevel) string {
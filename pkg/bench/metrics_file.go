@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// --- metrics file (local time-series sink) ---
+
+// fileExporter writes every periodic collection as NDJSON, one line per
+// series, so a run without a collector still keeps throughput and latency over
+// time. A path ending in .gz is gzip-compressed.
+//
+// Counters and trends use delta temporality: each line holds what happened in
+// that interval, so a checkpoint stall shows up as a dip in the count and a
+// spike in the interval's quantiles rather than being averaged into the run.
+type fileExporter struct {
+	mu     sync.Mutex
+	file   *os.File
+	gzip   *gzip.Writer // nil for plain NDJSON
+	out    *bufio.Writer
+	prefix string
+	runID  string
+	closed bool
+}
+
+// metricsFileLine is one series at one collection. Trend quantiles are bucket
+// upper bounds over the interval, as in the summary.
+type metricsFileLine struct {
+	Time  time.Time `json:"time"`
+	RunID string    `json:"run_id,omitempty"`
+	summaryExportMetric
+}
+
+var _ sdkmetric.Exporter = (*fileExporter)(nil)
+
+func newFileExporter(path, prefix, runID string) (*fileExporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create metrics file: %w", err)
+	}
+
+	exporter := &fileExporter{file: file, prefix: prefix, runID: runID}
+
+	var sink io.Writer = file
+	if strings.HasSuffix(path, ".gz") {
+		exporter.gzip = gzip.NewWriter(file)
+		sink = exporter.gzip
+	}
+
+	exporter.out = bufio.NewWriter(sink)
+
+	return exporter, nil
+}
+
+func (*fileExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram,
+		sdkmetric.InstrumentKindObservableCounter:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
+
+func (*fileExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+func (e *fileExporter) Export(_ context.Context, data *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return nil
+	}
+
+	encoder := json.NewEncoder(e.out)
+	now := time.Now().UTC()
+
+	for _, metric := range exportMetrics(explicitTrends(*data), e.prefix) {
+		// A delta series with nothing recorded this interval carries no news.
+		if metric.Type == "trend" && metric.Count == 0 {
+			continue
+		}
+
+		if err := encoder.Encode(metricsFileLine{Time: now, RunID: e.runID, summaryExportMetric: metric}); err != nil {
+			return fmt.Errorf("write metrics file: %w", err)
+		}
+	}
+
+	// Flush each interval so the file is readable while the run is going.
+	return e.flush()
+}
+
+func (e *fileExporter) ForceFlush(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return nil
+	}
+
+	return e.flush()
+}
+
+func (e *fileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+
+	err := e.out.Flush()
+	if e.gzip != nil {
+		err = errors.Join(err, e.gzip.Close())
+	}
+
+	return errors.Join(err, e.file.Close())
+}
+
+func (e *fileExporter) flush() error {
+	if err := e.out.Flush(); err != nil {
+		return err
+	}
+
+	if e.gzip != nil {
+		return e.gzip.Flush()
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/zap"
+)
+
+const prometheusReadHeaderTimeout = 10 * time.Second
+
+// prometheusEndpoint serves the run's metrics on /metrics for a Prometheus
+// scrape. Each run gets its own registry, so only stroppy's metrics are served.
+type prometheusEndpoint struct {
+	reader *otelprometheus.Exporter
+	server *http.Server
+	addr   net.Addr
+}
+
+// newPrometheusEndpoint listens on addr before the run starts, so a busy port
+// fails the run instead of silently serving nothing.
+func newPrometheusEndpoint(addr string, lg *zap.Logger) (*prometheusEndpoint, error) {
+	registry := prometheus.NewRegistry()
+
+	// Units are dropped so the series keep the names the summary and
+	// thresholds use; durations are milliseconds throughout.
+	reader, err := otelprometheus.New(
+		otelprometheus.WithRegisterer(registry),
+		otelprometheus.WithoutUnits(),
+		otelprometheus.WithoutScopeInfo(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create Prometheus metrics reader: %w", err)
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen for Prometheus metrics: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	endpoint := &prometheusEndpoint{
+		reader: reader,
+		server: &http.Server{Handler: mux, ReadHeaderTimeout: prometheusReadHeaderTimeout},
+		addr:   listener.Addr(),
+	}
+
+	go func() {
+		if err := endpoint.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			lg.Error("serving Prometheus metrics", zap.Error(err))
+		}
+	}()
+
+	lg.Info("Serving Prometheus metrics", zap.String("url", "http://"+endpoint.addr.String()+"/metrics"))
+
+	return endpoint, nil
+}
+
+func (e *prometheusEndpoint) metricReader() sdkmetric.Reader { return e.reader }
+
+func (e *prometheusEndpoint) shutdown(ctx context.Context) error {
+	return e.server.Shutdown(ctx)
+}
@@ -33,11 +33,24 @@ type MetricsConfig struct {
 	ServiceVersion     string
 	RunID              string
 	ResourceAttributes map[string]string
+	// Thresholds are pass/fail expressions evaluated against the final summary.
+	Thresholds []string
+	// SummaryExport is a .json or .csv file the final summary is written to.
+	SummaryExport string
+	// MetricsFile receives every export interval's metrics as NDJSON, gzipped
+	// when the path ends in .gz.
+	MetricsFile string
+	// PrometheusListen is the address /metrics is served on for scraping.
+	PrometheusListen string
+	// LatencyPrecision, when positive, records millisecond trends as
+	// exponential histograms with at most this relative error per quantile.
+	LatencyPrecision float64
 }
 
 func newMeterProvider(
 	ctx context.Context,
 	config *MetricsConfig,
+	readers ...sdkmetric.Reader,
 ) (*sdkmetric.MeterProvider, *sdkmetric.ManualReader, string, error) {
 	if config == nil {
 		config = &MetricsConfig{}
@@ -49,6 +62,18 @@ func newMeterProvider(
 		sdkmetric.WithCardinalityLimit(metricCardinalityLimit),
 	}
 
+	for _, reader := range readers {
+		options = append(options, sdkmetric.WithReader(reader))
+	}
+
+	if err := validateLatencyPrecision(config.LatencyPrecision); err != nil {
+		return nil, nil, "", err
+	}
+
+	if config.LatencyPrecision > 0 {
+		options = append(options, sdkmetric.WithView(latencyView(config.LatencyPrecision)))
+	}
+
 	res, err := metricsResource(config)
 	if err != nil {
 		return nil, nil, "", fmt.Errorf("create metrics resource: %w", err)
@@ -73,6 +98,18 @@ func newMeterProvider(
 		prefix = defaultMetricsPrefix
 	}
 
+	if config.MetricsFile != "" {
+		fileExporter, err := newFileExporter(config.MetricsFile, prefix, config.RunID)
+		if err != nil {
+			return nil, nil, "", err
+		}
+
+		options = append(options, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(
+			fileExporter,
+			sdkmetric.WithInterval(metricExportInterval()),
+		)))
+	}
+
 	return sdkmetric.NewMeterProvider(options...), manualReader, prefix, nil
 }
 
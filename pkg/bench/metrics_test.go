@@ -1,13 +1,21 @@
 package bench
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
 
 	"github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
 )
@@ -176,7 +184,7 @@ func TestTransactionEndKeepsActionAndIsolationAttributes(t *testing.T) {
 	t.Cleanup(func() { root = previousRoot })
 
 	rootState.txMetrics.recordTxEnd(
-		vu, "commit", "payment", stroppy.TxIsolationLevel_READ_COMMITTED, time.Millisecond, 3, true,
+		vu, "", "commit", "payment", stroppy.TxIsolationLevel_READ_COMMITTED, time.Millisecond, 3, true,
 	)
 
 	var data metricdata.ResourceMetrics
@@ -189,6 +197,135 @@ func TestTransactionEndKeepsActionAndIsolationAttributes(t *testing.T) {
 	require.Equal(t, "read_committed", attributeValue(attrs, "tx_isolation"))
 }
 
+func TestTxEndMetricsCarryScenarioAndDriverTags(t *testing.T) {
+	provider, reader, prefix, err := newMeterProvider(context.Background(), &MetricsConfig{})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, provider.Shutdown(context.Background())) })
+
+	rootState := &RootState{
+		registry:  NewRegistry(provider.Meter("test"), prefix),
+		txMetrics: &txMetrics{},
+	}
+	vu := &VU{root: rootState, ctx: context.Background(), scenario: "oltp"}
+	previousRoot := root
+	root = rootState
+
+	t.Cleanup(func() { root = previousRoot })
+
+	rootState.txMetrics.recordTxEnd(
+		vu, "1", "commit", "payment", stroppy.TxIsolationLevel_READ_COMMITTED, time.Millisecond, 3, true,
+	)
+
+	var data metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &data))
+
+	histogram := findHistogram(t, data, prefix+"tx_total_duration")
+	require.Len(t, histogram.DataPoints, 1)
+	attrs := histogram.DataPoints[0].Attributes
+	require.Equal(t, "oltp", attributeValue(attrs, "scenario"))
+	require.Equal(t, "1", attributeValue(attrs, "driver"))
+	require.Equal(t, "payment", attributeValue(attrs, "tx_name"))
+}
+
+func TestMetricsFileWritesIntervalDeltas(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "metrics.ndjson.gz")
+	provider, _, prefix, err := newMeterProvider(context.Background(), &MetricsConfig{MetricsFile: path, RunID: "run-9"})
+	require.NoError(t, err)
+
+	registry := NewRegistry(provider.Meter("test"), prefix)
+	counter, err := registry.NewMetric("iterations_total", Counter)
+	require.NoError(t, err)
+	trend, err := registry.NewMetric("iteration_duration", Trend)
+	require.NoError(t, err)
+
+	attrs := attributes("step", "workload")
+	counter.add(context.Background(), 3, attrs)
+	trend.add(context.Background(), 4, attrs)
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	counter.add(context.Background(), 2, attrs)
+	require.NoError(t, provider.ForceFlush(context.Background()))
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	reader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	var (
+		counts []float64
+		trends int
+	)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var line metricsFileLine
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		require.Equal(t, "run-9", line.RunID)
+		require.Equal(t, "workload", line.Tags["step"])
+
+		switch line.Name {
+		case "iterations_total":
+			counts = append(counts, *line.Value)
+		case "iteration_duration":
+			trends++
+			require.Equal(t, uint64(1), line.Count)
+		}
+	}
+
+	require.NoError(t, scanner.Err())
+	require.Equal(t, []float64{3, 2}, counts)
+	require.Equal(t, 1, trends, "an interval without samples writes no trend line")
+}
+
+func TestPrometheusEndpointServesMetrics(t *testing.T) {
+	t.Parallel()
+
+	root, err := newRootState(zap.NewNop(), context.Background(), nil, &MetricsConfig{PrometheusListen: "127.0.0.1:0"})
+	require.NoError(t, err)
+	t.Cleanup(root.shutdownMetrics)
+
+	counter, err := root.registry.NewMetric("iterations_total", Counter)
+	require.NoError(t, err)
+	trend, err := root.registry.NewMetric("tx_total_duration", Trend)
+	require.NoError(t, err)
+
+	counter.add(context.Background(), 7, attributes("step", "workload"))
+	trend.add(context.Background(), 12, attributes("tx_name", "new_order"))
+
+	request, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, "http://"+root.prometheus.addr.String()+"/metrics", nil,
+	)
+	require.NoError(t, err)
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = response.Body.Close() })
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Contains(t, string(body), `stroppy_iterations_total{step="workload"} 7`)
+	require.Contains(t, string(body), `stroppy_tx_total_duration_count{tx_name="new_order"} 1`)
+}
+
+func TestPrometheusEndpointRejectsBusyAddress(t *testing.T) {
+	t.Parallel()
+
+	first, err := newRootState(zap.NewNop(), context.Background(), nil, &MetricsConfig{PrometheusListen: "127.0.0.1:0"})
+	require.NoError(t, err)
+	t.Cleanup(first.shutdownMetrics)
+
+	_, err = newRootState(
+		zap.NewNop(), context.Background(), nil, &MetricsConfig{PrometheusListen: first.prometheus.addr.String()},
+	)
+	require.ErrorContains(t, err, "listen for Prometheus metrics")
+}
+
 func BenchmarkMetricAdd(b *testing.B) {
 	provider, _, prefix, err := newMeterProvider(context.Background(), &MetricsConfig{})
 	require.NoError(b, err)
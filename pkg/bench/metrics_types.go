@@ -20,6 +20,7 @@ const (
 var (
 	errMetricAlreadyRegistered = errors.New("metric already registered")
 	errUnknownMetricType       = errors.New("unknown metric type")
+	errMetricTypeConflict      = errors.New("metric registered with a different type")
 )
 
 type metricType int
@@ -100,6 +101,27 @@ func (r *Registry) NewMetric(name string, typ metricType) (*metric, error) {
 		return nil, fmt.Errorf("%w: %q", errMetricAlreadyRegistered, name)
 	}
 
+	return r.create(name, typ)
+}
+
+// metricFor returns the named metric, creating it on first use. Scenarios that
+// run the same workload declare the same metrics and share one instrument.
+func (r *Registry) metricFor(name string, typ metricType) (*metric, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, ok := r.metrics[name]; ok {
+		if m.Type != typ {
+			return nil, fmt.Errorf("%w: %q", errMetricTypeConflict, name)
+		}
+
+		return m, nil
+	}
+
+	return r.create(name, typ)
+}
+
+func (r *Registry) create(name string, typ metricType) (*metric, error) {
 	m := &metric{
 		Name:       name,
 		Type:       typ,
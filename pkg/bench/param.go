@@ -56,6 +56,7 @@ const (
 	ParamTypeUint64   ParamType = "uint64"
 	ParamTypeFloat64  ParamType = "float64"
 	ParamTypeDuration ParamType = "duration"
+	ParamTypeStages   ParamType = "stages"
 )
 
 // ParamScope identifies the config object that owns a declaration.
@@ -78,6 +79,16 @@ type ParamSchema struct {
 	Env                string
 	LegacyEnvAliases   []string
 	Config             string
+	LocalPath          bool
+}
+
+// resolvedParam is the value one declaration resolved to and the channel that
+// supplied it, kept for the end-of-run summary export.
+type resolvedParam struct {
+	name   string
+	scope  ParamScope
+	value  any
+	source ParamSource
 }
 
 // Description is the discoverable, default-only schema for a workload.
@@ -115,6 +126,16 @@ func DerivedDefault(description string) ParamOption {
 	})
 }
 
+// LocalPath marks a parameter naming a file on the host running the workload.
+// Agents refuse such parameters from a coordinator.
+func LocalPath() ParamOption {
+	return paramOption(func(desc *paramDescriptor) error {
+		desc.localPath = true
+
+		return nil
+	})
+}
+
 type paramDescriptor struct {
 	name               string
 	scope              ParamScope
@@ -125,6 +146,7 @@ type paramDescriptor struct {
 	env                string
 	legacyEnvAliases   []string
 	config             string
+	localPath          bool
 }
 
 type paramParser[T any] struct {
@@ -140,6 +162,7 @@ type Def struct {
 	defaultsOnly bool
 	scope        ParamScope
 	descriptors  []paramDescriptor
+	resolved     []resolvedParam
 	names        map[string]struct{}
 	envNames     map[string]string
 	errs         []error
@@ -167,6 +190,7 @@ var (
 	errUnknownCLIParam       = errors.New("unknown CLI parameter")
 	errUnknownRunConfigParam = errors.New("unknown run config parameter")
 	errUnknownWorkloadConfig = errors.New("unknown workload config parameter")
+	errInvalidStage          = errors.New("invalid stage")
 )
 
 var reservedWorkloadParamNames = map[string]struct{}{
@@ -316,6 +340,29 @@ func (p ParamDeclarations) Duration(
 	}, opts...)
 }
 
+// Stage is one segment of a ramping load profile. Over Duration the executor
+// moves linearly from the previous stage's target to Target; the unit of
+// Target (VUs or iterations per time unit) depends on the executor.
+type Stage struct {
+	Duration time.Duration
+	Target   int
+}
+
+// Stages declares a load-profile parameter. The text form is a comma-separated
+// list of duration:target pairs ("2m:10,10m:200"); the config form is a JSON
+// array of {"duration": "2m", "target": 10} objects.
+func (p ParamDeclarations) Stages(
+	name string,
+	defaultValue []Stage,
+	description string,
+	opts ...ParamOption,
+) Param[[]Stage] {
+	return declareParam(p.def, name, ParamTypeStages, defaultValue, description, paramParser[[]Stage]{
+		text: parseStages,
+		raw:  decodeStages,
+	}, opts...)
+}
+
 func declareParam[T any](
 	d *Def,
 	name string,
@@ -352,18 +399,20 @@ func declareParam[T any](
 		return Param[T]{value: defaultValue, source: ParamSourceDefault}
 	}
 
+	param := Param[T]{value: defaultValue, source: ParamSourceDefault}
+
 	value, source, ok, err := resolveParam(d.inputs, &desc, parser)
 	if err != nil {
 		d.addError(fmt.Errorf("parameter %q from %s: %w", name, source, err))
-
-		return Param[T]{value: defaultValue, source: ParamSourceDefault}
+	} else if ok {
+		param = Param[T]{value: value, source: source}
 	}
 
-	if !ok {
-		return Param[T]{value: defaultValue, source: ParamSourceDefault}
-	}
+	d.resolved = append(d.resolved, resolvedParam{
+		name: name, scope: desc.scope, value: param.value, source: param.source,
+	})
 
-	return Param[T]{value: value, source: source}
+	return param
 }
 
 func (d *Def) register(desc *paramDescriptor) bool {
@@ -551,6 +600,85 @@ func decodeFiniteFloat(raw json.RawMessage) (float64, error) {
 	return value, nil
 }
 
+func parseStages(value string) ([]Stage, error) {
+	var stages []Stage
+
+	for part := range strings.SplitSeq(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		durationText, targetText, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: want duration:target", errInvalidStage, part)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(durationText))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", errInvalidStage, part, err)
+		}
+
+		target, err := strconv.Atoi(strings.TrimSpace(targetText))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", errInvalidStage, part, err)
+		}
+
+		stages = append(stages, Stage{Duration: duration, Target: target})
+	}
+
+	return stages, validateStages(stages)
+}
+
+type stageJSON struct {
+	Duration *string `json:"duration"`
+	Target   *int    `json:"target"`
+}
+
+func decodeStages(raw json.RawMessage) ([]Stage, error) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return nil, errNullParamValue
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	var entries []stageJSON
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	stages := make([]Stage, 0, len(entries))
+	for idx, entry := range entries {
+		if entry.Duration == nil || entry.Target == nil {
+			return nil, fmt.Errorf("%w %d: duration and target are required", errInvalidStage, idx)
+		}
+
+		duration, err := time.ParseDuration(*entry.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d: %w", errInvalidStage, idx, err)
+		}
+
+		stages = append(stages, Stage{Duration: duration, Target: *entry.Target})
+	}
+
+	return stages, validateStages(stages)
+}
+
+func validateStages(stages []Stage) error {
+	for idx, stage := range stages {
+		if stage.Duration < 0 {
+			return fmt.Errorf("%w %d: duration must not be negative, got %s", errInvalidStage, idx, stage.Duration)
+		}
+
+		if stage.Target < 0 {
+			return fmt.Errorf("%w %d: target must not be negative, got %d", errInvalidStage, idx, stage.Target)
+		}
+	}
+
+	return nil
+}
+
 func (d *Def) finish() error {
 	for _, name := range sortedKeys(d.inputs.CLI) {
 		if _, ok := d.names[name]; !ok {
@@ -617,6 +745,7 @@ func (d *Def) schema() []ParamSchema {
 			Env:                desc.env,
 			LegacyEnvAliases:   slices.Clone(desc.legacyEnvAliases),
 			Config:             desc.config,
+			LocalPath:          desc.localPath,
 		}
 	}
 
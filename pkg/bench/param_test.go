@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -433,7 +434,7 @@ func TestDescribeUsesDefaultsAndCopiesSchema(t *testing.T) {
 		t.Fatalf("Setup calls = %d, want 0", setupCalls.Load())
 	}
 
-	if description.Name != "test/describe-params" || len(description.Params) != 6 {
+	if description.Name != "test/describe-params" || len(description.Params) != 16 {
 		t.Fatalf("description = %#v", description)
 	}
 
@@ -448,19 +449,19 @@ func TestDescribeUsesDefaultsAndCopiesSchema(t *testing.T) {
 		LegacyEnvAliases: []string{"OLD_BATCH_SIZE"},
 		Config:           "batchSize",
 	}
-	if !reflect.DeepEqual(description.Params[5], want) {
-		t.Fatalf("workload schema = %#v, want %#v", description.Params[5], want)
+	if !reflect.DeepEqual(description.Params[15], want) {
+		t.Fatalf("workload schema = %#v, want %#v", description.Params[15], want)
 	}
 
-	description.Params[5].LegacyEnvAliases[0] = "MUTATED"
+	description.Params[15].LegacyEnvAliases[0] = "MUTATED"
 
 	again, err := Describe("test/describe-params")
 	if err != nil {
 		t.Fatalf("Describe() again error = %v", err)
 	}
 
-	if again.Params[5].LegacyEnvAliases[0] != "OLD_BATCH_SIZE" {
-		t.Fatalf("schema alias was mutated: %#v", again.Params[5])
+	if again.Params[15].LegacyEnvAliases[0] != "OLD_BATCH_SIZE" {
+		t.Fatalf("schema alias was mutated: %#v", again.Params[15])
 	}
 }
 
@@ -775,6 +776,261 @@ func TestScenarioValidation(t *testing.T) {
 	}
 }
 
+func TestParamStagesFromCLIAndConfig(t *testing.T) {
+	clearParamEnv(t, "PROFILE", "CONFIGURED")
+
+	def := newDef(ParamInputs{
+		CLI: map[string]string{"profile": "30s:10, 1m:0"},
+		WorkloadConfig: map[string]json.RawMessage{
+			"configured": json.RawMessage(`[{"duration":"2m","target":10},{"duration":"10m","target":200}]`),
+		},
+	}, false)
+	def.scope = ParamScopeWorkload
+
+	profile := def.Param.Stages("profile", nil, "")
+	configured := def.Param.Stages("configured", nil, "")
+
+	if err := def.finish(); err != nil {
+		t.Fatalf("finish() error = %v", err)
+	}
+
+	wantProfile := []Stage{{Duration: 30 * time.Second, Target: 10}, {Duration: time.Minute, Target: 0}}
+	if !reflect.DeepEqual(profile.Value(), wantProfile) {
+		t.Fatalf("profile = %#v, want %#v", profile.Value(), wantProfile)
+	}
+
+	wantConfigured := []Stage{{Duration: 2 * time.Minute, Target: 10}, {Duration: 10 * time.Minute, Target: 200}}
+	if !reflect.DeepEqual(configured.Value(), wantConfigured) {
+		t.Fatalf("configured = %#v, want %#v", configured.Value(), wantConfigured)
+	}
+}
+
+func TestParamStagesRejectMalformedValues(t *testing.T) {
+	clearParamEnv(t, "PROFILE")
+
+	tests := []struct {
+		name   string
+		inputs ParamInputs
+		want   string
+	}{
+		{name: "missing target", inputs: ParamInputs{CLI: map[string]string{"profile": "30s"}}, want: "duration:target"},
+		{name: "bad duration", inputs: ParamInputs{CLI: map[string]string{"profile": "soon:10"}}, want: "invalid stage"},
+		{
+			name:   "negative target",
+			inputs: ParamInputs{CLI: map[string]string{"profile": "1s:-1"}},
+			want:   "must not be negative",
+		},
+		{
+			name: "unknown field",
+			inputs: ParamInputs{WorkloadConfig: map[string]json.RawMessage{
+				"profile": json.RawMessage(`[{"duration":"1s","target":1,"rate":2}]`),
+			}},
+			want: "unknown field",
+		},
+		{
+			name: "missing duration",
+			inputs: ParamInputs{WorkloadConfig: map[string]json.RawMessage{
+				"profile": json.RawMessage(`[{"target":1}]`),
+			}},
+			want: "duration and target are required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			def := newDef(test.inputs, false)
+			def.scope = ParamScopeWorkload
+			def.Param.Stages("profile", nil, "")
+
+			if err := def.finish(); err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("finish() error = %v, want containing %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestScenarioArrivalRateExecutors(t *testing.T) {
+	clearScenarioEnv(t)
+
+	spec, err := scenarioForTest(ParamInputs{CLI: map[string]string{
+		"executor": "constant-arrival-rate",
+		"rate":     "500",
+		"duration": "30s",
+		"vus":      "8",
+		"max-vus":  "64",
+	}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("constant-arrival-rate error = %v", err)
+	}
+
+	if spec.rate != 500 || spec.timeUnit != time.Second || spec.vus != 8 || spec.maxVUs != 64 {
+		t.Fatalf("constant-arrival-rate scenario = %#v", spec)
+	}
+
+	spec, err = scenarioForTest(ParamInputs{RunConfig: map[string]json.RawMessage{
+		"executor":  json.RawMessage(`"ramping-arrival-rate"`),
+		"startRate": json.RawMessage(`10`),
+		"timeUnit":  json.RawMessage(`"1m"`),
+		"stages":    json.RawMessage(`[{"duration":"1m","target":100}]`),
+	}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("ramping-arrival-rate error = %v", err)
+	}
+
+	if spec.startRate != 10 || spec.timeUnit != time.Minute || spec.maxVUs != 1 || len(spec.stages) != 1 {
+		t.Fatalf("ramping-arrival-rate scenario = %#v", spec)
+	}
+}
+
+func TestScenarioRampingVUsExecutor(t *testing.T) {
+	clearScenarioEnv(t)
+
+	spec, err := scenarioForTest(ParamInputs{RunConfig: map[string]json.RawMessage{
+		"executor": json.RawMessage(`"ramping-vus"`),
+		"vus":      json.RawMessage(`2`),
+		"stages":   json.RawMessage(`[{"duration":"2m","target":10},{"duration":"1m","target":0}]`),
+	}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("ramping-vus error = %v", err)
+	}
+
+	want := []Stage{{Duration: 2 * time.Minute, Target: 10}, {Duration: time.Minute}}
+	if spec.executor != "ramping-vus" || spec.vus != 2 || !slices.Equal(spec.stages, want) {
+		t.Fatalf("ramping-vus scenario = %#v", spec)
+	}
+
+	for _, tc := range []struct {
+		name string
+		cli  map[string]string
+		want string
+	}{
+		{"needs stages", map[string]string{"executor": "ramping-vus"}, "ramping-vus requires stages"},
+		{"rejects duration", map[string]string{"executor": "ramping-vus", "stages": "1s:2", "duration": "1s"}, "duration"},
+		{"rejects max-vus", map[string]string{"executor": "ramping-vus", "stages": "1s:2", "max-vus": "4"}, "max-vus"},
+		{"stages with closed executor", map[string]string{"executor": "constant-vus", "duration": "1s", "stages": "1s:2"},
+			"stages are only valid"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := scenarioForTest(ParamInputs{CLI: tc.cli}, zap.NewNop())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("scenario error = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestScenarioErrorPolicy(t *testing.T) {
+	clearScenarioEnv(t)
+
+	spec, err := scenarioForTest(ParamInputs{CLI: map[string]string{
+		"on-error": "backoff", "error-backoff": "250ms", "max-errors": "50", "max-error-rate": "5",
+	}}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("error policy error = %v", err)
+	}
+
+	want := errorPolicy{
+		onError: onErrorBackoff, backoff: 250 * time.Millisecond, maxErrors: 50, maxRate: 5, window: time.Minute,
+	}
+	if spec.errors != want {
+		t.Fatalf("error policy = %#v, want %#v", spec.errors, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		cli  map[string]string
+		want string
+	}{
+		{"unknown on-error", map[string]string{"on-error": "retry"}, "on-error must be"},
+		{"backoff without policy", map[string]string{"error-backoff": "1s"}, "only valid with on-error backoff"},
+		{"non-positive backoff", map[string]string{"on-error": "backoff", "error-backoff": "0s"}, "must be positive"},
+		{"negative max-errors", map[string]string{"max-errors": "-1"}, "max-errors must not be negative"},
+		{"rate above 100", map[string]string{"max-error-rate": "101"}, "between 0 and 100"},
+		{"window without rate", map[string]string{"error-rate-window": "10s"}, "requires max-error-rate"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := scenarioForTest(ParamInputs{CLI: tc.cli}, zap.NewNop())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("scenario error = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestScenarioArrivalRateValidation(t *testing.T) {
+	clearScenarioEnv(t)
+
+	tests := []struct {
+		name string
+		cli  map[string]string
+		want string
+	}{
+		{
+			name: "constant needs rate",
+			cli:  map[string]string{"executor": "constant-arrival-rate", "duration": "1s"},
+			want: "requires rate",
+		},
+		{
+			name: "constant needs duration",
+			cli:  map[string]string{"executor": "constant-arrival-rate", "rate": "1"},
+			want: "constant-arrival-rate requires duration",
+		},
+		{
+			name: "rate positive",
+			cli:  map[string]string{"executor": "constant-arrival-rate", "rate": "0", "duration": "1s"},
+			want: "rate must be at least 1",
+		},
+		{
+			name: "max-vus below vus",
+			cli: map[string]string{
+				"executor": "constant-arrival-rate", "rate": "1", "duration": "1s", "vus": "4", "max-vus": "2",
+			},
+			want: "max-vus must not be less than vus",
+		},
+		{
+			name: "time-unit positive",
+			cli: map[string]string{
+				"executor": "constant-arrival-rate", "rate": "1", "duration": "1s", "time-unit": "0s",
+			},
+			want: "time-unit must be positive",
+		},
+		{
+			name: "rate with closed executor",
+			cli:  map[string]string{"executor": "constant-vus", "duration": "1s", "rate": "10"},
+			want: "rate is only valid",
+		},
+		{
+			name: "max-vus with closed executor",
+			cli:  map[string]string{"max-vus": "10"},
+			want: "max-vus is only valid with an arrival-rate executor",
+		},
+		{
+			name: "ramping needs stages",
+			cli:  map[string]string{"executor": "ramping-arrival-rate"},
+			want: "requires stages",
+		},
+		{
+			name: "ramping stages need duration",
+			cli:  map[string]string{"executor": "ramping-arrival-rate", "stages": "0s:10"},
+			want: "positive total duration",
+		},
+		{
+			name: "ramping rejects duration",
+			cli:  map[string]string{"executor": "ramping-arrival-rate", "stages": "1s:10", "duration": "1s"},
+			want: "duration requires an explicit",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := scenarioForTest(ParamInputs{CLI: test.cli}, zap.NewNop())
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("scenario error = %v, want containing %q", err, test.want)
+			}
+		})
+	}
+}
+
 func TestRunResolvesParamsBeforeDriverInitialization(t *testing.T) {
 	clearScenarioEnv(t)
 	clearParamEnv(t, "COUNT")
@@ -847,7 +1103,11 @@ func (*paramTestWorkload) Teardown(context.Context, *Bench) error { return nil }
 
 func clearScenarioEnv(t *testing.T) {
 	t.Helper()
-	clearParamEnv(t, "EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION")
+	clearParamEnv(
+		t, "EXECUTOR", "VUS", "ITERATIONS", "ITER", "DURATION",
+		"RATE", "START_RATE", "TIME_UNIT", "MAX_VUS", "STAGES", "ON_ERROR", "ERROR_BACKOFF",
+		"MAX_ERRORS", "MAX_ERROR_RATE", "ERROR_RATE_WINDOW",
+	)
 }
 
 func clearParamEnv(t *testing.T, names ...string) {
@@ -130,7 +130,7 @@ func (b *Bench) finishQuery(res *driver.QueryResult, queryErr error) error {
 		elapsed = res.Stats.Elapsed
 	}
 
-	b.root.txMetrics.recordQueryResult(b.vu, elapsed, queryErr)
+	b.root.txMetrics.recordQueryResult(b.vu, b.driverTag, elapsed, queryErr)
 
 	return queryErr
 }
@@ -162,13 +162,13 @@ func (b *Bench) Insert(ctx context.Context, req *driver.InsertRequest) (*stats.Q
 		elapsed = result.Elapsed
 	}
 
-	b.root.txMetrics.recordInsertResult(b.vu, req.Table, elapsed, err)
+	b.root.txMetrics.recordInsertResult(b.vu, b.driverTag, req.Table, elapsed, err)
 
 	if err != nil {
 		return nil, fmt.Errorf("insert %q: %w", req.Table, err)
 	}
 
-	b.root.txMetrics.recordInsert(b.vu, req.Table, result.Rows)
+	b.root.txMetrics.recordInsert(b.vu, b.driverTag, req.Table, result.Rows)
 
 	return result, nil
 }
@@ -183,7 +183,7 @@ func (b *Bench) newBatchInsertTracker(req *driver.InsertRequest) *insertprogress
 	config.Workers = req.Workers
 	config.Logger = b.lg.Named("insert-progress")
 	config.OnSample = func(snapshot insertprogress.Snapshot) {
-		b.root.txMetrics.recordInsertProgress(b.vu, &snapshot)
+		b.root.txMetrics.recordInsertProgress(b.vu, b.driverTag, &snapshot)
 	}
 
 	return insertprogress.NewTracker(&config)
@@ -233,6 +233,28 @@ func (b *Bench) InsertTpcds(ctx context.Context, table string, scaleFactor float
 	return b.Insert(ctx, req)
 }
 
+// InsertTpcdsRefresh inserts the rows of TPC-DS update set `set` into fact
+// table `table` (one LF_* data maintenance function) through the same typed
+// insert path as the initial load.
+func (b *Bench) InsertTpcdsRefresh(
+	ctx context.Context, table string, scaleFactor float64, set, workers int,
+) (*stats.Query, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	src, err := tpcdsgen.NewRefreshBatchSource(table, scaleFactor, set)
+	if err != nil {
+		return nil, fmt.Errorf("tpcds refresh %q: %w", table, err)
+	}
+
+	req := &driver.InsertRequest{
+		Table: table, Method: driver.InsertNative, Workers: workers, Source: src,
+	}
+
+	return b.Insert(ctx, req)
+}
+
 // Begin starts a transaction.
 func (b *Bench) Begin(ctx context.Context, opts BeginOpts) (*TxX, error) {
 	iso, err := ParseTxIsolation(string(opts.Isolation))
@@ -347,7 +369,7 @@ func (t *TxX) Commit(ctx context.Context) error {
 		}
 	}
 
-	t.b.root.txMetrics.record(t.b.vu, "commit", t.name, t.iso)
+	t.b.root.txMetrics.record(t.b.vu, t.b.driverTag, "commit", t.name, t.iso)
 	t.recordEnd("commit", committed)
 
 	return nil
@@ -360,7 +382,7 @@ func (t *TxX) Rollback(ctx context.Context) error {
 		}
 	}
 
-	t.b.root.txMetrics.record(t.b.vu, "rollback", t.name, t.iso)
+	t.b.root.txMetrics.record(t.b.vu, t.b.driverTag, "rollback", t.name, t.iso)
 	t.recordEnd("rollback", false)
 
 	return nil
@@ -375,7 +397,7 @@ func (t *TxX) recordEnd(action string, committed bool) {
 
 	t.done = true
 	t.b.root.txMetrics.recordTxEnd(
-		t.b.vu, action, t.name, t.iso, time.Since(t.start), t.queries, committed,
+		t.b.vu, t.b.driverTag, action, t.name, t.iso, time.Since(t.start), t.queries, committed,
 	)
 }
 
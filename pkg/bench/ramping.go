@@ -0,0 +1,133 @@
+package bench
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// --- executor (ramping-vus) ---
+
+// rampTick is how often the ramping-vus controller re-evaluates its target.
+const rampTick = 100 * time.Millisecond
+
+// vuGate activates the first n VUs of a ramping pool. A VU above the target
+// parks between iterations, so ramp-down never interrupts an iteration.
+type vuGate struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+	done   bool
+}
+
+func newVUGate(active int) *vuGate {
+	gate := &vuGate{active: active}
+	gate.cond = sync.NewCond(&gate.mu)
+
+	return gate
+}
+
+// wait blocks until vuid is within the active target and reports whether the
+// scenario is still running. A parked VU does not count towards vus_active.
+func (g *vuGate) wait(vuid int, run *scenarioRun) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.done && vuid > g.active {
+		run.vuStopped()
+
+		for !g.done && vuid > g.active {
+			g.cond.Wait()
+		}
+
+		run.vuStarted()
+	}
+
+	return !g.done
+}
+
+func (g *vuGate) set(active int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.active != active {
+		g.active = active
+		g.cond.Broadcast()
+	}
+}
+
+func (g *vuGate) stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.done = true
+	g.cond.Broadcast()
+}
+
+// rampingTarget returns the VU target elapsed into a profile that starts at
+// start VUs and moves linearly through each stage.
+func rampingTarget(start int, stages []Stage, elapsed time.Duration) int {
+	from := start
+
+	for _, stage := range stages {
+		if elapsed < stage.Duration {
+			progress := float64(elapsed) / float64(stage.Duration)
+
+			return int(math.Round(float64(from) + float64(stage.Target-from)*progress))
+		}
+
+		elapsed -= stage.Duration
+		from = stage.Target
+	}
+
+	return from
+}
+
+// runRampingVUsScenario starts a VU for the highest stage target up front and
+// gates them to the current target, so ramping up reuses warm VUs and ramping
+// down lets each surplus VU finish its iteration before it parks.
+func runRampingVUsScenario(
+	ctx context.Context,
+	sc scenarioSpec,
+	run *scenarioRun,
+	startWorker func(vuid int, keep func() bool),
+) {
+	peak := sc.vus
+	for _, stage := range sc.stages {
+		peak = max(peak, stage.Target)
+	}
+
+	gate := newVUGate(sc.vus)
+	defer gate.stop()
+
+	for i := range peak {
+		vuid := i + 1
+		startWorker(vuid, func() bool { return gate.wait(vuid, run) })
+	}
+
+	var total time.Duration
+	for _, stage := range sc.stages {
+		total += stage.Duration
+	}
+
+	begin := time.Now()
+	ticker := time.NewTicker(rampTick)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		elapsed := time.Since(begin)
+		if elapsed >= total {
+			return
+		}
+
+		gate.set(rampingTarget(sc.vus, sc.stages, elapsed))
+	}
+}
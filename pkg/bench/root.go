@@ -29,6 +29,7 @@ type RootState struct {
 	meterProvider *sdkmetric.MeterProvider
 	manualReader  *sdkmetric.ManualReader
 	metricsPrefix string
+	prometheus    *prometheusEndpoint // nil without PrometheusListen
 
 	txMetrics *txMetrics
 
@@ -50,8 +51,27 @@ func newRootState(
 	env map[string]string,
 	metricsConfig *MetricsConfig,
 ) (*RootState, error) {
-	provider, reader, prefix, err := newMeterProvider(ctx, metricsConfig)
+	var (
+		prometheus *prometheusEndpoint
+		readers    []sdkmetric.Reader
+	)
+
+	if metricsConfig != nil && metricsConfig.PrometheusListen != "" {
+		endpoint, err := newPrometheusEndpoint(metricsConfig.PrometheusListen, lg)
+		if err != nil {
+			return nil, err
+		}
+
+		prometheus = endpoint
+		readers = append(readers, endpoint.metricReader())
+	}
+
+	provider, reader, prefix, err := newMeterProvider(ctx, metricsConfig, readers...)
 	if err != nil {
+		if prometheus != nil {
+			_ = prometheus.shutdown(ctx)
+		}
+
 		return nil, err
 	}
 
@@ -63,6 +83,7 @@ func newRootState(
 		meterProvider: provider,
 		manualReader:  reader,
 		metricsPrefix: prefix,
+		prometheus:    prometheus,
 		txMetrics:     &txMetrics{},
 		sharedSlots:   make(map[uint64]*sharedDriverSlot),
 		env:           env,
@@ -95,4 +116,10 @@ func (r *RootState) shutdownMetrics() {
 	if err := r.meterProvider.Shutdown(ctx); err != nil {
 		r.lg.Error("shutting down metrics", zap.Error(err))
 	}
+
+	if r.prometheus != nil {
+		if err := r.prometheus.shutdown(ctx); err != nil {
+			r.lg.Error("shutting down Prometheus endpoint", zap.Error(err))
+		}
+	}
 }
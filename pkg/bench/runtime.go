@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
 
@@ -32,6 +35,8 @@ type Workload interface {
 
 // Bench is the per-VU session handed to a workload: the shared driver (pool-backed,
 // safe for concurrent VUs), the VU's identity/step-tag for metrics, and the SDK API.
+// It is bound to driver 0; DriverAt returns the same session bound to another
+// configured driver.
 type Bench struct {
 	root *RootState
 	vu   *VU
@@ -39,9 +44,40 @@ type Bench struct {
 	drv  driver.Driver
 	cfg  *stroppy.DriverConfig
 
+	drivers   map[int]*driverTarget
+	driverTag string
+
 	stepStart time.Time
 }
 
+// driverTarget is one dispatched driver of a run, addressed by its -d index.
+type driverTarget struct {
+	drv driver.Driver
+	cfg *stroppy.DriverConfig
+	tag string // metric driver tag; empty when the run has a single driver
+}
+
+// DriverAt returns this session bound to the driver configured at index idx
+// (-d<idx> on the CLI, "drivers" key in the config file). Exec, the Query
+// helpers, Begin, Insert and TxRetryPolicy on the result run against that
+// driver; in a run with several drivers their metrics carry a driver tag.
+func (b *Bench) DriverAt(idx int) (*Bench, error) {
+	target, ok := b.drivers[idx]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errDriverIndexNotConfigured, idx)
+	}
+
+	bound := *b
+	bound.drv, bound.cfg, bound.driverTag = target.drv, target.cfg, target.tag
+
+	return &bound, nil
+}
+
+// DriverIndexes returns the configured driver indexes in ascending order.
+func (b *Bench) DriverIndexes() []int {
+	return slices.Sorted(maps.Keys(b.drivers))
+}
+
 // Driver returns the raw driver (escape hatch).
 func (b *Bench) Driver() driver.Driver { return b.drv }
 
@@ -67,14 +103,33 @@ var (
 
 	errNoWorkloadRegistered      = errors.New("bench: no workload registered")
 	errDriverIndexMissing        = errors.New("bench: driver index 0 not configured")
+	errDriverIndexNotConfigured  = errors.New("bench: driver index not configured")
 	errUnsupportedExecutor       = errors.New("unsupported executor")
 	errVUsOutOfRange             = errors.New("vus must be at least 1")
 	errIterationsOutOfRange      = errors.New("iterations must be at least 1")
 	errDurationOutOfRange        = errors.New("duration must be positive")
-	errDurationNeedsExecutor     = errors.New("duration requires an explicit constant-vus executor")
-	errDurationWithWrongExecutor = errors.New("duration is only valid with the constant-vus executor")
+	errDurationNeedsExecutor     = errors.New("duration requires an explicit constant-vus or constant-arrival-rate")
+	errDurationWithWrongExecutor = errors.New("duration is only valid with the constant-vus or constant-arrival-rate")
 	errConstantVUsNeedsDuration  = errors.New("constant-vus requires duration")
+	errArrivalRateNeedsDuration  = errors.New("constant-arrival-rate requires duration")
+	errArrivalRateNeedsRate      = errors.New("constant-arrival-rate requires rate")
+	errRateOutOfRange            = errors.New("rate must be at least 1")
+	errRateWithWrongExecutor     = errors.New("rate is only valid with the constant-arrival-rate executor")
+	errTimeUnitOutOfRange        = errors.New("time-unit must be positive")
+	errMaxVUsBelowVUs            = errors.New("max-vus must not be less than vus")
+	errArrivalOnlyParam          = errors.New("is only valid with an arrival-rate executor")
+	errStartRateOutOfRange       = errors.New("start-rate must not be negative")
+	errRampingNeedsStages        = errors.New("requires stages")
+	errStagesWithWrongExecutor   = errors.New("stages are only valid with a ramping executor")
+	errStagesNeedDuration        = errors.New("stages must have a positive total duration")
 	errNegativeQueryTimeout      = errors.New("query-timeout must not be negative")
+	errUnsupportedOnError        = errors.New("on-error must be continue, backoff or abort")
+	errErrorBackoffOutOfRange    = errors.New("error-backoff must be positive")
+	errErrorBackoffWithoutPolicy = errors.New("error-backoff is only valid with on-error backoff")
+	errMaxErrorsOutOfRange       = errors.New("max-errors must not be negative")
+	errMaxErrorRateOutOfRange    = errors.New("max-error-rate must be between 0 and 100")
+	errErrorRateWindowOutOfRange = errors.New("error-rate-window must be positive")
+	errErrorWindowWithoutRate    = errors.New("error-rate-window requires max-error-rate")
 )
 
 // Register adds a workload factory. Workload packages call it during init.
@@ -144,12 +199,12 @@ func Describe(name string) (Description, error) {
 		return Description{}, fmt.Errorf("%w as %q", errNoWorkloadRegistered, name)
 	}
 
-	_, schema, err := defineWorkload(wl, ParamInputs{}, true)
+	_, def, err := defineWorkload(wl, ParamInputs{}, true)
 	if err != nil {
 		return Description{}, fmt.Errorf("define workload %q: %w", name, err)
 	}
 
-	return Description{Name: name, Params: schema}, nil
+	return Description{Name: name, Params: def.schema()}, nil
 }
 
 // DescribeAll returns all registered workload schemas ordered by workload name.
@@ -185,6 +240,11 @@ const teardownTimeout = 30 * time.Second
 
 // Run looks up a fresh workload instance and executes it: Define and parameter
 // resolution first, Setup once, Iterate across the scenario, then Teardown once.
+// A "scenarios" list in the run config runs several named scenarios instead, each
+// with its own workload instance; every Setup runs before the first scenario
+// starts and Teardown runs in reverse order once all of them finish. The
+// configured drivers are opened once for the whole run and closed after the
+// last Teardown.
 // env remains available to legacy workload Env calls through the root state.
 func Run(
 	ctx context.Context,
@@ -195,19 +255,41 @@ func Run(
 	lg *zap.Logger,
 	metricsConfig *MetricsConfig,
 ) (retErr error) {
-	wl, ok := Lookup(name)
-	if !ok {
+	if _, ok := Lookup(name); !ok {
 		return fmt.Errorf("%w as %q", errNoWorkloadRegistered, name)
 	}
 
-	scenarioParams, _, err := defineWorkload(wl, paramInputs, false)
+	plan, err := parseScenarioPlan(name, paramInputs)
 	if err != nil {
-		return fmt.Errorf("define workload %q: %w", name, err)
+		return fmt.Errorf("scenarios: %w", err)
 	}
 
-	sc, err := scenarioParams.spec(lg)
+	if metricsConfig == nil {
+		metricsConfig = &MetricsConfig{}
+	}
+
+	thresholds, err := parseThresholds(metricsConfig.Thresholds)
 	if err != nil {
-		return fmt.Errorf("scenario: %w", err)
+		return err
+	}
+
+	if err := validateSummaryExport(metricsConfig.SummaryExport); err != nil {
+		return err
+	}
+
+	scenarios := make([]*preparedScenario, 0, len(plan.scenarios))
+
+	for _, config := range plan.scenarios {
+		prepared, err := prepareScenario(config, plan.named, lg)
+		if err != nil {
+			if plan.named {
+				return fmt.Errorf("scenario %q: %w", config.name, err)
+			}
+
+			return err
+		}
+
+		scenarios = append(scenarios, prepared)
 	}
 
 	root, err = newRootState(lg, ctx, env, metricsConfig)
@@ -215,78 +297,248 @@ func Run(
 		return fmt.Errorf("initialize metrics: %w", err)
 	}
 
-	sum := newSummary(root)
+	hooks := RunHooksFrom(ctx)
+
+	sum := newSummary(root, summaryConfig{
+		thresholds: thresholds,
+		exportPath: metricsConfig.SummaryExport,
+		runID:      metricsConfig.RunID,
+		workload:   name,
+		drivers:    drivers,
+		scenarios:  scenarios,
+		collected:  hooks.Collected,
+	})
 
 	defer root.shutdownMetrics()
-	defer sum.print()
+	defer func() { retErr = sum.report(retErr) }()
 	defer func() { _ = root.Teardown() }()
 
-	cfg := drivers[0]
-	if cfg == nil {
+	if drivers[0] == nil {
 		return errDriverIndexMissing
 	}
 
-	queryTimeout := scenarioParams.queryTimeout.Value()
-	if queryTimeout < 0 {
-		return fmt.Errorf("%w, got %s", errNegativeQueryTimeout, queryTimeout)
+	// Drivers close after every workload Teardown below has run, since those
+	// defers are registered later and so run first.
+	targetSets, err := dispatchScenarioDrivers(ctx, drivers, scenarios, lg)
+	defer func() { retErr = errors.Join(retErr, teardownDrivers(ctx, targetSets)) }()
+
+	if err != nil {
+		return err
 	}
 
-	drv, err := driver.Dispatch(ctx, driver.Options{
-		Config:       cfg,
-		Logger:       lg,
-		DialFunc:     root.dialer.DialContext,
-		QueryTimeout: queryTimeout,
+	for _, scenario := range scenarios {
+		targets := targetSets[scenario.queryTimeout]
+		drv, cfg := targets[0].drv, targets[0].cfg
+
+		wl := scenario.workload
+		workloadLg := lg.Named("workload").With(zap.String("workload", scenario.config.workload))
+
+		if plan.named {
+			workloadLg = workloadLg.With(zap.String("scenario", scenario.config.name))
+		}
+
+		setupVU := &VU{root: root, vuid: 1, scenario: scenario.spec.tag, initPhase: true, ctx: ctx}
+		setupBench := &Bench{root: root, vu: setupVU, lg: workloadLg, drv: drv, cfg: cfg, drivers: targets}
+
+		// Teardown always runs exactly once for every workload whose Setup was
+		// attempted, even when Setup or a scenario returns early on cancellation or
+		// error. It executes under a timeout detached from cancellation of the run
+		// ctx, and its error is joined with any returned error.
+		defer func() {
+			teardownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), teardownTimeout)
+			defer cancel()
+
+			if err := wl.Teardown(teardownCtx, setupBench); err != nil {
+				retErr = errors.Join(retErr, scenarioError(plan, scenario, fmt.Errorf("teardown: %w", err)))
+			}
+		}()
+
+		if err := wl.Setup(ctx, setupBench); err != nil {
+			return scenarioError(plan, scenario, fmt.Errorf("setup: %w", err))
+		}
+
+		scenario.iterate = func(vu *VU) error {
+			b := &Bench{
+				root: root, vu: vu,
+				lg:  workloadLg.With(zap.Uint64("VUID", vu.VUID())),
+				drv: drv, cfg: cfg, drivers: targets,
+			}
+
+			return wl.Iterate(vu.Context(), b)
+		}
+	}
+
+	if hooks.Ready != nil {
+		if err := hooks.Ready(ctx); err != nil {
+			return err
+		}
+	}
+
+	sum.start()
+	defer sum.stop()
+
+	return runScenarioPlan(ctx, plan.sequential, scenarios, func(ctx context.Context, scenario *preparedScenario) error {
+		if err := runScenario(ctx, scenario.spec, scenario.iterate); err != nil {
+			return fmt.Errorf("scenario %q: %w", scenario.spec.name, err)
+		}
+
+		return nil
 	})
-	if err != nil {
-		return fmt.Errorf("driver dispatch: %w", err)
+}
+
+// dispatchScenarioDrivers opens the configured drivers once per distinct
+// query-timeout among scenarios, so scenarios that share a timeout, which is
+// every scenario unless they set it differently, share one set of pools. The
+// sets opened before a dispatch error are returned with it for teardown.
+func dispatchScenarioDrivers(
+	ctx context.Context,
+	drivers map[int]*stroppy.DriverConfig,
+	scenarios []*preparedScenario,
+	lg *zap.Logger,
+) (map[time.Duration]map[int]*driverTarget, error) {
+	targetSets := make(map[time.Duration]map[int]*driverTarget)
+
+	for _, scenario := range scenarios {
+		if _, ok := targetSets[scenario.queryTimeout]; ok {
+			continue
+		}
+
+		targets, err := dispatchDrivers(ctx, drivers, scenario.queryTimeout, lg)
+		targetSets[scenario.queryTimeout] = targets
+
+		if err != nil {
+			return targetSets, err
+		}
 	}
 
-	setupVU := &VU{root: root, vuid: 1, initPhase: true, ctx: ctx}
-	setupBench := &Bench{
-		root: root, vu: setupVU,
-		lg:  lg.Named("workload").With(zap.String("workload", name)),
-		drv: drv, cfg: cfg,
+	return targetSets, nil
+}
+
+// teardownDrivers closes every dispatched driver, running interceptor
+// teardown hooks and releasing pools. Like workload Teardown it runs under a
+// timeout detached from cancellation of the run ctx.
+func teardownDrivers(ctx context.Context, targetSets map[time.Duration]map[int]*driverTarget) error {
+	teardownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), teardownTimeout)
+	defer cancel()
+
+	var err error
+
+	for _, timeout := range slices.Sorted(maps.Keys(targetSets)) {
+		targets := targetSets[timeout]
+		for _, idx := range slices.Sorted(maps.Keys(targets)) {
+			if teardownErr := targets[idx].drv.Teardown(teardownCtx); teardownErr != nil {
+				err = errors.Join(err, fmt.Errorf("driver %d teardown: %w", idx, teardownErr))
+			}
+		}
 	}
 
-	// Teardown always runs exactly once, even when Setup or the scenario returns
-	// early on cancellation or error. It executes under a timeout detached from
-	// cancellation of the run ctx, and its error is joined with any returned error.
-	defer func() {
-		teardownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), teardownTimeout)
-		defer cancel()
+	return err
+}
 
-		if err := wl.Teardown(teardownCtx, setupBench); err != nil {
-			retErr = errors.Join(retErr, fmt.Errorf("teardown: %w", err))
+// dispatchDrivers opens every configured driver in index order. Driver metric
+// tags are only set when more than one driver is configured. On error it
+// returns the drivers already opened alongside it.
+func dispatchDrivers(
+	ctx context.Context,
+	drivers map[int]*stroppy.DriverConfig,
+	queryTimeout time.Duration,
+	lg *zap.Logger,
+) (map[int]*driverTarget, error) {
+	targets := make(map[int]*driverTarget, len(drivers))
+
+	for _, idx := range slices.Sorted(maps.Keys(drivers)) {
+		cfg := drivers[idx]
+		if cfg == nil {
+			continue
 		}
-	}()
 
-	if err := wl.Setup(ctx, setupBench); err != nil {
-		return fmt.Errorf("setup: %w", err)
+		drv, err := driver.Dispatch(ctx, driver.Options{
+			Config:       cfg,
+			Logger:       lg,
+			DialFunc:     root.dialer.DialContext,
+			QueryTimeout: queryTimeout,
+		})
+		if err != nil {
+			if idx == 0 {
+				return targets, fmt.Errorf("driver dispatch: %w", err)
+			}
+
+			return targets, fmt.Errorf("driver %d dispatch: %w", idx, err)
+		}
+
+		targets[idx] = &driverTarget{drv: drv, cfg: cfg}
 	}
 
-	if err := runScenario(ctx, sc, func(vu *VU) error {
-		b := &Bench{
-			root: root, vu: vu,
-			lg:  lg.Named("workload").With(zap.String("workload", name), zap.Uint64("VUID", vu.VUID())),
-			drv: drv, cfg: cfg,
+	if len(targets) > 1 {
+		for idx, target := range targets {
+			target.tag = strconv.Itoa(idx)
 		}
+	}
+
+	return targets, nil
+}
 
-		return wl.Iterate(vu.Context(), b)
-	}); err != nil {
-		return fmt.Errorf("scenario %q: %w", sc.name, err)
+// prepareScenario defines a fresh workload instance against the scenario's
+// inputs and resolves its executor spec.
+func prepareScenario(config scenarioConfig, named bool, lg *zap.Logger) (*preparedScenario, error) {
+	wl, ok := Lookup(config.workload)
+	if !ok {
+		return nil, fmt.Errorf("%w as %q", errNoWorkloadRegistered, config.workload)
 	}
 
-	return nil
+	params, def, err := defineWorkload(wl, config.inputs, false)
+	if err != nil {
+		return nil, fmt.Errorf("define workload %q: %w", config.workload, err)
+	}
+
+	sc, err := params.spec(lg)
+	if err != nil {
+		return nil, fmt.Errorf("scenario: %w", err)
+	}
+
+	sc.name = config.name
+	if named {
+		sc.tag = config.name
+	}
+
+	queryTimeout := params.queryTimeout.Value()
+	if queryTimeout < 0 {
+		return nil, fmt.Errorf("%w, got %s", errNegativeQueryTimeout, queryTimeout)
+	}
+
+	return &preparedScenario{
+		config: config, workload: wl, spec: sc, queryTimeout: queryTimeout, params: def.resolved,
+	}, nil
+}
+
+// scenarioError names the scenario in err for runs with named scenarios.
+func scenarioError(plan scenarioPlan, scenario *preparedScenario, err error) error {
+	if !plan.named {
+		return err
+	}
+
+	return fmt.Errorf("scenario %q: %w", scenario.config.name, err)
 }
 
 // --- scenario ---
 
 type scenarioSpec struct {
 	name       string
+	tag        string // metric scenario tag; empty for the implicit scenario
 	executor   string
 	vus        int
 	iterations int64
 	duration   time.Duration
+
+	// ramping-vus: vus is the starting VU count and stage targets count VUs.
+	// arrival-rate executors: vus is the pre-allocated pool, grown up to maxVUs.
+	rate      int
+	startRate int
+	timeUnit  time.Duration
+	maxVUs    int
+	stages    []Stage
+
+	errors errorPolicy
 }
 
 type scenarioParams struct {
@@ -295,6 +547,18 @@ type scenarioParams struct {
 	iterations Param[int64]
 	duration   Param[time.Duration]
 
+	rate      Param[int]
+	startRate Param[int]
+	timeUnit  Param[time.Duration]
+	maxVUs    Param[int]
+	stages    Param[[]Stage]
+
+	onError         Param[string]
+	errorBackoff    Param[time.Duration]
+	maxErrors       Param[int64]
+	maxErrorRate    Param[float64]
+	errorRateWindow Param[time.Duration]
+
 	queryTimeout Param[time.Duration]
 }
 
@@ -302,33 +566,78 @@ func defineWorkload(
 	wl Workload,
 	inputs ParamInputs,
 	defaultsOnly bool,
-) (scenarioParams, []ParamSchema, error) {
+) (scenarioParams, *Def, error) {
 	def := newDef(inputs, defaultsOnly)
+	params := declareScenarioParams(def, inputs, defaultsOnly)
+
+	def.scope = ParamScopeWorkload
+	defineErr := wl.Define(def)
+
+	return params, def, errors.Join(defineErr, def.finish())
+}
 
+// declareScenarioParams declares the run-scope parameters shared by every workload.
+func declareScenarioParams(def *Def, inputs ParamInputs, defaultsOnly bool) scenarioParams {
 	iterationOptions := []ParamOption{LegacyEnvAliases("ITER")}
 	if !defaultsOnly && effectiveDurationIsLegacy(inputs) {
 		iterationOptions = nil
 	}
 
-	params := scenarioParams{
+	return scenarioParams{
 		executor: def.Param.String(
-			"executor", "shared-iterations", "Scenario executor: shared-iterations or constant-vus.",
+			"executor", "shared-iterations",
+			"Scenario executor: shared-iterations, constant-vus, ramping-vus, constant-arrival-rate or ramping-arrival-rate.",
+		),
+		vus: def.Param.Int(
+			"vus", 1,
+			"Number of concurrent virtual users; the starting count for ramping-vus and "+
+				"the pre-allocated pool for arrival-rate executors.",
 		),
-		vus: def.Param.Int("vus", 1, "Number of concurrent virtual users."),
 		iterations: def.Param.Int64(
 			"iterations", 1, "Total shared iterations.", iterationOptions...,
 		),
-		duration: def.Param.Duration("duration", 0, "Duration of a constant-vus scenario."),
+		duration: def.Param.Duration(
+			"duration", 0, "Duration of a constant-vus or constant-arrival-rate scenario.",
+		),
+		rate: def.Param.Int(
+			"rate", 0, "Iterations started per time-unit by the constant-arrival-rate executor.",
+		),
+		startRate: def.Param.Int(
+			"start-rate", 0, "Iterations per time-unit at the start of a ramping-arrival-rate scenario.",
+		),
+		timeUnit: def.Param.Duration(
+			"time-unit", time.Second, "Period that rate, start-rate and stage targets are counted over.",
+		),
+		maxVUs: def.Param.Int(
+			"max-vus", 0, "Upper bound of the arrival-rate VU pool; 0 keeps it at vus.",
+		),
+		stages: def.Param.Stages(
+			"stages", nil, "Ramping load profile as duration:target pairs, e.g. 2m:10,10m:200; "+
+				"targets count VUs for ramping-vus and iterations per time-unit for ramping-arrival-rate.",
+		),
+		onError: def.Param.String(
+			"on-error", onErrorContinue,
+			"What a VU does after a non-fatal iteration error: continue, backoff or abort.",
+		),
+		errorBackoff: def.Param.Duration(
+			"error-backoff", time.Second,
+			"First pause of on-error backoff; doubles on consecutive errors of a VU.",
+		),
+		maxErrors: def.Param.Int64(
+			"max-errors", 0, "Abort the scenario after this many failed iterations; 0 disables it.",
+		),
+		maxErrorRate: def.Param.Float64(
+			"max-error-rate", 0,
+			"Abort the scenario when this percent of iterations in error-rate-window fail; 0 disables it.",
+		),
+		errorRateWindow: def.Param.Duration(
+			"error-rate-window", time.Minute, "Sliding window that max-error-rate is measured over.",
+		),
 		queryTimeout: def.Param.Duration(
 			"query-timeout", 0,
 			"Per-statement query deadline (e.g. 30s, 5s, 500ms); 0 disables it.",
 		),
 	}
-
-	def.scope = ParamScopeWorkload
-	defineErr := wl.Define(def)
-
-	return params, def.schema(), errors.Join(defineErr, def.finish())
 }
 
 func effectiveDurationIsLegacy(inputs ParamInputs) bool {
@@ -383,18 +692,34 @@ func (params *scenarioParams) spec(lg *zap.Logger) (scenarioSpec, error) {
 	}
 
 	if params.duration.Explicit() && !legacyDuration &&
-		(!params.executor.Explicit() || executor != "constant-vus") {
+		(!params.executor.Explicit() || !slices.Contains(durationExecutors, executor)) {
 		return scenarioSpec{}, errDurationNeedsExecutor
 	}
 
 	spec := scenarioSpec{
-		name:       "workload",
+		name:       implicitScenario,
 		executor:   executor,
 		vus:        params.vus.Value(),
 		iterations: params.iterations.Value(),
 		duration:   params.duration.Value(),
+		rate:       params.rate.Value(),
+		startRate:  params.startRate.Value(),
+		timeUnit:   params.timeUnit.Value(),
+		maxVUs:     params.maxVUs.Value(),
+		stages:     params.stages.Value(),
+	}
+
+	if err := params.validateExecutorParams(executor); err != nil {
+		return scenarioSpec{}, err
 	}
 
+	policy, err := params.errorPolicy()
+	if err != nil {
+		return scenarioSpec{}, err
+	}
+
+	spec.errors = policy
+
 	switch executor {
 	case "shared-iterations":
 		if params.duration.Explicit() {
@@ -404,26 +729,185 @@ func (params *scenarioParams) spec(lg *zap.Logger) (scenarioSpec, error) {
 		if !params.duration.Explicit() {
 			return scenarioSpec{}, errConstantVUsNeedsDuration
 		}
+	case "constant-arrival-rate":
+		if !params.duration.Explicit() {
+			return scenarioSpec{}, errArrivalRateNeedsDuration
+		}
+	case "ramping-vus", "ramping-arrival-rate":
+		if params.duration.Explicit() {
+			return scenarioSpec{}, errDurationWithWrongExecutor
+		}
 	default:
 		return scenarioSpec{}, fmt.Errorf("%w %q", errUnsupportedExecutor, executor)
 	}
 
-	return spec, nil
+	return spec.withArrivalDefaults(), nil
 }
 
-// --- executor (shared-iterations + constant-vus) ---
+var (
+	durationExecutors = []string{"constant-vus", "constant-arrival-rate"}
+	arrivalExecutors  = []string{"constant-arrival-rate", "ramping-arrival-rate"}
+	rampingExecutors  = []string{"ramping-vus", "ramping-arrival-rate"}
+)
+
+// validateExecutorParams rejects executor-specific parameters set for an
+// executor that would silently ignore them.
+func (params *scenarioParams) validateExecutorParams(executor string) error {
+	arrival := slices.Contains(arrivalExecutors, executor)
+
+	for _, param := range []struct {
+		name     string
+		explicit bool
+	}{
+		{"start-rate", params.startRate.Explicit()},
+		{"time-unit", params.timeUnit.Explicit()},
+		{"max-vus", params.maxVUs.Explicit()},
+	} {
+		if param.explicit && !arrival {
+			return fmt.Errorf("%s %w", param.name, errArrivalOnlyParam)
+		}
+	}
+
+	if params.rate.Explicit() && executor != "constant-arrival-rate" {
+		return errRateWithWrongExecutor
+	}
+
+	ramping := slices.Contains(rampingExecutors, executor)
+	if params.stages.Explicit() && !ramping {
+		return errStagesWithWrongExecutor
+	}
+
+	if ramping {
+		if err := validateRampingStages(executor, params.stages.Value()); err != nil {
+			return err
+		}
+	}
+
+	if !arrival {
+		return nil
+	}
+
+	if params.timeUnit.Value() <= 0 {
+		return fmt.Errorf("%w, got %s", errTimeUnitOutOfRange, params.timeUnit.Value())
+	}
+
+	if params.maxVUs.Explicit() && params.maxVUs.Value() < params.vus.Value() {
+		return fmt.Errorf("%w, got %d < %d", errMaxVUsBelowVUs, params.maxVUs.Value(), params.vus.Value())
+	}
+
+	if executor == "constant-arrival-rate" {
+		if !params.rate.Explicit() {
+			return errArrivalRateNeedsRate
+		}
+
+		if params.rate.Value() < 1 {
+			return fmt.Errorf("%w, got %d", errRateOutOfRange, params.rate.Value())
+		}
+
+		return nil
+	}
+
+	if params.startRate.Value() < 0 {
+		return fmt.Errorf("%w, got %d", errStartRateOutOfRange, params.startRate.Value())
+	}
+
+	return nil
+}
+
+func validateRampingStages(executor string, stages []Stage) error {
+	if len(stages) == 0 {
+		return fmt.Errorf("%s %w", executor, errRampingNeedsStages)
+	}
+
+	var total time.Duration
+	for _, stage := range stages {
+		total += stage.Duration
+	}
+
+	if total <= 0 {
+		return errStagesNeedDuration
+	}
+
+	return nil
+}
+
+// errorPolicy validates and returns the scenario's iteration error policy.
+func (params *scenarioParams) errorPolicy() (errorPolicy, error) {
+	policy := errorPolicy{
+		onError:   params.onError.Value(),
+		backoff:   params.errorBackoff.Value(),
+		maxErrors: params.maxErrors.Value(),
+		maxRate:   params.maxErrorRate.Value(),
+		window:    params.errorRateWindow.Value(),
+	}
+
+	switch policy.onError {
+	case onErrorContinue, onErrorAbort:
+		if params.errorBackoff.Explicit() {
+			return errorPolicy{}, errErrorBackoffWithoutPolicy
+		}
+	case onErrorBackoff:
+		if policy.backoff <= 0 {
+			return errorPolicy{}, fmt.Errorf("%w, got %s", errErrorBackoffOutOfRange, policy.backoff)
+		}
+	default:
+		return errorPolicy{}, fmt.Errorf("%w, got %q", errUnsupportedOnError, policy.onError)
+	}
+
+	if policy.maxErrors < 0 {
+		return errorPolicy{}, fmt.Errorf("%w, got %d", errMaxErrorsOutOfRange, policy.maxErrors)
+	}
+
+	if policy.maxRate < 0 || policy.maxRate > percentScale {
+		return errorPolicy{}, fmt.Errorf("%w, got %g", errMaxErrorRateOutOfRange, policy.maxRate)
+	}
+
+	if params.errorRateWindow.Explicit() && policy.maxRate == 0 {
+		return errorPolicy{}, errErrorWindowWithoutRate
+	}
+
+	if policy.window <= 0 {
+		return errorPolicy{}, fmt.Errorf("%w, got %s", errErrorRateWindowOutOfRange, policy.window)
+	}
+
+	return policy, nil
+}
+
+func (sc scenarioSpec) withArrivalDefaults() scenarioSpec {
+	if sc.timeUnit <= 0 {
+		sc.timeUnit = time.Second
+	}
+
+	if sc.maxVUs < sc.vus {
+		sc.maxVUs = sc.vus
+	}
+
+	return sc
+}
+
+// arrivalSchedule returns the start schedule of an arrival-rate scenario.
+func (sc scenarioSpec) arrivalSchedule() *arrivalSchedule {
+	if sc.executor == "constant-arrival-rate" {
+		return newArrivalSchedule(sc.rate, []Stage{{Duration: sc.duration, Target: sc.rate}}, sc.timeUnit)
+	}
+
+	return newArrivalSchedule(sc.startRate, sc.stages, sc.timeUnit)
+}
+
+// --- executor (shared-iterations + constant-vus; ramping-vus in ramping.go, arrival-rate in arrival.go) ---
 
 func runScenario(ctx context.Context, sc scenarioSpec, iterate func(*VU) error) error {
 	scenarioCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	fatalErrors := make(chan error, 1)
+	run := newScenarioRun(scenarioCtx, sc)
 
 	var wg sync.WaitGroup
 
 	startWorker := func(vuid int, keep func() bool) {
 		wg.Go(func() {
-			if err := runWorker(scenarioCtx, vuid, iterate, keep); IsFatalError(err) {
+			if err := runWorker(scenarioCtx, run, vuid, iterate, keep); IsFatalError(err) {
 				select {
 				case fatalErrors <- err:
 					cancel()
@@ -448,6 +932,11 @@ func runScenario(ctx context.Context, sc scenarioSpec, iterate func(*VU) error)
 				return time.Now().Before(deadline)
 			})
 		}
+	case "ramping-vus":
+		runRampingVUsScenario(scenarioCtx, sc, run, startWorker)
+	case "constant-arrival-rate", "ramping-arrival-rate":
+		arrival := sc.withArrivalDefaults()
+		runArrivalScenario(scenarioCtx, arrival, arrival.arrivalSchedule(), startWorker)
 	default:
 		return fmt.Errorf("%w %q", errUnsupportedExecutor, sc.executor)
 	}
@@ -463,8 +952,20 @@ func runScenario(ctx context.Context, sc scenarioSpec, iterate func(*VU) error)
 	return ctx.Err()
 }
 
-func runWorker(ctx context.Context, vuid int, iterate func(*VU) error, keep func() bool) error {
-	vu := &VU{root: root, vuid: uint64(vuid), ctx: ctx} //nolint:gosec // G115: scale-bound, no overflow
+// runWorker runs one VU until keep reports false. A non-fatal iteration error
+// is logged and handled by the scenario's error policy; the VU only stops on a
+// fatal error, cancellation, on-error abort, or an exceeded error threshold.
+func runWorker(ctx context.Context, run *scenarioRun, vuid int, iterate func(*VU) error, keep func() bool) error {
+	vu := &VU{
+		root: root, vuid: uint64(vuid), //nolint:gosec // G115: scale-bound, no overflow
+		scenario: run.tag, ctx: ctx,
+	}
+
+	run.vuStarted()
+	defer run.vuStopped()
+
+	consecutive := 0
+
 	for keep() {
 		if err := ctx.Err(); err != nil {
 			return err
@@ -475,20 +976,36 @@ func runWorker(ctx context.Context, vuid int, iterate func(*VU) error, keep func
 
 		start := time.Now()
 		err := iterate(vu)
-		root.txMetrics.recordIteration(vu, time.Since(start))
+		// An iteration cut short by the scenario ending is not a failure.
+		canceled := ctx.Err() != nil && errors.Is(err, ctx.Err())
+		root.txMetrics.recordIteration(vu, time.Since(start), err != nil && !canceled)
 
 		if err != nil {
 			if IsFatalError(err) {
 				return err
 			}
 
-			if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			if canceled {
 				return ctx.Err()
 			}
+		}
+
+		if abort := run.observe(err != nil, err); abort != nil {
+			return abort
+		}
 
-			root.lg.Error("iteration failed", zap.Int("vu", vuid), zap.Error(err))
+		if err == nil {
+			consecutive = 0
 
-			return nil
+			continue
+		}
+
+		consecutive++
+
+		root.lg.Error("iteration failed", zap.Int("vu", vuid), zap.Error(err))
+
+		if err := run.afterError(ctx, consecutive, err); err != nil {
+			return err
 		}
 	}
 
@@ -498,8 +1015,9 @@ func runWorker(ctx context.Context, vuid int, iterate func(*VU) error, keep func
 // --- metric handles (Counter/Trend/Rate) ---
 
 type Metric struct {
-	root *RootState
-	m    *metric
+	root     *RootState
+	m        *metric
+	scenario string
 }
 
 func (b *Bench) Counter(name string) *Metric { return b.newMetric(name, Counter) }
@@ -507,40 +1025,114 @@ func (b *Bench) Trend(name string) *Metric   { return b.newMetric(name, Trend) }
 func (b *Bench) Rate(name string) *Metric    { return b.newMetric(name, Rate) }
 
 func (b *Bench) newMetric(name string, typ metricType) *Metric {
-	m, err := b.root.registry.NewMetric(name, typ)
+	m, err := b.root.registry.metricFor(name, typ)
 	if err != nil {
 		b.lg.Fatal("can't register metric", zap.String("name", name), zap.Error(err))
 	}
 
-	return &Metric{root: b.root, m: m}
+	return &Metric{root: b.root, m: m, scenario: b.vu.scenario}
 }
 
-// Add records a value with optional tag key/value pairs.
+// Add records a value with optional tag key/value pairs. Metrics created in a
+// named scenario also carry its scenario tag.
 func (m *Metric) Add(value float64, tags ...string) {
+	if m.scenario != "" {
+		tags = append([]string{"scenario", m.scenario}, tags...)
+	}
+
 	m.m.add(context.Background(), value, m.m.taggedAttributes(tags))
 }
 
 // --- summary ---
 
+// summaryConfig is what the end-of-run report needs besides the metrics.
+type summaryConfig struct {
+	thresholds []threshold
+	exportPath string // --summary-export file; empty disables it
+	runID      string
+	workload   string
+	drivers    map[int]*stroppy.DriverConfig
+	scenarios  []*preparedScenario
+	collected  func(MetricsPayload) // RunHooks.Collected; nil outside an agent
+}
+
 type summary struct {
-	root *RootState
+	collect func(*metricdata.ResourceMetrics) error
+	prefix  string
+	config  summaryConfig
+
+	created time.Time
+	// began and elapsed bound the scenario phase that counter rates cover.
+	began   time.Time
+	elapsed time.Duration
+}
+
+func newSummary(root *RootState, config summaryConfig) *summary {
+	return &summary{
+		collect: func(data *metricdata.ResourceMetrics) error {
+			return root.manualReader.Collect(context.Background(), data)
+		},
+		prefix:  root.metricsPrefix,
+		config:  config,
+		created: time.Now(),
+	}
 }
 
-func newSummary(root *RootState) *summary { return &summary{root: root} }
+func (s *summary) start() { s.began = time.Now() }
+func (s *summary) stop()  { s.elapsed = time.Since(s.began) }
 
-func (s *summary) print() {
+// report prints the summary and threshold table and writes the summary export.
+// A crossed threshold only fails a run that otherwise succeeded.
+func (s *summary) report(runErr error) error {
 	var data metricdata.ResourceMetrics
-	if err := s.root.manualReader.Collect(context.Background(), &data); err != nil {
+	if err := s.collect(&data); err != nil {
 		fmt.Fprintf(os.Stderr, "bench: collect metrics: %v\n", err)
 
-		return
+		if runErr == nil && len(s.config.thresholds) > 0 {
+			return fmt.Errorf("%w: collect metrics: %w", ErrThresholdsCrossed, err)
+		}
+
+		return runErr
+	}
+
+	if s.config.collected != nil {
+		s.config.collected(newMetricsPayload(data, s.prefix))
 	}
 
+	data = explicitTrends(data)
+	s.print(data)
+
+	var (
+		results  []thresholdResult
+		reported error
+	)
+
+	if len(s.config.thresholds) > 0 {
+		results = evaluateThresholds(s.config.thresholds, data, s.prefix, s.elapsed)
+		if !printThresholds(os.Stderr, results) {
+			reported = ErrThresholdsCrossed
+		}
+	}
+
+	if runErr != nil {
+		reported = runErr
+	}
+
+	if s.config.exportPath != "" {
+		if err := s.export(data, results, runErr); err != nil {
+			return errors.Join(reported, fmt.Errorf("summary export: %w", err))
+		}
+	}
+
+	return reported
+}
+
+func (s *summary) print(data metricdata.ResourceMetrics) {
 	var lines []string
 
 	for _, scope := range data.ScopeMetrics {
 		for _, metric := range scope.Metrics {
-			name := strings.TrimPrefix(metric.Name, s.root.metricsPrefix)
+			name := strings.TrimPrefix(metric.Name, s.prefix)
 			switch aggregation := metric.Data.(type) {
 			case metricdata.Sum[float64]:
 				var total float64
@@ -549,10 +1141,22 @@ func (s *summary) print() {
 				}
 
 				lines = append(lines, fmt.Sprintf("  %-40s %.3f", name, total))
+				for _, scenario := range summaryScenarios(aggregation.DataPoints, pointAttributes) {
+					points := scenarioPoints(aggregation.DataPoints, scenario)
+					lines = append(lines, fmt.Sprintf("  %-40s %.3f", scenarioMetricName(name, scenario), sumPoints(points)))
+				}
 			case metricdata.Gauge[float64]:
 				lines = append(lines, fmt.Sprintf("  %-40s %.3f", name, sumGauge(aggregation.DataPoints)))
+				for _, scenario := range summaryScenarios(aggregation.DataPoints, pointAttributes) {
+					points := scenarioPoints(aggregation.DataPoints, scenario)
+					lines = append(lines, fmt.Sprintf("  %-40s %.3f", scenarioMetricName(name, scenario), sumGauge(points)))
+				}
 			case metricdata.Histogram[float64]:
 				lines = append(lines, formatHistogramSummary(name, aggregation.DataPoints))
+				for _, scenario := range summaryScenarios(aggregation.DataPoints, histogramPointAttributes) {
+					points := scenarioHistogramPoints(aggregation.DataPoints, scenario)
+					lines = append(lines, formatHistogramSummary(scenarioMetricName(name, scenario), points))
+				}
 			}
 		}
 	}
@@ -570,6 +1174,32 @@ func (s *summary) print() {
 	}
 }
 
+// summaryScenarios returns the sorted scenario tags present on points; a run
+// without named scenarios yields none, so its summary keeps one line per metric.
+func summaryScenarios[P any](points []P, attrs func(P) attribute.Set) []string {
+	var scenarios []string
+
+	for _, point := range points {
+		if scenario := pointScenario(attrs(point)); scenario != "" && !slices.Contains(scenarios, scenario) {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+
+	slices.Sort(scenarios)
+
+	return scenarios
+}
+
+func pointAttributes(point metricdata.DataPoint[float64]) attribute.Set { return point.Attributes }
+
+func histogramPointAttributes(point metricdata.HistogramDataPoint[float64]) attribute.Set {
+	return point.Attributes
+}
+
+func scenarioMetricName(name, scenario string) string {
+	return name + "{scenario=" + scenario + "}"
+}
+
 func sumGauge(points []metricdata.DataPoint[float64]) float64 {
 	var total float64
 	for _, point := range points {
@@ -2,15 +2,21 @@ package bench
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 
 	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
 	_ "github.com/stroppy-io/stroppy/pkg/driver/noop"
 )
 
@@ -121,6 +127,146 @@ func TestRunPassesScenarioCancellationToWorkload(t *testing.T) {
 	}
 }
 
+func TestRunExposesEveryConfiguredDriver(t *testing.T) {
+	workload := &multiDriverWorkload{}
+
+	Register(func() Workload { return workload })
+
+	err := Run(
+		context.Background(),
+		"test/multi-driver",
+		map[int]*stroppy.DriverConfig{
+			0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP},
+			1: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP},
+		},
+		nil,
+		ParamInputs{},
+		zap.NewNop(),
+		&MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !slices.Equal(workload.indexes, []int{0, 1}) {
+		t.Fatalf("DriverIndexes() = %v, want [0 1]", workload.indexes)
+	}
+
+	if workload.sameDriver {
+		t.Fatal("DriverAt(1) returned the driver of index 0")
+	}
+
+	if !errors.Is(workload.missingErr, errDriverIndexNotConfigured) {
+		t.Fatalf("DriverAt(2) error = %v, want errDriverIndexNotConfigured", workload.missingErr)
+	}
+
+	if workload.queries != 2 {
+		t.Fatalf("run_query_operations_total = %v, want 2", workload.queries)
+	}
+}
+
+// TestRunTearsDownSharedDrivers checks that named scenarios share one driver
+// and that Run tears it down, which is when the statement counter reports.
+func TestRunTearsDownSharedDrivers(t *testing.T) {
+	workload := &sharedDriverWorkload{}
+
+	Register(func() Workload { return workload })
+
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	err := Run(
+		context.Background(),
+		"test/shared-driver",
+		map[int]*stroppy.DriverConfig{0: {
+			DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP,
+			Interceptors: []*stroppy.DriverConfig_Interceptor{{
+				Kind: &stroppy.DriverConfig_Interceptor_StatementCounter_{},
+			}},
+		}},
+		nil,
+		ParamInputs{RunConfig: map[string]json.RawMessage{
+			"sequential": json.RawMessage(`true`),
+			"scenarios":  json.RawMessage(`[{"name": "first", "iterations": 2}, {"name": "second", "iterations": 3}]`),
+		}},
+		zap.New(core),
+		&MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(workload.drivers) != 2 || workload.drivers[0] != workload.drivers[1] {
+		t.Fatalf("scenario drivers = %v, want one driver shared by both scenarios", workload.drivers)
+	}
+
+	statements := logs.FilterMessage("Statement").All()
+	if len(statements) != 1 || statements[0].ContextMap()["calls"] != int64(5) {
+		t.Fatalf("statement counter logged %v, want one fingerprint with 5 calls", statements)
+	}
+}
+
+type sharedDriverWorkload struct {
+	drivers []driver.Driver
+}
+
+func (*sharedDriverWorkload) Name() string { return "test/shared-driver" }
+
+func (*sharedDriverWorkload) Define(*Def) error { return nil }
+
+func (w *sharedDriverWorkload) Setup(_ context.Context, b *Bench) error {
+	w.drivers = append(w.drivers, b.Driver())
+
+	return nil
+}
+
+func (*sharedDriverWorkload) Iterate(ctx context.Context, b *Bench) error {
+	return b.Exec(ctx, "select 1", nil)
+}
+
+func (*sharedDriverWorkload) Teardown(context.Context, *Bench) error { return nil }
+
+type multiDriverWorkload struct {
+	indexes    []int
+	sameDriver bool
+	missingErr error
+	queries    float64
+}
+
+func (*multiDriverWorkload) Name() string { return "test/multi-driver" }
+
+func (*multiDriverWorkload) Define(*Def) error { return nil }
+
+func (w *multiDriverWorkload) Setup(context.Context, *Bench) error { return nil }
+
+func (w *multiDriverWorkload) Iterate(ctx context.Context, b *Bench) error {
+	w.indexes = b.DriverIndexes()
+	_, w.missingErr = b.DriverAt(2)
+
+	replica, err := b.DriverAt(1)
+	if err != nil {
+		return err
+	}
+
+	w.sameDriver = replica.Driver() == b.Driver()
+
+	if err := b.Exec(ctx, "select 1", nil); err != nil {
+		return err
+	}
+
+	return replica.Exec(ctx, "select 1", nil)
+}
+
+func (w *multiDriverWorkload) Teardown(_ context.Context, b *Bench) error {
+	metrics, err := b.CollectedMetrics()
+	if err != nil {
+		return err
+	}
+
+	w.queries = metrics["run_query_operations_total"].Total
+
+	return nil
+}
+
 type fatalContextWorkload struct {
 	calls         atomic.Int64
 	canceled      atomic.Bool
@@ -177,3 +323,199 @@ func installRuntimeTestRoot(t *testing.T) {
 		root = oldRoot
 	})
 }
+
+func TestArrivalScheduleConstantRate(t *testing.T) {
+	schedule := newArrivalSchedule(4, []Stage{{Duration: time.Second, Target: 4}}, time.Second)
+
+	var offsets []time.Duration
+	for {
+		offset, ok := schedule.nextOffset()
+		if !ok {
+			break
+		}
+
+		offsets = append(offsets, offset)
+	}
+
+	want := []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond, 750 * time.Millisecond}
+	if !slices.Equal(offsets, want) {
+		t.Fatalf("offsets = %v, want %v", offsets, want)
+	}
+}
+
+func TestArrivalScheduleRampsLinearly(t *testing.T) {
+	// 0 -> 10/s over 2s integrates to 10 iterations, then a flat 10/s second.
+	schedule := newArrivalSchedule(0, []Stage{
+		{Duration: 2 * time.Second, Target: 10},
+		{Duration: time.Second, Target: 10},
+	}, time.Second)
+
+	var offsets []time.Duration
+	for {
+		offset, ok := schedule.nextOffset()
+		if !ok {
+			break
+		}
+
+		offsets = append(offsets, offset)
+	}
+
+	if len(offsets) != 20 {
+		t.Fatalf("iterations = %d, want 20: %v", len(offsets), offsets)
+	}
+
+	// N(t) = 2.5t² reaches 5 at t = sqrt(2).
+	if got := offsets[5]; (got - 1414*time.Millisecond).Abs() > time.Millisecond {
+		t.Fatalf("offset[5] = %s, want ~1.414s", got)
+	}
+
+	if !slices.IsSorted(offsets) || offsets[len(offsets)-1] >= schedule.total() {
+		t.Fatalf("offsets not within schedule: %v", offsets)
+	}
+}
+
+func TestRunScenarioConstantArrivalRateDropsWhenPoolExhausted(t *testing.T) {
+	installRuntimeTestRoot(t)
+
+	release := make(chan struct{})
+
+	var started atomic.Int64
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runScenario(context.Background(), scenarioSpec{
+			executor: "constant-arrival-rate",
+			vus:      1,
+			maxVUs:   2,
+			rate:     100,
+			timeUnit: time.Second,
+			duration: 100 * time.Millisecond,
+		}, func(*VU) error {
+			started.Add(1)
+			<-release
+
+			return nil
+		})
+	}()
+
+	time.Sleep(150 * time.Millisecond)
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("runScenario() error = %v", err)
+	}
+
+	if got := started.Load(); got != 2 {
+		t.Fatalf("started iterations = %d, want 2 (max-vus)", got)
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := root.manualReader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	var dropped float64
+
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			if metric.Name == root.metricsPrefix+"dropped_iterations_total" {
+				dropped = sumPoints(metric.Data.(metricdata.Sum[float64]).DataPoints)
+			}
+		}
+	}
+
+	if dropped != 8 {
+		t.Fatalf("dropped iterations = %v, want 8", dropped)
+	}
+}
+
+func TestRampingTarget(t *testing.T) {
+	stages := []Stage{{Duration: 10 * time.Second, Target: 10}, {Duration: 10 * time.Second, Target: 2}}
+
+	for _, tc := range []struct {
+		elapsed time.Duration
+		want    int
+	}{
+		{0, 0},
+		{5 * time.Second, 5},
+		{10 * time.Second, 10},
+		{15 * time.Second, 6},
+		{time.Minute, 2},
+	} {
+		if got := rampingTarget(0, stages, tc.elapsed); got != tc.want {
+			t.Fatalf("rampingTarget(%s) = %d, want %d", tc.elapsed, got, tc.want)
+		}
+	}
+}
+
+func TestRunScenarioRampingVUsFollowsStages(t *testing.T) {
+	installRuntimeTestRoot(t)
+
+	var (
+		active atomic.Int64
+		peak   atomic.Int64
+		seen   [5]atomic.Bool
+	)
+
+	start := time.Now()
+
+	err := runScenario(context.Background(), scenarioSpec{
+		executor: "ramping-vus",
+		vus:      1,
+		stages:   []Stage{{Duration: 200 * time.Millisecond, Target: 4}, {Duration: 200 * time.Millisecond, Target: 1}},
+	}, func(vu *VU) error {
+		current := active.Add(1)
+		defer active.Add(-1)
+
+		for {
+			prev := peak.Load()
+			if current <= prev || peak.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+
+		seen[vu.VUID()].Store(true)
+		time.Sleep(5 * time.Millisecond)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runScenario() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("ramping-vus finished after %s, want the full stage duration", elapsed)
+	}
+
+	if got := peak.Load(); got > 4 {
+		t.Fatalf("peak concurrent VUs = %d, want at most 4", got)
+	}
+
+	for vuid := 1; vuid <= 4; vuid++ {
+		if !seen[vuid].Load() {
+			t.Fatalf("VU %d never ran an iteration", vuid)
+		}
+	}
+}
+
+func TestRunScenarioArrivalRateStopsOnCancellation(t *testing.T) {
+	installRuntimeTestRoot(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := runScenario(ctx, scenarioSpec{
+		executor: "ramping-arrival-rate",
+		vus:      2,
+		maxVUs:   4,
+		timeUnit: time.Second,
+		stages:   []Stage{{Duration: time.Hour, Target: 1000}},
+	}, func(vu *VU) error {
+		<-vu.Context().Done()
+
+		return vu.Context().Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("runScenario() error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,285 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// --- named scenarios (run.scenarios) ---
+
+const (
+	scenariosConfigKey  = "scenarios"
+	sequentialConfigKey = "sequential"
+	implicitScenario    = "workload"
+)
+
+var (
+	errScenariosNotList      = errors.New(`run "scenarios" must be a non-empty list of objects`)
+	errSequentialNotBool     = errors.New(`run "sequential" must be a boolean`)
+	errSequentialNoScenarios = errors.New(`run "sequential" requires "scenarios"`)
+	errScenarioNameMissing   = errors.New("scenario name is required")
+	errScenarioNameDuplicate = errors.New("duplicate scenario name")
+	errScenarioFieldType     = errors.New("scenario field has the wrong type")
+	errScenarioStartTime     = errors.New("startTime must be a non-negative duration")
+)
+
+// scenarioConfig is one scenario of a run: the workload it drives, how long it
+// waits before starting, and the parameter inputs its Define resolves against.
+type scenarioConfig struct {
+	name      string
+	workload  string
+	startTime time.Duration
+	inputs    ParamInputs
+}
+
+// scenarioPlan is the ordered list of scenarios a run executes. named is false
+// for the implicit single scenario, whose metrics carry no scenario tag.
+type scenarioPlan struct {
+	scenarios  []scenarioConfig
+	sequential bool
+	named      bool
+}
+
+// parseScenarioPlan splits the run block into scenarios. Without a scenarios
+// list the run is one implicit scenario of workload over inputs unchanged.
+//
+// Each scenario object takes name (required), workload (default: the run's
+// workload), startTime and params; every other key is a run parameter. Run
+// parameters outside the list are shared defaults for every scenario. CLI run
+// flags apply to every scenario, while CLI workload flags and top-level params
+// only reach scenarios that run the run's own workload.
+func parseScenarioPlan(workload string, inputs ParamInputs) (scenarioPlan, error) {
+	rawScenarios, hasScenarios := inputs.RunConfig[scenariosConfigKey]
+	rawSequential, hasSequential := inputs.RunConfig[sequentialConfigKey]
+
+	if !hasScenarios {
+		if hasSequential {
+			return scenarioPlan{}, errSequentialNoScenarios
+		}
+
+		return scenarioPlan{scenarios: []scenarioConfig{{
+			name: implicitScenario, workload: workload, inputs: inputs,
+		}}}, nil
+	}
+
+	plan := scenarioPlan{named: true}
+
+	if hasSequential {
+		if err := json.Unmarshal(rawSequential, &plan.sequential); err != nil {
+			return scenarioPlan{}, errSequentialNotBool
+		}
+	}
+
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(rawScenarios, &entries); err != nil || len(entries) == 0 {
+		return scenarioPlan{}, errScenariosNotList
+	}
+
+	sharedRun := cloneRawMap(inputs.RunConfig)
+	delete(sharedRun, scenariosConfigKey)
+	delete(sharedRun, sequentialConfigKey)
+
+	runCLI := make(map[string]string)
+
+	for name := range runParamNames() {
+		if value, ok := inputs.CLI[name]; ok {
+			runCLI[name] = value
+		}
+	}
+
+	seen := make(map[string]struct{}, len(entries))
+
+	for idx, entry := range entries {
+		scenario, err := parseScenarioEntry(workload, entry)
+		if err != nil {
+			return scenarioPlan{}, fmt.Errorf("scenario %d: %w", idx, err)
+		}
+
+		if _, dup := seen[scenario.name]; dup {
+			return scenarioPlan{}, fmt.Errorf("%w %q", errScenarioNameDuplicate, scenario.name)
+		}
+
+		seen[scenario.name] = struct{}{}
+
+		params, err := scenarioParamsObject(entry["params"])
+		if err != nil {
+			return scenarioPlan{}, fmt.Errorf("scenario %q params: %w", scenario.name, err)
+		}
+
+		scenario.inputs = ParamInputs{
+			CLI:             runCLI,
+			LegacyEnv:       inputs.LegacyEnv,
+			RunConfig:       overlayRawMap(sharedRun, scenarioRunKeys(entry)),
+			WorkloadConfig:  params,
+			LegacyConfigEnv: inputs.LegacyConfigEnv,
+		}
+
+		if scenario.workload == workload {
+			scenario.inputs.CLI = inputs.CLI
+			scenario.inputs.WorkloadConfig = overlayRawMap(inputs.WorkloadConfig, params)
+		}
+
+		plan.scenarios = append(plan.scenarios, scenario)
+	}
+
+	return plan, nil
+}
+
+func parseScenarioEntry(workload string, entry map[string]json.RawMessage) (scenarioConfig, error) {
+	scenario := scenarioConfig{workload: workload}
+
+	if err := decodeScenarioField(entry, "name", &scenario.name); err != nil {
+		return scenarioConfig{}, err
+	}
+
+	if scenario.name == "" {
+		return scenarioConfig{}, errScenarioNameMissing
+	}
+
+	if err := decodeScenarioField(entry, "workload", &scenario.workload); err != nil {
+		return scenarioConfig{}, err
+	}
+
+	var startTime string
+	if err := decodeScenarioField(entry, "startTime", &startTime); err != nil {
+		return scenarioConfig{}, err
+	}
+
+	if startTime != "" {
+		parsed, err := time.ParseDuration(startTime)
+		if err != nil || parsed < 0 {
+			return scenarioConfig{}, fmt.Errorf("%w, got %q", errScenarioStartTime, startTime)
+		}
+
+		scenario.startTime = parsed
+	}
+
+	return scenario, nil
+}
+
+func decodeScenarioField[T any](entry map[string]json.RawMessage, key string, target *T) error {
+	raw, ok := entry[key]
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("%w: %q", errScenarioFieldType, key)
+	}
+
+	return nil
+}
+
+func scenarioParamsObject(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("%w: %q", errScenarioFieldType, "params")
+	}
+
+	return params, nil
+}
+
+// scenarioRunKeys returns the run parameters of a scenario object.
+func scenarioRunKeys(entry map[string]json.RawMessage) map[string]json.RawMessage {
+	run := cloneRawMap(entry)
+	for _, key := range []string{"name", "workload", "startTime", "params"} {
+		delete(run, key)
+	}
+
+	return run
+}
+
+func overlayRawMap(base, overlay map[string]json.RawMessage) map[string]json.RawMessage {
+	merged := cloneRawMap(base)
+	if merged == nil {
+		merged = make(map[string]json.RawMessage, len(overlay))
+	}
+
+	maps.Copy(merged, overlay)
+
+	return merged
+}
+
+// runParamNames returns the names of the run-scope parameters every workload shares.
+func runParamNames() map[string]struct{} {
+	def := newDef(ParamInputs{}, true)
+	declareScenarioParams(def, ParamInputs{}, true)
+
+	return def.names
+}
+
+// preparedScenario is a scenario whose workload is defined and whose executor
+// spec is resolved. Run binds iterate to its driver once Setup succeeds.
+type preparedScenario struct {
+	config       scenarioConfig
+	workload     Workload
+	spec         scenarioSpec
+	queryTimeout time.Duration
+	params       []resolvedParam
+	iterate      func(*VU) error
+}
+
+// runScenarioPlan runs each scenario after its startTime: all at once by
+// default, where the first failure cancels the others, or one after another
+// when sequential, stopping at the first failure.
+func runScenarioPlan(
+	ctx context.Context,
+	sequential bool,
+	scenarios []*preparedScenario,
+	run func(context.Context, *preparedScenario) error,
+) error {
+	if sequential {
+		for _, scenario := range scenarios {
+			if err := waitScenarioStart(ctx, scenario.config.startTime); err != nil {
+				return err
+			}
+
+			if err := run(ctx, scenario); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	for _, scenario := range scenarios {
+		group.Go(func() error {
+			if err := waitScenarioStart(groupCtx, scenario.config.startTime); err != nil {
+				return err
+			}
+
+			return run(groupCtx, scenario)
+		})
+	}
+
+	return group.Wait()
+}
+
+func waitScenarioStart(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,204 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+func TestParseScenarioPlanWithoutScenariosKeepsInputs(t *testing.T) {
+	inputs := ParamInputs{
+		CLI:       map[string]string{"vus": "4"},
+		RunConfig: map[string]json.RawMessage{"executor": json.RawMessage(`"constant-vus"`)},
+	}
+
+	plan, err := parseScenarioPlan("tpcc/tx", inputs)
+	if err != nil {
+		t.Fatalf("parseScenarioPlan() error = %v", err)
+	}
+
+	if plan.named || plan.sequential || len(plan.scenarios) != 1 {
+		t.Fatalf("plan = %#v, want one implicit scenario", plan)
+	}
+
+	scenario := plan.scenarios[0]
+	if scenario.name != implicitScenario || scenario.workload != "tpcc/tx" || scenario.inputs.CLI["vus"] != "4" {
+		t.Fatalf("scenario = %#v", scenario)
+	}
+}
+
+func TestParseScenarioPlanSplitsInputs(t *testing.T) {
+	plan, err := parseScenarioPlan("tpcc/tx", ParamInputs{
+		CLI:       map[string]string{"duration": "1m", "warehouses": "2"},
+		LegacyEnv: map[string]string{"POOL_SIZE": "8"},
+		RunConfig: map[string]json.RawMessage{
+			"executor":   json.RawMessage(`"constant-vus"`),
+			"sequential": json.RawMessage(`true`),
+			"scenarios": json.RawMessage(`[
+				{"name": "oltp", "vus": 16, "params": {"mix": "standard"}},
+				{"name": "olap", "workload": "tpch/tx", "startTime": "30s", "vus": 1, "params": {"scaleFactor": 1}}
+			]`),
+		},
+		WorkloadConfig: map[string]json.RawMessage{"warehouses": json.RawMessage(`10`)},
+	})
+	if err != nil {
+		t.Fatalf("parseScenarioPlan() error = %v", err)
+	}
+
+	if !plan.named || !plan.sequential || len(plan.scenarios) != 2 {
+		t.Fatalf("plan = %#v, want two named sequential scenarios", plan)
+	}
+
+	oltp, olap := plan.scenarios[0], plan.scenarios[1]
+
+	if oltp.workload != "tpcc/tx" || string(oltp.inputs.RunConfig["executor"]) != `"constant-vus"` ||
+		string(oltp.inputs.RunConfig["vus"]) != "16" {
+		t.Fatalf("oltp run inputs = %#v", oltp.inputs.RunConfig)
+	}
+
+	if _, leaked := oltp.inputs.RunConfig["scenarios"]; leaked {
+		t.Fatal("scenarios list leaked into scenario run inputs")
+	}
+
+	if string(oltp.inputs.WorkloadConfig["warehouses"]) != "10" || oltp.inputs.CLI["warehouses"] != "2" {
+		t.Fatalf("oltp did not inherit the run's workload params: %#v", oltp.inputs)
+	}
+
+	if olap.workload != "tpch/tx" || olap.startTime.Seconds() != 30 {
+		t.Fatalf("olap scenario = %#v", olap)
+	}
+
+	if _, ok := olap.inputs.WorkloadConfig["warehouses"]; ok {
+		t.Fatal("top-level params reached a scenario with another workload")
+	}
+
+	if _, ok := olap.inputs.CLI["warehouses"]; ok || olap.inputs.CLI["duration"] != "1m" {
+		t.Fatalf("olap CLI = %#v, want run flags only", olap.inputs.CLI)
+	}
+
+	if olap.inputs.LegacyEnv["POOL_SIZE"] != "8" {
+		t.Fatalf("olap legacy env = %#v", olap.inputs.LegacyEnv)
+	}
+}
+
+func TestParseScenarioPlanRejectsMalformedScenarios(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		run  map[string]json.RawMessage
+		want string
+	}{
+		{"sequential alone", map[string]json.RawMessage{"sequential": json.RawMessage(`true`)}, "requires"},
+		{"empty list", map[string]json.RawMessage{"scenarios": json.RawMessage(`[]`)}, "non-empty list"},
+		{"not a list", map[string]json.RawMessage{"scenarios": json.RawMessage(`{"a": {}}`)}, "non-empty list"},
+		{"missing name", map[string]json.RawMessage{"scenarios": json.RawMessage(`[{"vus": 1}]`)}, "name is required"},
+		{
+			"duplicate name",
+			map[string]json.RawMessage{"scenarios": json.RawMessage(`[{"name": "a"}, {"name": "a"}]`)},
+			"duplicate scenario name",
+		},
+		{
+			"negative start",
+			map[string]json.RawMessage{"scenarios": json.RawMessage(`[{"name": "a", "startTime": "-1s"}]`)},
+			"startTime",
+		},
+		{
+			"params not an object",
+			map[string]json.RawMessage{"scenarios": json.RawMessage(`[{"name": "a", "params": 3}]`)},
+			"params",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseScenarioPlan("tpcc/tx", ParamInputs{RunConfig: tc.run})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("parseScenarioPlan() error = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunNamedScenariosTagMetricsPerScenario(t *testing.T) {
+	recorder := &scenarioRecorder{iterations: map[string]float64{}}
+
+	Register(func() Workload { return &scenarioTestWorkload{recorder: recorder} })
+
+	err := Run(
+		context.Background(),
+		"test/scenarios",
+		map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP}},
+		nil,
+		ParamInputs{RunConfig: map[string]json.RawMessage{
+			"sequential": json.RawMessage(`true`),
+			"scenarios": json.RawMessage(`[
+				{"name": "first", "iterations": 3},
+				{"name": "second", "iterations": 5, "vus": 2, "startTime": "10ms"}
+			]`),
+		}},
+		zap.NewNop(),
+		&MetricsConfig{},
+	)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := "setup,setup,first,first,first,second,second,second,second,second"
+	if got := strings.Join(recorder.events, ","); got != want {
+		t.Fatalf("events = %s, want %s (both setups, then first before second)", got, want)
+	}
+
+	if recorder.iterations["first"] != 3 || recorder.iterations["second"] != 5 {
+		t.Fatalf("per-scenario iterations = %v, want first=3 second=5", recorder.iterations)
+	}
+}
+
+type scenarioRecorder struct {
+	mu         sync.Mutex
+	events     []string
+	iterations map[string]float64
+}
+
+func (r *scenarioRecorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+type scenarioTestWorkload struct {
+	recorder *scenarioRecorder
+}
+
+func (*scenarioTestWorkload) Name() string { return "test/scenarios" }
+
+func (*scenarioTestWorkload) Define(*Def) error { return nil }
+
+func (w *scenarioTestWorkload) Setup(context.Context, *Bench) error {
+	w.recorder.add("setup")
+
+	return nil
+}
+
+func (w *scenarioTestWorkload) Iterate(_ context.Context, b *Bench) error {
+	w.recorder.add(b.vu.scenario)
+
+	return nil
+}
+
+func (w *scenarioTestWorkload) Teardown(_ context.Context, b *Bench) error {
+	metrics, err := b.CollectedMetrics()
+	if err != nil {
+		return err
+	}
+
+	w.recorder.mu.Lock()
+	defer w.recorder.mu.Unlock()
+
+	w.recorder.iterations[b.vu.scenario] = metrics["iterations_total"].Total
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
@@ -21,7 +22,8 @@ type MetricSnapshot struct {
 
 // CollectedMetrics returns every collected metric keyed by its prefix-stripped
 // name. Workloads call it from Teardown to post-process their own
-// per-transaction counters and trends into a final report.
+// per-transaction counters and trends into a final report. In a named scenario
+// only that scenario's series are included.
 func (b *Bench) CollectedMetrics() (map[string]MetricSnapshot, error) {
 	var data metricdata.ResourceMetrics
 
@@ -29,11 +31,12 @@ func (b *Bench) CollectedMetrics() (map[string]MetricSnapshot, error) {
 		return nil, err
 	}
 
+	data = explicitTrends(data)
 	out := make(map[string]MetricSnapshot)
 
 	for _, scope := range data.ScopeMetrics {
 		for _, metric := range scope.Metrics {
-			snapshot, ok := snapshotMetric(metric)
+			snapshot, ok := snapshotMetric(metric, b.vu.scenario)
 			if !ok {
 				continue
 			}
@@ -45,20 +48,65 @@ func (b *Bench) CollectedMetrics() (map[string]MetricSnapshot, error) {
 	return out, nil
 }
 
-// snapshotMetric converts one collected metric into a MetricSnapshot.
-func snapshotMetric(metric metricdata.Metrics) (MetricSnapshot, bool) {
+// snapshotMetric converts one collected metric into a MetricSnapshot, keeping
+// only the series of scenario unless it is empty.
+func snapshotMetric(metric metricdata.Metrics, scenario string) (MetricSnapshot, bool) {
 	switch aggregation := metric.Data.(type) {
 	case metricdata.Sum[float64]:
-		return MetricSnapshot{Total: sumPoints(aggregation.DataPoints)}, true
+		return MetricSnapshot{Total: sumPoints(scenarioPoints(aggregation.DataPoints, scenario))}, true
 	case metricdata.Gauge[float64]:
-		return MetricSnapshot{Total: sumPoints(aggregation.DataPoints)}, true
+		return MetricSnapshot{Total: sumPoints(scenarioPoints(aggregation.DataPoints, scenario))}, true
 	case metricdata.Histogram[float64]:
-		return histogramSnapshot(aggregation.DataPoints), true
+		return histogramSnapshot(scenarioHistogramPoints(aggregation.DataPoints, scenario)), true
 	default:
 		return MetricSnapshot{}, false
 	}
 }
 
+func scenarioPoints(points []metricdata.DataPoint[float64], scenario string) []metricdata.DataPoint[float64] {
+	if scenario == "" {
+		return points
+	}
+
+	var kept []metricdata.DataPoint[float64]
+
+	for _, point := range points {
+		if pointScenario(point.Attributes) == scenario {
+			kept = append(kept, point)
+		}
+	}
+
+	return kept
+}
+
+func scenarioHistogramPoints(
+	points []metricdata.HistogramDataPoint[float64], scenario string,
+) []metricdata.HistogramDataPoint[float64] {
+	if scenario == "" {
+		return points
+	}
+
+	var kept []metricdata.HistogramDataPoint[float64]
+
+	for _, point := range points {
+		if pointScenario(point.Attributes) == scenario {
+			kept = append(kept, point)
+		}
+	}
+
+	return kept
+}
+
+// pointScenario returns the scenario tag of a data point, or "" when untagged.
+func pointScenario(attrs attribute.Set) string {
+	value, ok := attrs.Value("scenario")
+	if !ok {
+		return ""
+	}
+
+	return value.AsString()
+}
+
 // sumPoints totals the data points of a Counter or Gauge metric.
 func sumPoints(points []metricdata.DataPoint[float64]) float64 {
 	var total float64
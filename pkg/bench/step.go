@@ -51,6 +51,13 @@ func (s *stepFilterState) enabled(name string) bool {
 	return true
 }
 
+// selected reports whether --steps names the step explicitly.
+func (s *stepFilterState) selected(name string) bool {
+	_, ok := s.only[name]
+
+	return ok
+}
+
 // Step runs fn as a named phase: skips if filtered out (logging the skip), otherwise
 // tags metrics, notifies, logs start/end timing, clears the tag, and returns fn's
 // error. Use it for one-shot setup/load/schema steps, which should each emit one
@@ -67,6 +74,20 @@ func (b *Bench) StepSilent(name string, fn func() error) error {
 	return b.step(name, fn, true)
 }
 
+// StepOptIn runs fn as a named step like Step, but only when --steps names it
+// explicitly: a default run, or one filtered with --no-steps, skips it. Use it
+// for verdicts a measured run should not pay for or fail on unless asked, such
+// as a post-run audit.
+func (b *Bench) StepOptIn(name string, fn func() error) error {
+	if !b.root.stepFilter.selected(name) {
+		b.lg.Sugar().Infof("Skipping opt-in step '%s' (select it with --steps)", name)
+
+		return nil
+	}
+
+	return b.step(name, fn, false)
+}
+
 func (b *Bench) step(name string, fn func() error, silent bool) error {
 	if !b.root.stepFilter.enabled(name) {
 		if !silent {
@@ -87,7 +87,7 @@ func TestStepSilentKeepsMetricTagWithoutLogging(t *testing.T) {
 
 	err := fx.b.StepSilent("workload", func() error {
 		tagDuring = fx.b.vu.stepTag
-		fx.rootState.txMetrics.recordQueryResult(fx.b.vu, time.Millisecond, nil)
+		fx.rootState.txMetrics.recordQueryResult(fx.b.vu, "", time.Millisecond, nil)
 
 		return nil
 	})
@@ -140,6 +140,39 @@ func TestStepAndStepSilentRespectFilter(t *testing.T) {
 	})
 }
 
+func TestStepOptInRunsOnlyWhenSelected(t *testing.T) {
+	for name, only := range map[string]map[string]struct{}{
+		"default run":      {},
+		"other steps only": {"load_data": {}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fx := newTestBenchFixture(t)
+			fx.rootState.stepFilter.only = only
+
+			require.NoError(t, fx.b.StepOptIn("validate_consistency", func() error {
+				t.Fatal("opt-in step ran without being selected")
+
+				return nil
+			}))
+			require.Equal(t, "Skipping opt-in step 'validate_consistency' (select it with --steps)",
+				fx.logs.All()[0].Message)
+		})
+	}
+
+	fx := newTestBenchFixture(t)
+	fx.rootState.stepFilter.only = map[string]struct{}{"workload": {}, "validate_consistency": {}}
+
+	var ran atomic.Bool
+
+	require.NoError(t, fx.b.StepOptIn("validate_consistency", func() error {
+		ran.Store(true)
+
+		return nil
+	}))
+	require.True(t, ran.Load(), "selected opt-in step must run")
+	require.Equal(t, "Start of 'validate_consistency' step", fx.logs.All()[0].Message)
+}
+
 // stepLifecycleWorkload exhibits the uniform workload-step contract: one loud
 // setup step (load_data) and one silent per-iteration step (workload). Its
 // iteration runs one query so per-iteration query metrics carry the step tag.
@@ -0,0 +1,371 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+// --- summary export (machine-readable end-of-run summary) ---
+
+var errSummaryExportFormat = errors.New("summary export file must end in .json or .csv")
+
+// summaryExportQuantiles are the quantiles reported for every trend series.
+var summaryExportQuantiles = []struct {
+	name     string
+	quantile float64
+}{{"p50", 0.5}, {"p90", 0.9}, {"p95", 0.95}, {"p99", 0.99}}
+
+type summaryExport struct {
+	RunID      string                  `json:"run_id,omitempty"`
+	Workload   string                  `json:"workload"`
+	StartedAt  time.Time               `json:"started_at"`
+	FinishedAt time.Time               `json:"finished_at"`
+	Error      string                  `json:"error,omitempty"`
+	Drivers    []summaryExportDriver   `json:"drivers"`
+	Scenarios  []summaryExportScenario `json:"scenarios"`
+	Metrics    []summaryExportMetric   `json:"metrics"`
+	Thresholds []summaryExportCheck    `json:"thresholds,omitempty"`
+}
+
+type summaryExportDriver struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	URL   string `json:"url,omitempty"`
+}
+
+type summaryExportScenario struct {
+	Name     string               `json:"name,omitempty"`
+	Workload string               `json:"workload"`
+	Executor string               `json:"executor"`
+	Params   []summaryExportParam `json:"params"`
+}
+
+type summaryExportParam struct {
+	Name   string      `json:"name"`
+	Scope  ParamScope  `json:"scope"`
+	Value  any         `json:"value"`
+	Source ParamSource `json:"source"`
+}
+
+// summaryExportMetric is one data point: counters and gauges fill Value,
+// trends fill the histogram fields and quantiles.
+type summaryExportMetric struct {
+	Name      string             `json:"name"`
+	Type      string             `json:"type"`
+	Tags      map[string]string  `json:"tags,omitempty"`
+	Value     *float64           `json:"value,omitempty"`
+	Count     uint64             `json:"count,omitempty"`
+	Sum       float64            `json:"sum,omitempty"`
+	Min       *float64           `json:"min,omitempty"`
+	Max       *float64           `json:"max,omitempty"`
+	Bounds    []float64          `json:"bounds,omitempty"`
+	Buckets   []uint64           `json:"buckets,omitempty"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+}
+
+type summaryExportCheck struct {
+	Expr   string  `json:"expr"`
+	Actual float64 `json:"actual"`
+	Passed bool    `json:"passed"`
+	Reason string  `json:"reason,omitempty"`
+}
+
+// validateSummaryExport rejects an unknown export format before the run starts
+// rather than after it has spent its whole duration.
+func validateSummaryExport(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".csv":
+		return nil
+	default:
+		return fmt.Errorf("%w, got %q", errSummaryExportFormat, path)
+	}
+}
+
+func (s *summary) export(data metricdata.ResourceMetrics, results []thresholdResult, runErr error) error {
+	export := summaryExport{
+		RunID:      s.config.runID,
+		Workload:   s.config.workload,
+		StartedAt:  s.created.UTC(),
+		FinishedAt: time.Now().UTC(),
+		Drivers:    exportDrivers(s.config.drivers),
+		Scenarios:  exportScenarios(s.config.scenarios),
+		Metrics:    exportMetrics(data, s.prefix),
+	}
+
+	if runErr != nil {
+		export.Error = runErr.Error()
+	}
+
+	for _, result := range results {
+		export.Thresholds = append(export.Thresholds, summaryExportCheck{
+			Expr:   result.threshold.expr,
+			Actual: result.actual,
+			Passed: result.passed,
+			Reason: result.reason,
+		})
+	}
+
+	file, err := os.Create(s.config.exportPath)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(s.config.exportPath), ".csv") {
+		err = writeSummaryCSV(file, export)
+	} else {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(export)
+	}
+
+	return errors.Join(err, file.Close())
+}
+
+func exportDrivers(drivers map[int]*stroppy.DriverConfig) []summaryExportDriver {
+	exported := make([]summaryExportDriver, 0, len(drivers))
+
+	for index, config := range drivers {
+		if config == nil {
+			continue
+		}
+
+		exported = append(exported, summaryExportDriver{
+			Index: index,
+			Type:  string(DriverTypeNameFromProto(config.GetDriverType())),
+			URL:   redactDriverURL(config.GetUrl()),
+		})
+	}
+
+	sort.Slice(exported, func(i, j int) bool { return exported[i].Index < exported[j].Index })
+
+	return exported
+}
+
+// redactDriverURL masks the password of a URL or of a user:password@ DSN
+// (the MySQL form), so the export can be shared.
+func redactDriverURL(raw string) string {
+	if parsed, err := url.Parse(raw); err == nil && parsed.Scheme != "" && parsed.User != nil {
+		return parsed.Redacted()
+	}
+
+	at := strings.LastIndex(raw, "@")
+	if at < 0 {
+		return raw
+	}
+
+	colon := strings.Index(raw[:at], ":")
+	if colon < 0 || strings.Contains(raw[:colon], "/") {
+		return raw
+	}
+
+	return raw[:colon+1] + "xxxxx" + raw[at:]
+}
+
+func exportScenarios(scenarios []*preparedScenario) []summaryExportScenario {
+	exported := make([]summaryExportScenario, 0, len(scenarios))
+
+	for _, scenario := range scenarios {
+		params := make([]summaryExportParam, 0, len(scenario.params))
+		for _, param := range scenario.params {
+			params = append(params, summaryExportParam{
+				Name:   param.name,
+				Scope:  param.scope,
+				Value:  exportParamValue(param.value),
+				Source: param.source,
+			})
+		}
+
+		exported = append(exported, summaryExportScenario{
+			Name:     scenario.config.name,
+			Workload: scenario.config.workload,
+			Executor: scenario.spec.executor,
+			Params:   params,
+		})
+	}
+
+	return exported
+}
+
+// exportParamValue renders durations and stages in their text form so the
+// export reads the way the values were configured.
+func exportParamValue(value any) any {
+	switch typed := value.(type) {
+	case time.Duration:
+		return typed.String()
+	case []Stage:
+		parts := make([]string, len(typed))
+		for i, stage := range typed {
+			parts[i] = stage.Duration.String() + ":" + strconv.Itoa(stage.Target)
+		}
+
+		return strings.Join(parts, ",")
+	default:
+		return value
+	}
+}
+
+func exportMetrics(data metricdata.ResourceMetrics, prefix string) []summaryExportMetric {
+	var exported []summaryExportMetric
+
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			name := strings.TrimPrefix(metric.Name, prefix)
+
+			switch aggregation := metric.Data.(type) {
+			case metricdata.Sum[float64]:
+				for _, point := range aggregation.DataPoints {
+					exported = append(exported, exportPoint(name, "counter", point))
+				}
+			case metricdata.Gauge[float64]:
+				for _, point := range aggregation.DataPoints {
+					exported = append(exported, exportPoint(name, "gauge", point))
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range aggregation.DataPoints {
+					exported = append(exported, exportHistogramPoint(name, point))
+				}
+			}
+		}
+	}
+
+	return exported
+}
+
+func exportPoint(name, kind string, point metricdata.DataPoint[float64]) summaryExportMetric {
+	value := point.Value
+
+	return summaryExportMetric{Name: name, Type: kind, Tags: exportTags(point.Attributes), Value: &value}
+}
+
+func exportHistogramPoint(name string, point metricdata.HistogramDataPoint[float64]) summaryExportMetric {
+	exported := summaryExportMetric{
+		Name:      name,
+		Type:      "trend",
+		Tags:      exportTags(point.Attributes),
+		Count:     point.Count,
+		Sum:       point.Sum,
+		Bounds:    point.Bounds,
+		Buckets:   point.BucketCounts,
+		Quantiles: make(map[string]float64, len(summaryExportQuantiles)),
+	}
+
+	if value, ok := point.Min.Value(); ok {
+		exported.Min = &value
+	}
+
+	if value, ok := point.Max.Value(); ok {
+		exported.Max = &value
+	}
+
+	for _, q := range summaryExportQuantiles {
+		exported.Quantiles[q.name] = histogramQuantile(point.Bounds, point.BucketCounts, point.Count, q.quantile)
+	}
+
+	return exported
+}
+
+func exportTags(attrs attribute.Set) map[string]string {
+	if attrs.Len() == 0 {
+		return nil
+	}
+
+	tags := make(map[string]string, attrs.Len())
+	for _, attr := range attrs.ToSlice() {
+		tags[string(attr.Key)] = attr.Value.Emit()
+	}
+
+	return tags
+}
+
+// summaryCSVHeader lists the CSV columns; bounds and buckets are joined by ';'
+// and tags are rendered as k=v pairs joined by ';'.
+var summaryCSVHeader = []string{
+	"run_id", "metric", "type", "tags", "value", "count", "sum", "min", "max",
+	"p50", "p90", "p95", "p99", "bounds", "buckets",
+}
+
+func writeSummaryCSV(file *os.File, export summaryExport) error {
+	writer := csv.NewWriter(file)
+	if err := writer.Write(summaryCSVHeader); err != nil {
+		return err
+	}
+
+	for _, metric := range export.Metrics {
+		row := []string{
+			export.RunID, metric.Name, metric.Type, formatCSVTags(metric.Tags),
+			formatCSVFloat(metric.Value), "", "", formatCSVFloat(metric.Min), formatCSVFloat(metric.Max),
+		}
+
+		if metric.Type == "trend" {
+			row[5] = strconv.FormatUint(metric.Count, 10)
+			row[6] = strconv.FormatFloat(metric.Sum, 'g', -1, 64)
+		}
+
+		for _, q := range summaryExportQuantiles {
+			quantile, ok := metric.Quantiles[q.name]
+			if !ok {
+				row = append(row, "")
+
+				continue
+			}
+
+			row = append(row, strconv.FormatFloat(quantile, 'g', -1, 64))
+		}
+
+		bounds := make([]string, len(metric.Bounds))
+		for i, bound := range metric.Bounds {
+			bounds[i] = strconv.FormatFloat(bound, 'g', -1, 64)
+		}
+
+		buckets := make([]string, len(metric.Buckets))
+		for i, bucket := range metric.Buckets {
+			buckets[i] = strconv.FormatUint(bucket, 10)
+		}
+
+		row = append(row, strings.Join(bounds, ";"), strings.Join(buckets, ";"))
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func formatCSVTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+value)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ";")
+}
+
+func formatCSVFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*value, 'g', -1, 64)
+}
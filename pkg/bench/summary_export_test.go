@@ -0,0 +1,127 @@
+package bench
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+func TestRunWritesSummaryExport(t *testing.T) {
+	Register(func() Workload { return &summaryExportTestWorkload{} })
+
+	run := func(path string) error {
+		return Run(
+			context.Background(),
+			"test/summary-export",
+			map[int]*stroppy.DriverConfig{0: {
+				DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP,
+				Url:        "postgres://bench:secret@db:5432/bench",
+			}},
+			nil,
+			ParamInputs{RunConfig: map[string]json.RawMessage{"iterations": json.RawMessage(`3`)}},
+			zap.NewNop(),
+			&MetricsConfig{RunID: "run-7", SummaryExport: path, Thresholds: []string{"iterations_total.count == 3"}},
+		)
+	}
+
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "summary.json")
+
+	if err := run(jsonPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+
+	var export summaryExport
+	if err := json.Unmarshal(raw, &export); err != nil {
+		t.Fatalf("decode export: %v", err)
+	}
+
+	if export.RunID != "run-7" || export.Workload != "test/summary-export" || export.Error != "" {
+		t.Fatalf("export header = %+v", export)
+	}
+
+	if len(export.Drivers) != 1 || export.Drivers[0].URL != "postgres://bench:xxxxx@db:5432/bench" {
+		t.Fatalf("drivers = %+v, want one with a redacted URL", export.Drivers)
+	}
+
+	if len(export.Thresholds) != 1 || !export.Thresholds[0].Passed || export.Thresholds[0].Actual != 3 {
+		t.Fatalf("thresholds = %+v", export.Thresholds)
+	}
+
+	params := export.Scenarios[0].Params
+	iterations := slices.IndexFunc(params, func(p summaryExportParam) bool { return p.Name == "iterations" })
+	if iterations < 0 || params[iterations].Source != ParamSourceConfig || params[iterations].Value != float64(3) {
+		t.Fatalf("params = %+v, want iterations=3 from config", params)
+	}
+
+	var trend *summaryExportMetric
+	for i := range export.Metrics {
+		if export.Metrics[i].Name == "iteration_duration" {
+			trend = &export.Metrics[i]
+		}
+	}
+
+	if trend == nil || trend.Type != "trend" || trend.Count != 3 || len(trend.Buckets) != len(trend.Bounds)+1 ||
+		len(trend.Quantiles) != len(summaryExportQuantiles) {
+		t.Fatalf("iteration_duration = %+v, want a 3-sample trend with buckets and quantiles", trend)
+	}
+
+	csvPath := filepath.Join(dir, "summary.csv")
+	if err := run(csvPath); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	file, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatalf("open csv: %v", err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	if len(rows) < 2 || !slices.Equal(rows[0], summaryCSVHeader) || rows[1][0] != "run-7" {
+		t.Fatalf("csv rows = %v", rows)
+	}
+
+	if err := run(filepath.Join(dir, "summary.txt")); !errors.Is(err, errSummaryExportFormat) {
+		t.Fatalf("Run() with a .txt export error = %v, want errSummaryExportFormat", err)
+	}
+}
+
+func TestRedactDriverURL(t *testing.T) {
+	for raw, want := range map[string]string{
+		"postgres://u:p@h:5432/db":        "postgres://u:xxxxx@h:5432/db",
+		"postgres://h:5432/db":            "postgres://h:5432/db",
+		"root:pass@tcp(127.0.0.1:3306)/b": "root:xxxxx@tcp(127.0.0.1:3306)/b",
+		"/tmp/bench.db":                   "/tmp/bench.db",
+	} {
+		if got := redactDriverURL(raw); got != want {
+			t.Errorf("redactDriverURL(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+type summaryExportTestWorkload struct{}
+
+func (*summaryExportTestWorkload) Name() string                           { return "test/summary-export" }
+func (*summaryExportTestWorkload) Define(*Def) error                      { return nil }
+func (*summaryExportTestWorkload) Setup(context.Context, *Bench) error    { return nil }
+func (*summaryExportTestWorkload) Iterate(context.Context, *Bench) error  { return nil }
+func (*summaryExportTestWorkload) Teardown(context.Context, *Bench) error { return nil }
@@ -0,0 +1,462 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// --- thresholds (pass/fail gates over the run summary) ---
+
+// ErrThresholdsCrossed is returned by Run when the run itself succeeded but at
+// least one threshold failed. The CLI maps it to a dedicated exit status.
+var ErrThresholdsCrossed = errors.New("thresholds crossed")
+
+var (
+	errThresholdSyntax = errors.New("threshold must look like metric{tag=value}.stat < value")
+	errThresholdStat   = errors.New("threshold stat must be count, rate, value, avg, min, max, med or p(N)")
+	errThresholdValue  = errors.New("threshold value must be a number or a duration")
+)
+
+const (
+	statCount = "count"
+	statRate  = "rate"
+	statValue = "value"
+	statAvg   = "avg"
+	statMin   = "min"
+	statMax   = "max"
+	statMed   = "med"
+
+	rateEventsSuffix = "_events_total"
+	rateTrueSuffix   = "_true_total"
+	counterSuffix    = "_total"
+)
+
+// thresholdOperators is ordered so two-character operators match before their
+// one-character prefixes.
+var thresholdOperators = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// threshold is one parsed gate such as tx_total_duration{tx_name=new_order}.p95 < 50ms.
+// Duration values compare in milliseconds, the unit duration trends record.
+type threshold struct {
+	expr     string
+	metric   string
+	tags     []attribute.KeyValue
+	stat     string
+	quantile float64 // set for percentile stats
+	op       string
+	value    float64
+}
+
+// thresholdResult is the outcome of one threshold against the collected metrics.
+type thresholdResult struct {
+	threshold threshold
+	actual    float64
+	passed    bool
+	reason    string // set when the threshold could not be evaluated
+}
+
+// parseThresholds parses every expression, reporting the first invalid one.
+func parseThresholds(exprs []string) ([]threshold, error) {
+	thresholds := make([]threshold, 0, len(exprs))
+
+	for _, expr := range exprs {
+		parsed, err := parseThreshold(expr)
+		if err != nil {
+			return nil, fmt.Errorf("threshold %q: %w", expr, err)
+		}
+
+		thresholds = append(thresholds, parsed)
+	}
+
+	return thresholds, nil
+}
+
+func parseThreshold(expr string) (threshold, error) {
+	parsed := threshold{expr: strings.TrimSpace(expr)}
+
+	lhs, rhs, op, ok := splitThreshold(parsed.expr)
+	if !ok {
+		return threshold{}, errThresholdSyntax
+	}
+
+	parsed.op = op
+
+	selector, stat, ok := cutThresholdStat(lhs)
+	if !ok {
+		return threshold{}, errThresholdSyntax
+	}
+
+	metric, tags, err := parseThresholdSelector(selector)
+	if err != nil {
+		return threshold{}, err
+	}
+
+	parsed.metric, parsed.tags = metric, tags
+
+	if parsed.stat, parsed.quantile, err = parseThresholdStat(stat); err != nil {
+		return threshold{}, err
+	}
+
+	if parsed.value, err = parseThresholdValue(rhs); err != nil {
+		return threshold{}, err
+	}
+
+	return parsed, nil
+}
+
+// splitThreshold splits expr around its comparison operator, ignoring
+// characters inside the tag braces.
+func splitThreshold(expr string) (lhs, rhs, op string, ok bool) {
+	braces := 0
+
+	for idx := range len(expr) {
+		switch expr[idx] {
+		case '{':
+			braces++
+
+			continue
+		case '}':
+			braces--
+
+			continue
+		}
+
+		if braces != 0 {
+			continue
+		}
+
+		for _, candidate := range thresholdOperators {
+			if strings.HasPrefix(expr[idx:], candidate) {
+				lhs = strings.TrimSpace(expr[:idx])
+				rhs = strings.TrimSpace(expr[idx+len(candidate):])
+
+				return lhs, rhs, candidate, lhs != "" && rhs != ""
+			}
+		}
+	}
+
+	return "", "", "", false
+}
+
+// cutThresholdStat splits metric{tags}.stat at the dot that follows the
+// selector; percentile stats such as p(99.9) contain dots of their own.
+func cutThresholdStat(lhs string) (selector, stat string, ok bool) {
+	start := 0
+	if closing := strings.LastIndexByte(lhs, '}'); closing >= 0 {
+		start = closing
+	}
+
+	dot := strings.IndexByte(lhs[start:], '.')
+	if dot < 0 {
+		return "", "", false
+	}
+
+	return lhs[:start+dot], lhs[start+dot+1:], true
+}
+
+func parseThresholdSelector(selector string) (string, []attribute.KeyValue, error) {
+	metric, rest, hasTags := strings.Cut(selector, "{")
+	if metric == "" || strings.ContainsAny(metric, " }") {
+		return "", nil, errThresholdSyntax
+	}
+
+	if !hasTags {
+		return metric, nil, nil
+	}
+
+	body, ok := strings.CutSuffix(rest, "}")
+	if !ok {
+		return "", nil, errThresholdSyntax
+	}
+
+	var tags []attribute.KeyValue
+
+	for pair := range strings.SplitSeq(body, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return "", nil, errThresholdSyntax
+		}
+
+		tags = append(tags, attribute.String(key, value))
+	}
+
+	return metric, tags, nil
+}
+
+func parseThresholdStat(stat string) (string, float64, error) {
+	switch stat {
+	case statCount, statRate, statValue, statAvg, statMin, statMax:
+		return stat, 0, nil
+	case statMed:
+		return stat, medianP, nil
+	}
+
+	digits, ok := strings.CutPrefix(stat, "p")
+	if !ok {
+		return "", 0, fmt.Errorf("%w, got %q", errThresholdStat, stat)
+	}
+
+	if inner, wrapped := strings.CutPrefix(digits, "("); wrapped {
+		digits, wrapped = strings.CutSuffix(inner, ")")
+		if !wrapped {
+			return "", 0, fmt.Errorf("%w, got %q", errThresholdStat, stat)
+		}
+	}
+
+	percentile, err := strconv.ParseFloat(digits, 64)
+	if err != nil || percentile <= 0 || percentile > percentScale {
+		return "", 0, fmt.Errorf("%w, got %q", errThresholdStat, stat)
+	}
+
+	return "p(" + digits + ")", percentile / percentScale, nil
+}
+
+func parseThresholdValue(value string) (float64, error) {
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w, got %q", errThresholdValue, value)
+	}
+
+	return float64(duration) / float64(time.Millisecond), nil
+}
+
+func (t threshold) compare(actual float64) bool {
+	switch t.op {
+	case "<":
+		return actual < t.value
+	case "<=":
+		return actual <= t.value
+	case ">":
+		return actual > t.value
+	case ">=":
+		return actual >= t.value
+	case "==":
+		return actual == t.value
+	default:
+		return actual != t.value
+	}
+}
+
+// evaluateThresholds checks every threshold against one collection of the
+// run's metrics. elapsed is the scenario time counter rates are taken over.
+func evaluateThresholds(
+	thresholds []threshold,
+	data metricdata.ResourceMetrics,
+	prefix string,
+	elapsed time.Duration,
+) []thresholdResult {
+	collected := make(map[string]metricdata.Aggregation)
+
+	for _, scope := range data.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			collected[strings.TrimPrefix(metric.Name, prefix)] = metric.Data
+		}
+	}
+
+	results := make([]thresholdResult, 0, len(thresholds))
+
+	for _, t := range thresholds {
+		actual, reason := thresholdActual(t, collected, elapsed)
+
+		results = append(results, thresholdResult{
+			threshold: t,
+			actual:    actual,
+			passed:    reason == "" && t.compare(actual),
+			reason:    reason,
+		})
+	}
+
+	return results
+}
+
+// thresholdActual resolves the metric a threshold names and computes its stat.
+// A Rate metric is found through its _events_total/_true_total pair, and a
+// counter may be named without its _total suffix.
+func thresholdActual(
+	t threshold,
+	collected map[string]metricdata.Aggregation,
+	elapsed time.Duration,
+) (float64, string) {
+	if data, ok := collected[t.metric]; ok {
+		return aggregationStat(t, data, elapsed)
+	}
+
+	if events, ok := collected[t.metric+rateEventsSuffix].(metricdata.Sum[float64]); ok {
+		if t.stat != statRate {
+			return 0, "rate metrics only support rate"
+		}
+
+		total := sumPoints(matchingPoints(events.DataPoints, t.tags))
+		if total == 0 {
+			return 0, "no data"
+		}
+
+		var hits float64
+		if matches, ok := collected[t.metric+rateTrueSuffix].(metricdata.Sum[float64]); ok {
+			hits = sumPoints(matchingPoints(matches.DataPoints, t.tags))
+		}
+
+		return hits / total, ""
+	}
+
+	if data, ok := collected[t.metric+counterSuffix]; ok {
+		return aggregationStat(t, data, elapsed)
+	}
+
+	return 0, "no data"
+}
+
+func aggregationStat(t threshold, data metricdata.Aggregation, elapsed time.Duration) (float64, string) {
+	switch aggregation := data.(type) {
+	case metricdata.Sum[float64]:
+		points := matchingPoints(aggregation.DataPoints, t.tags)
+		if len(points) == 0 {
+			return 0, "no data"
+		}
+
+		switch t.stat {
+		case statCount:
+			return sumPoints(points), ""
+		case statRate:
+			if elapsed <= 0 {
+				return 0, "no scenario time"
+			}
+
+			return sumPoints(points) / elapsed.Seconds(), ""
+		default:
+			return 0, "counters only support count and rate"
+		}
+	case metricdata.Gauge[float64]:
+		points := matchingPoints(aggregation.DataPoints, t.tags)
+		if len(points) == 0 {
+			return 0, "no data"
+		}
+
+		if t.stat != statValue {
+			return 0, "gauges only support value"
+		}
+
+		return sumGauge(points), ""
+	case metricdata.Histogram[float64]:
+		return histogramStat(t, matchingHistogramPoints(aggregation.DataPoints, t.tags))
+	default:
+		return 0, "unsupported metric type"
+	}
+}
+
+func histogramStat(t threshold, points []metricdata.HistogramDataPoint[float64]) (float64, string) {
+	snapshot := histogramSnapshot(points)
+	if snapshot.Count == 0 {
+		return 0, "no data"
+	}
+
+	switch t.stat {
+	case statCount:
+		return float64(snapshot.Count), ""
+	case statAvg:
+		return snapshot.Sum / float64(snapshot.Count), ""
+	case statMin, statMax:
+		return histogramExtremum(points, t.stat == statMax)
+	case statRate, statValue:
+		return 0, "trends only support count, avg, min, max, med and p(N)"
+	default:
+		return histogramQuantile(snapshot.Bounds, snapshot.Buckets, snapshot.Count, t.quantile), ""
+	}
+}
+
+func histogramExtremum(points []metricdata.HistogramDataPoint[float64], upper bool) (float64, string) {
+	var (
+		result float64
+		found  bool
+	)
+
+	for _, point := range points {
+		extremum := point.Min
+		if upper {
+			extremum = point.Max
+		}
+
+		value, defined := extremum.Value()
+		if !defined {
+			continue
+		}
+
+		if !found || (upper && value > result) || (!upper && value < result) {
+			result, found = value, true
+		}
+	}
+
+	if !found {
+		return 0, "no min/max recorded"
+	}
+
+	return result, ""
+}
+
+func matchingPoints(points []metricdata.DataPoint[float64], tags []attribute.KeyValue) []metricdata.DataPoint[float64] {
+	return slices.DeleteFunc(slices.Clone(points), func(point metricdata.DataPoint[float64]) bool {
+		return !hasTags(point.Attributes, tags)
+	})
+}
+
+func matchingHistogramPoints(
+	points []metricdata.HistogramDataPoint[float64],
+	tags []attribute.KeyValue,
+) []metricdata.HistogramDataPoint[float64] {
+	return slices.DeleteFunc(slices.Clone(points), func(point metricdata.HistogramDataPoint[float64]) bool {
+		return !hasTags(point.Attributes, tags)
+	})
+}
+
+func hasTags(attrs attribute.Set, tags []attribute.KeyValue) bool {
+	for _, tag := range tags {
+		if value, ok := attrs.Value(tag.Key); !ok || value.Emit() != tag.Value.AsString() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// printThresholds writes the pass/fail table and reports whether every
+// threshold passed.
+func printThresholds(out io.Writer, results []thresholdResult) bool {
+	width := 0
+	for _, result := range results {
+		width = max(width, len(result.threshold.expr))
+	}
+
+	passed := true
+
+	fmt.Fprintln(out, "\n=== thresholds ===")
+
+	for _, result := range results {
+		status := "PASS"
+		if !result.passed {
+			status, passed = "FAIL", false
+		}
+
+		actual := strconv.FormatFloat(result.actual, 'f', 3, 64)
+		if result.reason != "" {
+			actual = result.reason
+		}
+
+		fmt.Fprintf(out, "  %s  %-*s  actual=%s\n", status, width, result.threshold.expr, actual)
+	}
+
+	return passed
+}
@@ -0,0 +1,244 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+func TestParseThreshold(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		want threshold
+	}{
+		{
+			expr: "tx_total_duration{tx_name=new_order}.p95 < 50ms",
+			want: threshold{
+				metric: "tx_total_duration", tags: []attribute.KeyValue{attribute.String("tx_name", "new_order")},
+				stat: "p(95)", quantile: 0.95, op: "<", value: 50,
+			},
+		},
+		{
+			expr: "iteration_duration{scenario=oltp, step=}.p(99.9)<=1.5s",
+			want: threshold{
+				metric: "iteration_duration",
+				tags:   []attribute.KeyValue{attribute.String("scenario", "oltp"), attribute.String("step", "")},
+				stat:   "p(99.9)", quantile: 0.999, op: "<=", value: 1500,
+			},
+		},
+		{
+			expr: "errors.rate < 0.01",
+			want: threshold{metric: "errors", stat: "rate", op: "<", value: 0.01},
+		},
+		{
+			expr: "iterations.rate >= 1000",
+			want: threshold{metric: "iterations", stat: "rate", op: ">=", value: 1000},
+		},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			got, err := parseThreshold(tc.expr)
+			if err != nil {
+				t.Fatalf("parseThreshold() error = %v", err)
+			}
+
+			tc.want.expr = tc.expr
+			if got.metric != tc.want.metric || got.stat != tc.want.stat || got.op != tc.want.op ||
+				got.value != tc.want.value || math.Abs(got.quantile-tc.want.quantile) > 1e-12 ||
+				attribute.NewSet(got.tags...) != attribute.NewSet(tc.want.tags...) {
+				t.Fatalf("parseThreshold() = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseThresholdRejectsMalformed(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		want error
+	}{
+		{"iterations_total.rate", errThresholdSyntax},
+		{"iterations_total > 5", errThresholdSyntax},
+		{"tx_total_duration{tx_name}.p95 < 5", errThresholdSyntax},
+		{"tx_total_duration{tx_name=a.p95 < 5", errThresholdSyntax},
+		{"tx_total_duration.p101 < 5", errThresholdStat},
+		{"tx_total_duration.mean < 5", errThresholdStat},
+		{"tx_total_duration.p95 < fast", errThresholdValue},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			if _, err := parseThreshold(tc.expr); !errors.Is(err, tc.want) {
+				t.Fatalf("parseThreshold() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateThresholds(t *testing.T) {
+	newOrder := attribute.NewSet(attribute.String("tx_name", "new_order"))
+	payment := attribute.NewSet(attribute.String("tx_name", "payment"))
+
+	data := metricdata.ResourceMetrics{ScopeMetrics: []metricdata.ScopeMetrics{{Metrics: []metricdata.Metrics{
+		{Name: "stroppy_iterations_total", Data: metricdata.Sum[float64]{DataPoints: []metricdata.DataPoint[float64]{
+			{Value: 600, Attributes: newOrder}, {Value: 400, Attributes: payment},
+		}}},
+		{Name: "stroppy_errors_events_total", Data: metricdata.Sum[float64]{DataPoints: []metricdata.DataPoint[float64]{
+			{Value: 200},
+		}}},
+		{Name: "stroppy_errors_true_total", Data: metricdata.Sum[float64]{DataPoints: []metricdata.DataPoint[float64]{
+			{Value: 1},
+		}}},
+		{Name: "stroppy_vus_active", Data: metricdata.Gauge[float64]{DataPoints: []metricdata.DataPoint[float64]{
+			{Value: 0},
+		}}},
+		{Name: "stroppy_tx_total_duration", Data: metricdata.Histogram[float64]{
+			DataPoints: []metricdata.HistogramDataPoint[float64]{
+				{
+					Attributes: newOrder, Count: 10, Sum: 300, Bounds: []float64{10, 50, 100},
+					BucketCounts: []uint64{5, 4, 1, 0}, Max: metricdata.NewExtrema(80.0),
+				},
+				{Attributes: payment, Count: 10, Sum: 5000, Bounds: []float64{10, 50, 100}, BucketCounts: []uint64{0, 0, 0, 10}},
+			},
+		}},
+	}}}}
+
+	for _, tc := range []struct {
+		expr   string
+		actual float64
+		passed bool
+		reason string
+	}{
+		{expr: "iterations_total.rate > 50", actual: 100, passed: true},
+		{expr: "iterations.count == 1000", actual: 1000, passed: true},
+		{expr: "iterations_total{tx_name=payment}.count < 400", actual: 400},
+		{expr: "errors.rate < 0.01", actual: 0.005, passed: true},
+		{expr: "vus_active.value == 0", passed: true},
+		{expr: "tx_total_duration{tx_name=new_order}.p95 < 50ms", actual: 50},
+		{expr: "tx_total_duration{tx_name=new_order}.med <= 10", actual: 10, passed: true},
+		{expr: "tx_total_duration{tx_name=new_order}.avg < 50ms", actual: 30, passed: true},
+		{expr: "tx_total_duration{tx_name=new_order}.max < 100", actual: 80, passed: true},
+		{expr: "tx_total_duration.count == 20", actual: 20, passed: true},
+		{expr: "tx_total_duration{tx_name=delivery}.p95 < 1s", reason: "no data"},
+		{expr: "tx_total_duration.rate > 1", reason: "trends only support count, avg, min, max, med and p(N)"},
+		{expr: "missing.count > 0", reason: "no data"},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			parsed, err := parseThreshold(tc.expr)
+			if err != nil {
+				t.Fatalf("parseThreshold() error = %v", err)
+			}
+
+			result := evaluateThresholds([]threshold{parsed}, data, "stroppy_", 10*time.Second)[0]
+			if result.actual != tc.actual || result.passed != tc.passed || result.reason != tc.reason {
+				t.Fatalf("result = actual %v passed %v reason %q, want %v %v %q",
+					result.actual, result.passed, result.reason, tc.actual, tc.passed, tc.reason)
+			}
+		})
+	}
+}
+
+func TestPrintThresholdsReportsFailures(t *testing.T) {
+	var out strings.Builder
+
+	passed := printThresholds(&out, []thresholdResult{
+		{threshold: threshold{expr: "a.count > 1"}, actual: 2, passed: true},
+		{threshold: threshold{expr: "b.p(95) < 50"}, reason: "no data"},
+	})
+
+	if passed {
+		t.Fatal("printThresholds() = true with a failed threshold")
+	}
+
+	want := "  PASS  a.count > 1   actual=2.000\n  FAIL  b.p(95) < 50  actual=no data\n"
+	if got := out.String(); !strings.HasSuffix(got, want) {
+		t.Fatalf("table = %q, want suffix %q", got, want)
+	}
+}
+
+func TestRunFailsOnCrossedThreshold(t *testing.T) {
+	Register(func() Workload { return &thresholdTestWorkload{} })
+
+	run := func(thresholds ...string) error {
+		return Run(
+			context.Background(),
+			"test/thresholds",
+			map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP}},
+			nil,
+			ParamInputs{RunConfig: map[string]json.RawMessage{"iterations": json.RawMessage(`4`)}},
+			zap.NewNop(),
+			&MetricsConfig{Thresholds: thresholds},
+		)
+	}
+
+	if err := run("iterations_total.count == 4"); err != nil {
+		t.Fatalf("Run() with a passing threshold error = %v", err)
+	}
+
+	if err := run("iterations_total.count > 4"); !errors.Is(err, ErrThresholdsCrossed) {
+		t.Fatalf("Run() with a crossed threshold error = %v, want ErrThresholdsCrossed", err)
+	}
+
+	if err := run("iterations_total.count >"); !errors.Is(err, errThresholdSyntax) {
+		t.Fatalf("Run() with a malformed threshold error = %v, want syntax error", err)
+	}
+}
+
+func TestRunGatesOnIterationErrorRate(t *testing.T) {
+	Register(func() Workload { return &failingThresholdWorkload{} })
+
+	run := func(threshold string) error {
+		return Run(
+			context.Background(),
+			"test/thresholds-errors",
+			map[int]*stroppy.DriverConfig{0: {DriverType: stroppy.DriverConfig_DRIVER_TYPE_NOOP}},
+			nil,
+			ParamInputs{RunConfig: map[string]json.RawMessage{"iterations": json.RawMessage(`4`)}},
+			zap.NewNop(),
+			&MetricsConfig{Thresholds: []string{threshold}},
+		)
+	}
+
+	if err := run("errors.rate == 0.5"); err != nil {
+		t.Fatalf("Run() with every other iteration failing: error = %v, want errors.rate 0.5", err)
+	}
+
+	if err := run("errors.rate < 0.01"); !errors.Is(err, ErrThresholdsCrossed) {
+		t.Fatalf("Run() error = %v, want ErrThresholdsCrossed", err)
+	}
+}
+
+// failingThresholdWorkload fails every other iteration.
+type failingThresholdWorkload struct {
+	calls atomic.Int64
+}
+
+func (*failingThresholdWorkload) Name() string                        { return "test/thresholds-errors" }
+func (*failingThresholdWorkload) Define(*Def) error                   { return nil }
+func (*failingThresholdWorkload) Setup(context.Context, *Bench) error { return nil }
+
+func (w *failingThresholdWorkload) Iterate(context.Context, *Bench) error {
+	if w.calls.Add(1)%2 == 0 {
+		return errors.New("iteration failed")
+	}
+
+	return nil
+}
+
+func (*failingThresholdWorkload) Teardown(context.Context, *Bench) error { return nil }
+
+type thresholdTestWorkload struct{}
+
+func (*thresholdTestWorkload) Name() string                           { return "test/thresholds" }
+func (*thresholdTestWorkload) Define(*Def) error                      { return nil }
+func (*thresholdTestWorkload) Setup(context.Context, *Bench) error    { return nil }
+func (*thresholdTestWorkload) Iterate(context.Context, *Bench) error  { return nil }
+func (*thresholdTestWorkload) Teardown(context.Context, *Bench) error { return nil }
@@ -11,6 +11,10 @@ type VU struct {
 	root *RootState
 	vuid uint64
 
+	// scenario names the run's scenario for metric tags; empty when the run has
+	// a single implicit scenario.
+	scenario string
+
 	// initPhase mirrors k6's "vu.State() == nil" rule: a driver declared while
 	// true (Setup run) is shared across VUs; one declared during Iterate is per-VU.
 	initPhase bool
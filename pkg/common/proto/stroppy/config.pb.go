@@ -32,6 +32,8 @@ const (
 	DriverConfig_DRIVER_TYPE_YDB         DriverConfig_DriverType = 4
 	DriverConfig_DRIVER_TYPE_NOOP        DriverConfig_DriverType = 5
 	DriverConfig_DRIVER_TYPE_CSV         DriverConfig_DriverType = 6
+	DriverConfig_DRIVER_TYPE_SQLITE      DriverConfig_DriverType = 7
+	DriverConfig_DRIVER_TYPE_GENERIC_SQL DriverConfig_DriverType = 8
 )
 
 // Enum value maps for DriverConfig_DriverType.
@@ -44,6 +46,8 @@ var (
 		4: "DRIVER_TYPE_YDB",
 		5: "DRIVER_TYPE_NOOP",
 		6: "DRIVER_TYPE_CSV",
+		7: "DRIVER_TYPE_SQLITE",
+		8: "DRIVER_TYPE_GENERIC_SQL",
 	}
 	DriverConfig_DriverType_value = map[string]int32{
 		"DRIVER_TYPE_UNSPECIFIED": 0,
@@ -53,6 +57,8 @@ var (
 		"DRIVER_TYPE_YDB":         4,
 		"DRIVER_TYPE_NOOP":        5,
 		"DRIVER_TYPE_CSV":         6,
+		"DRIVER_TYPE_SQLITE":      7,
+		"DRIVER_TYPE_GENERIC_SQL": 8,
 	}
 )
 
@@ -281,8 +287,12 @@ type DriverConfig struct {
 	TlsInsecureSkipVerify *bool `protobuf:"varint,24,opt,name=tls_insecure_skip_verify,json=tlsInsecureSkipVerify,proto3,oneof" json:"tls_insecure_skip_verify,omitempty"`
 	// * Periodic InsertSpec progress reporting configuration.
 	InsertProgress *DriverConfig_InsertProgressConfig `protobuf:"bytes,25,opt,name=insert_progress,json=insertProgress,proto3,oneof" json:"insert_progress,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// * Dialect and error mapping for DRIVER_TYPE_GENERIC_SQL
+	GenericSql *DriverConfig_GenericSqlConfig `protobuf:"bytes,26,opt,name=generic_sql,json=genericSql,proto3,oneof" json:"generic_sql,omitempty"`
+	// * Call interceptors wrapped around the driver, first outermost
+	Interceptors  []*DriverConfig_Interceptor `protobuf:"bytes,27,rep,name=interceptors,proto3" json:"interceptors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DriverConfig) Reset() {
@@ -410,6 +420,20 @@ func (x *DriverConfig) GetInsertProgress() *DriverConfig_InsertProgressConfig {
 	return nil
 }
 
+func (x *DriverConfig) GetGenericSql() *DriverConfig_GenericSqlConfig {
+	if x != nil {
+		return x.GenericSql
+	}
+	return nil
+}
+
+func (x *DriverConfig) GetInterceptors() []*DriverConfig_Interceptor {
+	if x != nil {
+		return x.Interceptors
+	}
+	return nil
+}
+
 type isDriverConfig_DriverSpecific interface {
 	isDriverConfig_DriverSpecific()
 }
@@ -899,11 +923,805 @@ func (x *DriverConfig_InsertProgressConfig) GetMode() string {
 	return ""
 }
 
+// *
+// Dialect and error mapping of DRIVER_TYPE_GENERIC_SQL, which opens the
+// database/sql driver named by the URL scheme (<driver>://<dsn>).
+type DriverConfig_GenericSqlConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Bind placeholder style: question (?), dollar ($1), colon (:1), at_p (@p1). Default: question
+	Placeholder *string `protobuf:"bytes,1,opt,name=placeholder,proto3,oneof" json:"placeholder,omitempty"`
+	// * Bind a repeated named parameter once; needs a numbered placeholder style
+	Deduplicate *bool `protobuf:"varint,2,opt,name=deduplicate,proto3,oneof" json:"deduplicate,omitempty"`
+	// *
+	// Server-side timeout hint inserted after a leading SELECT; {ms} expands
+	// to the statement timeout in milliseconds, e.g. "/*+ MAX_EXECUTION_TIME({ms}) */"
+	TimeoutHint *string `protobuf:"bytes,3,opt,name=timeout_hint,json=timeoutHint,proto3,oneof" json:"timeout_hint,omitempty"`
+	// * Client deadline padding past a hinted statement timeout (e.g. "1s"). Default: 1s
+	TimeoutGrace *string `protobuf:"bytes,4,opt,name=timeout_grace,json=timeoutGrace,proto3,oneof" json:"timeout_grace,omitempty"`
+	// * UUID binding: string or bytes. Default: string
+	UuidAs *string `protobuf:"bytes,5,opt,name=uuid_as,json=uuidAs,proto3,oneof" json:"uuid_as,omitempty"`
+	// * Timestamp binding: time (driver-native), text (time_layout) or unix (seconds). Default: time
+	TimeAs *string `protobuf:"bytes,6,opt,name=time_as,json=timeAs,proto3,oneof" json:"time_as,omitempty"`
+	// * Go time layout for time_as=text. Default: 2006-01-02 15:04:05.999999
+	TimeLayout *string `protobuf:"bytes,7,opt,name=time_layout,json=timeLayout,proto3,oneof" json:"time_layout,omitempty"`
+	// * Decimal binding: string or float. Default: string
+	DecimalAs *string `protobuf:"bytes,8,opt,name=decimal_as,json=decimalAs,proto3,oneof" json:"decimal_as,omitempty"`
+	// * Boolean binding: bool or int (1/0). Default: bool
+	BoolAs *string `protobuf:"bytes,9,opt,name=bool_as,json=boolAs,proto3,oneof" json:"bool_as,omitempty"`
+	// * Bound parameters one statement may carry; caps the bulk INSERT batch. Default: 65535
+	MaxBindParams *int32 `protobuf:"varint,10,opt,name=max_bind_params,json=maxBindParams,proto3,oneof" json:"max_bind_params,omitempty"`
+	// * Error classification rules, first match wins
+	ErrorCodes    []*DriverConfig_GenericSqlConfig_ErrorCode `protobuf:"bytes,11,rep,name=error_codes,json=errorCodes,proto3" json:"error_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_GenericSqlConfig) Reset() {
+	*x = DriverConfig_GenericSqlConfig{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_GenericSqlConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_GenericSqlConfig) ProtoMessage() {}
+
+func (x *DriverConfig_GenericSqlConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_GenericSqlConfig.ProtoReflect.Descriptor instead.
+func (*DriverConfig_GenericSqlConfig) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetPlaceholder() string {
+	if x != nil && x.Placeholder != nil {
+		return *x.Placeholder
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetDeduplicate() bool {
+	if x != nil && x.Deduplicate != nil {
+		return *x.Deduplicate
+	}
+	return false
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetTimeoutHint() string {
+	if x != nil && x.TimeoutHint != nil {
+		return *x.TimeoutHint
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetTimeoutGrace() string {
+	if x != nil && x.TimeoutGrace != nil {
+		return *x.TimeoutGrace
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetUuidAs() string {
+	if x != nil && x.UuidAs != nil {
+		return *x.UuidAs
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetTimeAs() string {
+	if x != nil && x.TimeAs != nil {
+		return *x.TimeAs
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetTimeLayout() string {
+	if x != nil && x.TimeLayout != nil {
+		return *x.TimeLayout
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetDecimalAs() string {
+	if x != nil && x.DecimalAs != nil {
+		return *x.DecimalAs
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetBoolAs() string {
+	if x != nil && x.BoolAs != nil {
+		return *x.BoolAs
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetMaxBindParams() int32 {
+	if x != nil && x.MaxBindParams != nil {
+		return *x.MaxBindParams
+	}
+	return 0
+}
+
+func (x *DriverConfig_GenericSqlConfig) GetErrorCodes() []*DriverConfig_GenericSqlConfig_ErrorCode {
+	if x != nil {
+		return x.ErrorCodes
+	}
+	return nil
+}
+
+// *
+// Wraps the driver's RunQuery, Begin, Commit and Insert calls.
+// Interceptors apply in list order, the first one outermost.
+type DriverConfig_Interceptor struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*DriverConfig_Interceptor_SlowQuery_
+	//	*DriverConfig_Interceptor_SqlSample_
+	//	*DriverConfig_Interceptor_StatementCounter_
+	//	*DriverConfig_Interceptor_Chaos_
+	Kind          isDriverConfig_Interceptor_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor) Reset() {
+	*x = DriverConfig_Interceptor{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *DriverConfig_Interceptor) GetKind() isDriverConfig_Interceptor_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor) GetSlowQuery() *DriverConfig_Interceptor_SlowQuery {
+	if x != nil {
+		if x, ok := x.Kind.(*DriverConfig_Interceptor_SlowQuery_); ok {
+			return x.SlowQuery
+		}
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor) GetSqlSample() *DriverConfig_Interceptor_SqlSample {
+	if x != nil {
+		if x, ok := x.Kind.(*DriverConfig_Interceptor_SqlSample_); ok {
+			return x.SqlSample
+		}
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor) GetStatementCounter() *DriverConfig_Interceptor_StatementCounter {
+	if x != nil {
+		if x, ok := x.Kind.(*DriverConfig_Interceptor_StatementCounter_); ok {
+			return x.StatementCounter
+		}
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor) GetChaos() *DriverConfig_Interceptor_Chaos {
+	if x != nil {
+		if x, ok := x.Kind.(*DriverConfig_Interceptor_Chaos_); ok {
+			return x.Chaos
+		}
+	}
+	return nil
+}
+
+type isDriverConfig_Interceptor_Kind interface {
+	isDriverConfig_Interceptor_Kind()
+}
+
+type DriverConfig_Interceptor_SlowQuery_ struct {
+	// * Log statements slower than a threshold
+	SlowQuery *DriverConfig_Interceptor_SlowQuery `protobuf:"bytes,1,opt,name=slow_query,json=slowQuery,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_SqlSample_ struct {
+	// * Log the SQL text of a sample of each distinct statement
+	SqlSample *DriverConfig_Interceptor_SqlSample `protobuf:"bytes,2,opt,name=sql_sample,json=sqlSample,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_StatementCounter_ struct {
+	// * Count statements by normalized SQL fingerprint
+	StatementCounter *DriverConfig_Interceptor_StatementCounter `protobuf:"bytes,3,opt,name=statement_counter,json=statementCounter,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_Chaos_ struct {
+	// * Inject latency, errors, dropped connections and failed commits
+	Chaos *DriverConfig_Interceptor_Chaos `protobuf:"bytes,4,opt,name=chaos,proto3,oneof"`
+}
+
+func (*DriverConfig_Interceptor_SlowQuery_) isDriverConfig_Interceptor_Kind() {}
+
+func (*DriverConfig_Interceptor_SqlSample_) isDriverConfig_Interceptor_Kind() {}
+
+func (*DriverConfig_Interceptor_StatementCounter_) isDriverConfig_Interceptor_Kind() {}
+
+func (*DriverConfig_Interceptor_Chaos_) isDriverConfig_Interceptor_Kind() {}
+
+// * Maps a driver error to a database-independent error kind.
+type DriverConfig_GenericSqlConfig_ErrorCode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// *
+	// Error code matched against the error chain: SQLSTATE, vendor error
+	// number or result code, compared as text. Empty matches any code
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// * Substring the error text must contain. Empty matches any text
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// *
+	// Error kind: serialization, deadlock, lock_timeout, transient,
+	// unsupported, canceled or timeout
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// * Back off before retrying an error of this code
+	Backoff       bool `protobuf:"varint,4,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) Reset() {
+	*x = DriverConfig_GenericSqlConfig_ErrorCode{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_GenericSqlConfig_ErrorCode) ProtoMessage() {}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_GenericSqlConfig_ErrorCode.ProtoReflect.Descriptor instead.
+func (*DriverConfig_GenericSqlConfig_ErrorCode) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 3, 0}
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DriverConfig_GenericSqlConfig_ErrorCode) GetBackoff() bool {
+	if x != nil {
+		return x.Backoff
+	}
+	return false
+}
+
+type DriverConfig_Interceptor_SlowQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Statements at or above this duration are logged (e.g. "200ms"). Default: 1s
+	Threshold *string `protobuf:"bytes,1,opt,name=threshold,proto3,oneof" json:"threshold,omitempty"`
+	// * Include the bound arguments in the log line
+	LogArgs       *bool `protobuf:"varint,2,opt,name=log_args,json=logArgs,proto3,oneof" json:"log_args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_SlowQuery) Reset() {
+	*x = DriverConfig_Interceptor_SlowQuery{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_SlowQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_SlowQuery) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_SlowQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_SlowQuery.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_SlowQuery) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 0}
+}
+
+func (x *DriverConfig_Interceptor_SlowQuery) GetThreshold() string {
+	if x != nil && x.Threshold != nil {
+		return *x.Threshold
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_SlowQuery) GetLogArgs() bool {
+	if x != nil && x.LogArgs != nil {
+		return *x.LogArgs
+	}
+	return false
+}
+
+type DriverConfig_Interceptor_SqlSample struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Fraction of executions logged, 0..1. Default: 0.01
+	Rate *float64 `protobuf:"fixed64,1,opt,name=rate,proto3,oneof" json:"rate,omitempty"`
+	// * Samples logged per distinct statement, 0 = unlimited. Default: 10
+	MaxPerStatement *int32 `protobuf:"varint,2,opt,name=max_per_statement,json=maxPerStatement,proto3,oneof" json:"max_per_statement,omitempty"`
+	// * Include the bound arguments in the log line
+	LogArgs       *bool `protobuf:"varint,3,opt,name=log_args,json=logArgs,proto3,oneof" json:"log_args,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_SqlSample) Reset() {
+	*x = DriverConfig_Interceptor_SqlSample{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_SqlSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_SqlSample) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_SqlSample) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_SqlSample.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_SqlSample) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 1}
+}
+
+func (x *DriverConfig_Interceptor_SqlSample) GetRate() float64 {
+	if x != nil && x.Rate != nil {
+		return *x.Rate
+	}
+	return 0
+}
+
+func (x *DriverConfig_Interceptor_SqlSample) GetMaxPerStatement() int32 {
+	if x != nil && x.MaxPerStatement != nil {
+		return *x.MaxPerStatement
+	}
+	return 0
+}
+
+func (x *DriverConfig_Interceptor_SqlSample) GetLogArgs() bool {
+	if x != nil && x.LogArgs != nil {
+		return *x.LogArgs
+	}
+	return false
+}
+
+type DriverConfig_Interceptor_StatementCounter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Fingerprints logged at teardown, most executed first. Default: 20
+	Top           *int32 `protobuf:"varint,1,opt,name=top,proto3,oneof" json:"top,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_StatementCounter) Reset() {
+	*x = DriverConfig_Interceptor_StatementCounter{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_StatementCounter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_StatementCounter) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_StatementCounter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_StatementCounter.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_StatementCounter) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 2}
+}
+
+func (x *DriverConfig_Interceptor_StatementCounter) GetTop() int32 {
+	if x != nil && x.Top != nil {
+		return *x.Top
+	}
+	return 0
+}
+
+// *
+// Fault injection driven by rules. Every fault decision draws from one
+// generator seeded by seed, so a run with the same seed and the same
+// call sequence (e.g. one VU) injects the same faults.
+type DriverConfig_Interceptor_Chaos struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Seed of the fault generator. Default: random, logged at start
+	Seed *uint64 `protobuf:"varint,1,opt,name=seed,proto3,oneof" json:"seed,omitempty"`
+	// * Fault rules; every rule matching a call gets its own draw
+	Rules         []*DriverConfig_Interceptor_Chaos_Rule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_Chaos) Reset() {
+	*x = DriverConfig_Interceptor_Chaos{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_Chaos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_Chaos) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_Chaos) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_Chaos.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_Chaos) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 3}
+}
+
+func (x *DriverConfig_Interceptor_Chaos) GetSeed() uint64 {
+	if x != nil && x.Seed != nil {
+		return *x.Seed
+	}
+	return 0
+}
+
+func (x *DriverConfig_Interceptor_Chaos) GetRules() []*DriverConfig_Interceptor_Chaos_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DriverConfig_Interceptor_Chaos_Rule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Regular expression the statement SQL must match. Default: any statement
+	Pattern *string `protobuf:"bytes,1,opt,name=pattern,proto3,oneof" json:"pattern,omitempty"`
+	// *
+	// Tables the statement must reference after FROM, JOIN, INTO or UPDATE,
+	// case-insensitive. Default: any table
+	Tables []string `protobuf:"bytes,2,rep,name=tables,proto3" json:"tables,omitempty"`
+	// * Chance the fault fires on a matching call, 0..1. Default: 1
+	Probability *float64 `protobuf:"fixed64,3,opt,name=probability,proto3,oneof" json:"probability,omitempty"`
+	// Types that are valid to be assigned to Fault:
+	//
+	//	*DriverConfig_Interceptor_Chaos_Rule_Latency
+	//	*DriverConfig_Interceptor_Chaos_Rule_Error
+	//	*DriverConfig_Interceptor_Chaos_Rule_DropConnection
+	//	*DriverConfig_Interceptor_Chaos_Rule_FailCommit
+	Fault         isDriverConfig_Interceptor_Chaos_Rule_Fault `protobuf_oneof:"fault"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) Reset() {
+	*x = DriverConfig_Interceptor_Chaos_Rule{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_Chaos_Rule) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_Chaos_Rule.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_Chaos_Rule) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 3, 0}
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetPattern() string {
+	if x != nil && x.Pattern != nil {
+		return *x.Pattern
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetProbability() float64 {
+	if x != nil && x.Probability != nil {
+		return *x.Probability
+	}
+	return 0
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetFault() isDriverConfig_Interceptor_Chaos_Rule_Fault {
+	if x != nil {
+		return x.Fault
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetLatency() *DriverConfig_Interceptor_Chaos_Latency {
+	if x != nil {
+		if x, ok := x.Fault.(*DriverConfig_Interceptor_Chaos_Rule_Latency); ok {
+			return x.Latency
+		}
+	}
+	return nil
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetError() string {
+	if x != nil {
+		if x, ok := x.Fault.(*DriverConfig_Interceptor_Chaos_Rule_Error); ok {
+			return x.Error
+		}
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetDropConnection() bool {
+	if x != nil {
+		if x, ok := x.Fault.(*DriverConfig_Interceptor_Chaos_Rule_DropConnection); ok {
+			return x.DropConnection
+		}
+	}
+	return false
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Rule) GetFailCommit() string {
+	if x != nil {
+		if x, ok := x.Fault.(*DriverConfig_Interceptor_Chaos_Rule_FailCommit); ok {
+			return x.FailCommit
+		}
+	}
+	return ""
+}
+
+type isDriverConfig_Interceptor_Chaos_Rule_Fault interface {
+	isDriverConfig_Interceptor_Chaos_Rule_Fault()
+}
+
+type DriverConfig_Interceptor_Chaos_Rule_Latency struct {
+	// * Delay the statement before running it
+	Latency *DriverConfig_Interceptor_Chaos_Latency `protobuf:"bytes,4,opt,name=latency,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_Chaos_Rule_Error struct {
+	// *
+	// Fail the statement without running it, with an error ClassifyError maps
+	// to this kind: serialization, deadlock, lock_timeout, transient, timeout, ...
+	Error string `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_Chaos_Rule_DropConnection struct {
+	// *
+	// Roll back the surrounding transaction and fail the statement and every
+	// later call on the transaction with a transient error
+	DropConnection bool `protobuf:"varint,6,opt,name=drop_connection,json=dropConnection,proto3,oneof"`
+}
+
+type DriverConfig_Interceptor_Chaos_Rule_FailCommit struct {
+	// *
+	// Roll back instead of committing transactions that ran a matching
+	// statement, failing the commit with this error kind. Default: serialization
+	FailCommit string `protobuf:"bytes,7,opt,name=fail_commit,json=failCommit,proto3,oneof"`
+}
+
+func (*DriverConfig_Interceptor_Chaos_Rule_Latency) isDriverConfig_Interceptor_Chaos_Rule_Fault() {}
+
+func (*DriverConfig_Interceptor_Chaos_Rule_Error) isDriverConfig_Interceptor_Chaos_Rule_Fault() {}
+
+func (*DriverConfig_Interceptor_Chaos_Rule_DropConnection) isDriverConfig_Interceptor_Chaos_Rule_Fault() {
+}
+
+func (*DriverConfig_Interceptor_Chaos_Rule_FailCommit) isDriverConfig_Interceptor_Chaos_Rule_Fault() {
+}
+
+type DriverConfig_Interceptor_Chaos_Latency struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * constant, uniform, normal or exponential. Default: constant
+	Distribution *string `protobuf:"bytes,1,opt,name=distribution,proto3,oneof" json:"distribution,omitempty"`
+	// * Delay of constant, mean of normal and exponential (e.g. "20ms")
+	Mean *string `protobuf:"bytes,2,opt,name=mean,proto3,oneof" json:"mean,omitempty"`
+	// * Standard deviation of normal
+	Stddev *string `protobuf:"bytes,3,opt,name=stddev,proto3,oneof" json:"stddev,omitempty"`
+	// * Lower bound of uniform, floor of the other distributions
+	Min *string `protobuf:"bytes,4,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// * Upper bound of uniform, cap of the other distributions
+	Max           *string `protobuf:"bytes,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) Reset() {
+	*x = DriverConfig_Interceptor_Chaos_Latency{}
+	mi := &file_proto_stroppy_config_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverConfig_Interceptor_Chaos_Latency) ProtoMessage() {}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stroppy_config_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverConfig_Interceptor_Chaos_Latency.ProtoReflect.Descriptor instead.
+func (*DriverConfig_Interceptor_Chaos_Latency) Descriptor() ([]byte, []int) {
+	return file_proto_stroppy_config_proto_rawDescGZIP(), []int{0, 4, 3, 1}
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) GetDistribution() string {
+	if x != nil && x.Distribution != nil {
+		return *x.Distribution
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) GetMean() string {
+	if x != nil && x.Mean != nil {
+		return *x.Mean
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) GetStddev() string {
+	if x != nil && x.Stddev != nil {
+		return *x.Stddev
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) GetMin() string {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return ""
+}
+
+func (x *DriverConfig_Interceptor_Chaos_Latency) GetMax() string {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return ""
+}
+
 var File_proto_stroppy_config_proto protoreflect.FileDescriptor
 
 const file_proto_stroppy_config_proto_rawDesc = "" +
 	"\n" +
-	"\x1aproto/stroppy/config.proto\x12\astroppy\x1a\x1aproto/stroppy/common.proto\x1a\x17validate/validate.proto\"\xdc\x11\n" +
+	"\x1aproto/stroppy/config.proto\x12\astroppy\x1a\x1aproto/stroppy/common.proto\x1a\x17validate/validate.proto\"\xa9#\n" +
 	"\fDriverConfig\x12\x1a\n" +
 	"\x03url\x18\x01 \x01(\tB\b\xfaB\x05r\x03\x90\x01\x01R\x03url\x12K\n" +
 	"\vdriver_type\x18\x02 \x01(\x0e2 .stroppy.DriverConfig.DriverTypeB\b\xfaB\x05\x82\x01\x02\x10\x01R\n" +
@@ -921,7 +1739,10 @@ const file_proto_stroppy_config_proto_rawDesc = "" +
 	"\tauth_user\x18\x16 \x01(\tH\x04R\bauthUser\x88\x01\x01\x12(\n" +
 	"\rauth_password\x18\x17 \x01(\tH\x05R\fauthPassword\x88\x01\x01\x12<\n" +
 	"\x18tls_insecure_skip_verify\x18\x18 \x01(\bH\x06R\x15tlsInsecureSkipVerify\x88\x01\x01\x12X\n" +
-	"\x0finsert_progress\x18\x19 \x01(\v2*.stroppy.DriverConfig.InsertProgressConfigH\aR\x0einsertProgress\x88\x01\x01\x1a\x95\x05\n" +
+	"\x0finsert_progress\x18\x19 \x01(\v2*.stroppy.DriverConfig.InsertProgressConfigH\aR\x0einsertProgress\x88\x01\x01\x12L\n" +
+	"\vgeneric_sql\x18\x1a \x01(\v2&.stroppy.DriverConfig.GenericSqlConfigH\bR\n" +
+	"genericSql\x88\x01\x01\x12E\n" +
+	"\finterceptors\x18\x1b \x03(\v2!.stroppy.DriverConfig.InterceptorR\finterceptors\x1a\x95\x05\n" +
 	"\x0ePostgresConfig\x12+\n" +
 	"\x0ftrace_log_level\x18\x01 \x01(\tH\x00R\rtraceLogLevel\x88\x01\x01\x12/\n" +
 	"\x11max_conn_lifetime\x18\x02 \x01(\tH\x01R\x0fmaxConnLifetime\x88\x01\x01\x120\n" +
@@ -962,7 +1783,94 @@ const file_proto_stroppy_config_proto_rawDesc = "" +
 	"\b_enabledB\v\n" +
 	"\t_intervalB\x0e\n" +
 	"\f_stall_afterB\a\n" +
-	"\x05_mode\"\xb4\x01\n" +
+	"\x05_mode\x1a\xd9\x05\n" +
+	"\x10GenericSqlConfig\x12%\n" +
+	"\vplaceholder\x18\x01 \x01(\tH\x00R\vplaceholder\x88\x01\x01\x12%\n" +
+	"\vdeduplicate\x18\x02 \x01(\bH\x01R\vdeduplicate\x88\x01\x01\x12&\n" +
+	"\ftimeout_hint\x18\x03 \x01(\tH\x02R\vtimeoutHint\x88\x01\x01\x12(\n" +
+	"\rtimeout_grace\x18\x04 \x01(\tH\x03R\ftimeoutGrace\x88\x01\x01\x12\x1c\n" +
+	"\auuid_as\x18\x05 \x01(\tH\x04R\x06uuidAs\x88\x01\x01\x12\x1c\n" +
+	"\atime_as\x18\x06 \x01(\tH\x05R\x06timeAs\x88\x01\x01\x12$\n" +
+	"\vtime_layout\x18\a \x01(\tH\x06R\n" +
+	"timeLayout\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"decimal_as\x18\b \x01(\tH\aR\tdecimalAs\x88\x01\x01\x12\x1c\n" +
+	"\abool_as\x18\t \x01(\tH\bR\x06boolAs\x88\x01\x01\x12+\n" +
+	"\x0fmax_bind_params\x18\n" +
+	" \x01(\x05H\tR\rmaxBindParams\x88\x01\x01\x12Q\n" +
+	"\verror_codes\x18\v \x03(\v20.stroppy.DriverConfig.GenericSqlConfig.ErrorCodeR\n" +
+	"errorCodes\x1ag\n" +
+	"\tErrorCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x18\n" +
+	"\abackoff\x18\x04 \x01(\bR\abackoffB\x0e\n" +
+	"\f_placeholderB\x0e\n" +
+	"\f_deduplicateB\x0f\n" +
+	"\r_timeout_hintB\x10\n" +
+	"\x0e_timeout_graceB\n" +
+	"\n" +
+	"\b_uuid_asB\n" +
+	"\n" +
+	"\b_time_asB\x0e\n" +
+	"\f_time_layoutB\r\n" +
+	"\v_decimal_asB\n" +
+	"\n" +
+	"\b_bool_asB\x12\n" +
+	"\x10_max_bind_params\x1a\x94\n" +
+	"\n" +
+	"\vInterceptor\x12L\n" +
+	"\n" +
+	"slow_query\x18\x01 \x01(\v2+.stroppy.DriverConfig.Interceptor.SlowQueryH\x00R\tslowQuery\x12L\n" +
+	"\n" +
+	"sql_sample\x18\x02 \x01(\v2+.stroppy.DriverConfig.Interceptor.SqlSampleH\x00R\tsqlSample\x12a\n" +
+	"\x11statement_counter\x18\x03 \x01(\v22.stroppy.DriverConfig.Interceptor.StatementCounterH\x00R\x10statementCounter\x12?\n" +
+	"\x05chaos\x18\x04 \x01(\v2'.stroppy.DriverConfig.Interceptor.ChaosH\x00R\x05chaos\x1ai\n" +
+	"\tSlowQuery\x12!\n" +
+	"\tthreshold\x18\x01 \x01(\tH\x00R\tthreshold\x88\x01\x01\x12\x1e\n" +
+	"\blog_args\x18\x02 \x01(\bH\x01R\alogArgs\x88\x01\x01B\f\n" +
+	"\n" +
+	"_thresholdB\v\n" +
+	"\t_log_args\x1a\xa1\x01\n" +
+	"\tSqlSample\x12\x17\n" +
+	"\x04rate\x18\x01 \x01(\x01H\x00R\x04rate\x88\x01\x01\x12/\n" +
+	"\x11max_per_statement\x18\x02 \x01(\x05H\x01R\x0fmaxPerStatement\x88\x01\x01\x12\x1e\n" +
+	"\blog_args\x18\x03 \x01(\bH\x02R\alogArgs\x88\x01\x01B\a\n" +
+	"\x05_rateB\x14\n" +
+	"\x12_max_per_statementB\v\n" +
+	"\t_log_args\x1a1\n" +
+	"\x10StatementCounter\x12\x15\n" +
+	"\x03top\x18\x01 \x01(\x05H\x00R\x03top\x88\x01\x01B\x06\n" +
+	"\x04_top\x1a\xfa\x04\n" +
+	"\x05Chaos\x12\x17\n" +
+	"\x04seed\x18\x01 \x01(\x04H\x00R\x04seed\x88\x01\x01\x12B\n" +
+	"\x05rules\x18\x02 \x03(\v2,.stroppy.DriverConfig.Interceptor.Chaos.RuleR\x05rules\x1a\xbc\x02\n" +
+	"\x04Rule\x12\x1d\n" +
+	"\apattern\x18\x01 \x01(\tH\x01R\apattern\x88\x01\x01\x12\x16\n" +
+	"\x06tables\x18\x02 \x03(\tR\x06tables\x12%\n" +
+	"\vprobability\x18\x03 \x01(\x01H\x02R\vprobability\x88\x01\x01\x12K\n" +
+	"\alatency\x18\x04 \x01(\v2/.stroppy.DriverConfig.Interceptor.Chaos.LatencyH\x00R\alatency\x12\x16\n" +
+	"\x05error\x18\x05 \x01(\tH\x00R\x05error\x12)\n" +
+	"\x0fdrop_connection\x18\x06 \x01(\bH\x00R\x0edropConnection\x12!\n" +
+	"\vfail_commit\x18\a \x01(\tH\x00R\n" +
+	"failCommitB\a\n" +
+	"\x05faultB\n" +
+	"\n" +
+	"\b_patternB\x0e\n" +
+	"\f_probability\x1a\xcb\x01\n" +
+	"\aLatency\x12'\n" +
+	"\fdistribution\x18\x01 \x01(\tH\x00R\fdistribution\x88\x01\x01\x12\x17\n" +
+	"\x04mean\x18\x02 \x01(\tH\x01R\x04mean\x88\x01\x01\x12\x1b\n" +
+	"\x06stddev\x18\x03 \x01(\tH\x02R\x06stddev\x88\x01\x01\x12\x15\n" +
+	"\x03min\x18\x04 \x01(\tH\x03R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x05 \x01(\tH\x04R\x03max\x88\x01\x01B\x0f\n" +
+	"\r_distributionB\a\n" +
+	"\x05_meanB\t\n" +
+	"\a_stddevB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_maxB\a\n" +
+	"\x05_seedB\x06\n" +
+	"\x04kind\"\xe9\x01\n" +
 	"\n" +
 	"DriverType\x12\x1b\n" +
 	"\x17DRIVER_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
@@ -971,7 +1879,9 @@ const file_proto_stroppy_config_proto_rawDesc = "" +
 	"\x14DRIVER_TYPE_PICODATA\x10\x03\x12\x13\n" +
 	"\x0fDRIVER_TYPE_YDB\x10\x04\x12\x14\n" +
 	"\x10DRIVER_TYPE_NOOP\x10\x05\x12\x13\n" +
-	"\x0fDRIVER_TYPE_CSV\x10\x06\"\x93\x01\n" +
+	"\x0fDRIVER_TYPE_CSV\x10\x06\x12\x16\n" +
+	"\x12DRIVER_TYPE_SQLITE\x10\a\x12\x1b\n" +
+	"\x17DRIVER_TYPE_GENERIC_SQL\x10\b\"\x93\x01\n" +
 	"\tErrorMode\x12\x1a\n" +
 	"\x16ERROR_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11ERROR_MODE_SILENT\x10\x01\x12\x12\n" +
@@ -988,7 +1898,8 @@ const file_proto_stroppy_config_proto_rawDesc = "" +
 	"_auth_userB\x10\n" +
 	"\x0e_auth_passwordB\x1b\n" +
 	"\x19_tls_insecure_skip_verifyB\x12\n" +
-	"\x10_insert_progress\"\xca\x02\n" +
+	"\x10_insert_progressB\x0e\n" +
+	"\f_generic_sql\"\xca\x02\n" +
 	"\fLoggerConfig\x12E\n" +
 	"\tlog_level\x18\x01 \x01(\x0e2\x1e.stroppy.LoggerConfig.LogLevelB\b\xfaB\x05\x82\x01\x02\x10\x01R\blogLevel\x12B\n" +
 	"\blog_mode\x18\x02 \x01(\x0e2\x1d.stroppy.LoggerConfig.LogModeB\b\xfaB\x05\x82\x01\x02\x10\x01R\alogMode\"q\n" +
@@ -1029,21 +1940,30 @@ func file_proto_stroppy_config_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_stroppy_config_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_proto_stroppy_config_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_stroppy_config_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_stroppy_config_proto_goTypes = []any{
-	(DriverConfig_DriverType)(0),              // 0: stroppy.DriverConfig.DriverType
-	(DriverConfig_ErrorMode)(0),               // 1: stroppy.DriverConfig.ErrorMode
-	(LoggerConfig_LogLevel)(0),                // 2: stroppy.LoggerConfig.LogLevel
-	(LoggerConfig_LogMode)(0),                 // 3: stroppy.LoggerConfig.LogMode
-	(*DriverConfig)(nil),                      // 4: stroppy.DriverConfig
-	(*LoggerConfig)(nil),                      // 5: stroppy.LoggerConfig
-	(*ExporterConfig)(nil),                    // 6: stroppy.ExporterConfig
-	(*GlobalConfig)(nil),                      // 7: stroppy.GlobalConfig
-	(*DriverConfig_PostgresConfig)(nil),       // 8: stroppy.DriverConfig.PostgresConfig
-	(*DriverConfig_SqlConfig)(nil),            // 9: stroppy.DriverConfig.SqlConfig
-	(*DriverConfig_InsertProgressConfig)(nil), // 10: stroppy.DriverConfig.InsertProgressConfig
-	nil,                // 11: stroppy.GlobalConfig.MetadataEntry
-	(*OtlpExport)(nil), // 12: stroppy.OtlpExport
+	(DriverConfig_DriverType)(0),                      // 0: stroppy.DriverConfig.DriverType
+	(DriverConfig_ErrorMode)(0),                       // 1: stroppy.DriverConfig.ErrorMode
+	(LoggerConfig_LogLevel)(0),                        // 2: stroppy.LoggerConfig.LogLevel
+	(LoggerConfig_LogMode)(0),                         // 3: stroppy.LoggerConfig.LogMode
+	(*DriverConfig)(nil),                              // 4: stroppy.DriverConfig
+	(*LoggerConfig)(nil),                              // 5: stroppy.LoggerConfig
+	(*ExporterConfig)(nil),                            // 6: stroppy.ExporterConfig
+	(*GlobalConfig)(nil),                              // 7: stroppy.GlobalConfig
+	(*DriverConfig_PostgresConfig)(nil),               // 8: stroppy.DriverConfig.PostgresConfig
+	(*DriverConfig_SqlConfig)(nil),                    // 9: stroppy.DriverConfig.SqlConfig
+	(*DriverConfig_InsertProgressConfig)(nil),         // 10: stroppy.DriverConfig.InsertProgressConfig
+	(*DriverConfig_GenericSqlConfig)(nil),             // 11: stroppy.DriverConfig.GenericSqlConfig
+	(*DriverConfig_Interceptor)(nil),                  // 12: stroppy.DriverConfig.Interceptor
+	(*DriverConfig_GenericSqlConfig_ErrorCode)(nil),   // 13: stroppy.DriverConfig.GenericSqlConfig.ErrorCode
+	(*DriverConfig_Interceptor_SlowQuery)(nil),        // 14: stroppy.DriverConfig.Interceptor.SlowQuery
+	(*DriverConfig_Interceptor_SqlSample)(nil),        // 15: stroppy.DriverConfig.Interceptor.SqlSample
+	(*DriverConfig_Interceptor_StatementCounter)(nil), // 16: stroppy.DriverConfig.Interceptor.StatementCounter
+	(*DriverConfig_Interceptor_Chaos)(nil),            // 17: stroppy.DriverConfig.Interceptor.Chaos
+	(*DriverConfig_Interceptor_Chaos_Rule)(nil),       // 18: stroppy.DriverConfig.Interceptor.Chaos.Rule
+	(*DriverConfig_Interceptor_Chaos_Latency)(nil),    // 19: stroppy.DriverConfig.Interceptor.Chaos.Latency
+	nil,                // 20: stroppy.GlobalConfig.MetadataEntry
+	(*OtlpExport)(nil), // 21: stroppy.OtlpExport
 }
 var file_proto_stroppy_config_proto_depIdxs = []int32{
 	0,  // 0: stroppy.DriverConfig.driver_type:type_name -> stroppy.DriverConfig.DriverType
@@ -1051,17 +1971,26 @@ var file_proto_stroppy_config_proto_depIdxs = []int32{
 	8,  // 2: stroppy.DriverConfig.postgres:type_name -> stroppy.DriverConfig.PostgresConfig
 	9,  // 3: stroppy.DriverConfig.sql:type_name -> stroppy.DriverConfig.SqlConfig
 	10, // 4: stroppy.DriverConfig.insert_progress:type_name -> stroppy.DriverConfig.InsertProgressConfig
-	2,  // 5: stroppy.LoggerConfig.log_level:type_name -> stroppy.LoggerConfig.LogLevel
-	3,  // 6: stroppy.LoggerConfig.log_mode:type_name -> stroppy.LoggerConfig.LogMode
-	12, // 7: stroppy.ExporterConfig.otlp_export:type_name -> stroppy.OtlpExport
-	11, // 8: stroppy.GlobalConfig.metadata:type_name -> stroppy.GlobalConfig.MetadataEntry
-	5,  // 9: stroppy.GlobalConfig.logger:type_name -> stroppy.LoggerConfig
-	6,  // 10: stroppy.GlobalConfig.exporter:type_name -> stroppy.ExporterConfig
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	11, // 5: stroppy.DriverConfig.generic_sql:type_name -> stroppy.DriverConfig.GenericSqlConfig
+	12, // 6: stroppy.DriverConfig.interceptors:type_name -> stroppy.DriverConfig.Interceptor
+	2,  // 7: stroppy.LoggerConfig.log_level:type_name -> stroppy.LoggerConfig.LogLevel
+	3,  // 8: stroppy.LoggerConfig.log_mode:type_name -> stroppy.LoggerConfig.LogMode
+	21, // 9: stroppy.ExporterConfig.otlp_export:type_name -> stroppy.OtlpExport
+	20, // 10: stroppy.GlobalConfig.metadata:type_name -> stroppy.GlobalConfig.MetadataEntry
+	5,  // 11: stroppy.GlobalConfig.logger:type_name -> stroppy.LoggerConfig
+	6,  // 12: stroppy.GlobalConfig.exporter:type_name -> stroppy.ExporterConfig
+	13, // 13: stroppy.DriverConfig.GenericSqlConfig.error_codes:type_name -> stroppy.DriverConfig.GenericSqlConfig.ErrorCode
+	14, // 14: stroppy.DriverConfig.Interceptor.slow_query:type_name -> stroppy.DriverConfig.Interceptor.SlowQuery
+	15, // 15: stroppy.DriverConfig.Interceptor.sql_sample:type_name -> stroppy.DriverConfig.Interceptor.SqlSample
+	16, // 16: stroppy.DriverConfig.Interceptor.statement_counter:type_name -> stroppy.DriverConfig.Interceptor.StatementCounter
+	17, // 17: stroppy.DriverConfig.Interceptor.chaos:type_name -> stroppy.DriverConfig.Interceptor.Chaos
+	18, // 18: stroppy.DriverConfig.Interceptor.Chaos.rules:type_name -> stroppy.DriverConfig.Interceptor.Chaos.Rule
+	19, // 19: stroppy.DriverConfig.Interceptor.Chaos.Rule.latency:type_name -> stroppy.DriverConfig.Interceptor.Chaos.Latency
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_proto_stroppy_config_proto_init() }
@@ -1077,13 +2006,31 @@ func file_proto_stroppy_config_proto_init() {
 	file_proto_stroppy_config_proto_msgTypes[4].OneofWrappers = []any{}
 	file_proto_stroppy_config_proto_msgTypes[5].OneofWrappers = []any{}
 	file_proto_stroppy_config_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[8].OneofWrappers = []any{
+		(*DriverConfig_Interceptor_SlowQuery_)(nil),
+		(*DriverConfig_Interceptor_SqlSample_)(nil),
+		(*DriverConfig_Interceptor_StatementCounter_)(nil),
+		(*DriverConfig_Interceptor_Chaos_)(nil),
+	}
+	file_proto_stroppy_config_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_stroppy_config_proto_msgTypes[14].OneofWrappers = []any{
+		(*DriverConfig_Interceptor_Chaos_Rule_Latency)(nil),
+		(*DriverConfig_Interceptor_Chaos_Rule_Error)(nil),
+		(*DriverConfig_Interceptor_Chaos_Rule_DropConnection)(nil),
+		(*DriverConfig_Interceptor_Chaos_Rule_FailCommit)(nil),
+	}
+	file_proto_stroppy_config_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stroppy_config_proto_rawDesc), len(file_proto_stroppy_config_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   8,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
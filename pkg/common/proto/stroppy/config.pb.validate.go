@@ -82,6 +82,40 @@ func (m *DriverConfig) validate(all bool) error {
 
 	// no validation rules for ErrorMode
 
+	for idx, item := range m.GetInterceptors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfigValidationError{
+						field:  fmt.Sprintf("Interceptors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfigValidationError{
+						field:  fmt.Sprintf("Interceptors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfigValidationError{
+					field:  fmt.Sprintf("Interceptors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	switch v := m.DriverSpecific.(type) {
 	case *DriverConfig_Postgres:
 		if v == nil {
@@ -226,6 +260,39 @@ func (m *DriverConfig) validate(all bool) error {
 
 	}
 
+	if m.GenericSql != nil {
+
+		if all {
+			switch v := interface{}(m.GetGenericSql()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfigValidationError{
+						field:  "GenericSql",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfigValidationError{
+						field:  "GenericSql",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGenericSql()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfigValidationError{
+					field:  "GenericSql",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return DriverConfigMultiError(errors)
 	}
@@ -1126,3 +1193,1368 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = DriverConfig_InsertProgressConfigValidationError{}
+
+// Validate checks the field values on DriverConfig_GenericSqlConfig with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *DriverConfig_GenericSqlConfig) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_GenericSqlConfig with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// DriverConfig_GenericSqlConfigMultiError, or nil if none found.
+func (m *DriverConfig_GenericSqlConfig) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_GenericSqlConfig) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Placeholder != nil {
+		// no validation rules for Placeholder
+	}
+
+	if m.Deduplicate != nil {
+		// no validation rules for Deduplicate
+	}
+
+	if m.TimeoutHint != nil {
+		// no validation rules for TimeoutHint
+	}
+
+	if m.TimeoutGrace != nil {
+		// no validation rules for TimeoutGrace
+	}
+
+	if m.UuidAs != nil {
+		// no validation rules for UuidAs
+	}
+
+	if m.TimeAs != nil {
+		// no validation rules for TimeAs
+	}
+
+	if m.TimeLayout != nil {
+		// no validation rules for TimeLayout
+	}
+
+	if m.DecimalAs != nil {
+		// no validation rules for DecimalAs
+	}
+
+	if m.BoolAs != nil {
+		// no validation rules for BoolAs
+	}
+
+	if m.MaxBindParams != nil {
+		// no validation rules for MaxBindParams
+	}
+
+	for idx, item := range m.GetErrorCodes() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_GenericSqlConfigValidationError{
+						field:  fmt.Sprintf("ErrorCodes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_GenericSqlConfigValidationError{
+						field:  fmt.Sprintf("ErrorCodes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_GenericSqlConfigValidationError{
+					field:  fmt.Sprintf("ErrorCodes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_GenericSqlConfigMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_GenericSqlConfigMultiError is an error wrapping multiple
+// validation errors returned by DriverConfig_GenericSqlConfig.ValidateAll()
+// if the designated constraints aren't met.
+type DriverConfig_GenericSqlConfigMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_GenericSqlConfigMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_GenericSqlConfigMultiError) AllErrors() []error { return m }
+
+// DriverConfig_GenericSqlConfigValidationError is the validation error
+// returned by DriverConfig_GenericSqlConfig.Validate if the designated
+// constraints aren't met.
+type DriverConfig_GenericSqlConfigValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_GenericSqlConfigValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_GenericSqlConfigValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_GenericSqlConfigValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_GenericSqlConfigValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_GenericSqlConfigValidationError) ErrorName() string {
+	return "DriverConfig_GenericSqlConfigValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_GenericSqlConfigValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_GenericSqlConfig.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_GenericSqlConfigValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_GenericSqlConfigValidationError{}
+
+// Validate checks the field values on DriverConfig_GenericSqlConfig_ErrorCode
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *DriverConfig_GenericSqlConfig_ErrorCode) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// DriverConfig_GenericSqlConfig_ErrorCode with the rules defined in the proto
+// definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in
+// DriverConfig_GenericSqlConfig_ErrorCodeMultiError, or nil if none found.
+func (m *DriverConfig_GenericSqlConfig_ErrorCode) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_GenericSqlConfig_ErrorCode) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Code
+
+	// no validation rules for Message
+
+	// no validation rules for Kind
+
+	// no validation rules for Backoff
+
+	if len(errors) > 0 {
+		return DriverConfig_GenericSqlConfig_ErrorCodeMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_GenericSqlConfig_ErrorCodeMultiError is an error wrapping
+// multiple validation errors returned by
+// DriverConfig_GenericSqlConfig_ErrorCode.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_GenericSqlConfig_ErrorCodeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_GenericSqlConfig_ErrorCodeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_GenericSqlConfig_ErrorCodeMultiError) AllErrors() []error { return m }
+
+// DriverConfig_GenericSqlConfig_ErrorCodeValidationError is the validation
+// error returned by DriverConfig_GenericSqlConfig_ErrorCode.Validate if the
+// designated constraints aren't met.
+type DriverConfig_GenericSqlConfig_ErrorCodeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) ErrorName() string {
+	return "DriverConfig_GenericSqlConfig_ErrorCodeValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_GenericSqlConfig_ErrorCodeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_GenericSqlConfig_ErrorCode.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_GenericSqlConfig_ErrorCodeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_GenericSqlConfig_ErrorCodeValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *DriverConfig_Interceptor) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_Interceptor with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DriverConfig_InterceptorMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Kind.(type) {
+	case *DriverConfig_Interceptor_SlowQuery_:
+		if v == nil {
+			err := DriverConfig_InterceptorValidationError{
+				field:  "Kind",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetSlowQuery()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "SlowQuery",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "SlowQuery",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSlowQuery()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_InterceptorValidationError{
+					field:  "SlowQuery",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DriverConfig_Interceptor_SqlSample_:
+		if v == nil {
+			err := DriverConfig_InterceptorValidationError{
+				field:  "Kind",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetSqlSample()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "SqlSample",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "SqlSample",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSqlSample()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_InterceptorValidationError{
+					field:  "SqlSample",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DriverConfig_Interceptor_StatementCounter_:
+		if v == nil {
+			err := DriverConfig_InterceptorValidationError{
+				field:  "Kind",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStatementCounter()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "StatementCounter",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "StatementCounter",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetStatementCounter()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_InterceptorValidationError{
+					field:  "StatementCounter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DriverConfig_Interceptor_Chaos_:
+		if v == nil {
+			err := DriverConfig_InterceptorValidationError{
+				field:  "Kind",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetChaos()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "Chaos",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_InterceptorValidationError{
+						field:  "Chaos",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetChaos()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_InterceptorValidationError{
+					field:  "Chaos",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if len(errors) > 0 {
+		return DriverConfig_InterceptorMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_InterceptorMultiError is an error wrapping multiple validation
+// errors returned by DriverConfig_Interceptor.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_InterceptorMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_InterceptorMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_InterceptorMultiError) AllErrors() []error { return m }
+
+// DriverConfig_InterceptorValidationError is the validation error returned by
+// DriverConfig_Interceptor.Validate if the designated constraints aren't met.
+type DriverConfig_InterceptorValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_InterceptorValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_InterceptorValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_InterceptorValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_InterceptorValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_InterceptorValidationError) ErrorName() string {
+	return "DriverConfig_InterceptorValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_InterceptorValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_InterceptorValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_InterceptorValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor_SlowQuery with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *DriverConfig_Interceptor_SlowQuery) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_Interceptor_SlowQuery
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// DriverConfig_Interceptor_SlowQueryMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_SlowQuery) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_SlowQuery) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Threshold != nil {
+		// no validation rules for Threshold
+	}
+
+	if m.LogArgs != nil {
+		// no validation rules for LogArgs
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_SlowQueryMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_SlowQueryMultiError is an error wrapping multiple
+// validation errors returned by
+// DriverConfig_Interceptor_SlowQuery.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_SlowQueryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_SlowQueryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_SlowQueryMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_SlowQueryValidationError is the validation error
+// returned by DriverConfig_Interceptor_SlowQuery.Validate if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_SlowQueryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_SlowQueryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_SlowQueryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_SlowQueryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_SlowQueryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_SlowQueryValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_SlowQueryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_SlowQueryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_SlowQuery.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_SlowQueryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_SlowQueryValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor_SqlSample with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the first error encountered is returned, or nil if there are
+// no violations.
+func (m *DriverConfig_Interceptor_SqlSample) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_Interceptor_SqlSample
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// DriverConfig_Interceptor_SqlSampleMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_SqlSample) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_SqlSample) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Rate != nil {
+		// no validation rules for Rate
+	}
+
+	if m.MaxPerStatement != nil {
+		// no validation rules for MaxPerStatement
+	}
+
+	if m.LogArgs != nil {
+		// no validation rules for LogArgs
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_SqlSampleMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_SqlSampleMultiError is an error wrapping multiple
+// validation errors returned by
+// DriverConfig_Interceptor_SqlSample.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_SqlSampleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_SqlSampleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_SqlSampleMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_SqlSampleValidationError is the validation error
+// returned by DriverConfig_Interceptor_SqlSample.Validate if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_SqlSampleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_SqlSampleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_SqlSampleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_SqlSampleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_SqlSampleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_SqlSampleValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_SqlSampleValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_SqlSampleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_SqlSample.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_SqlSampleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_SqlSampleValidationError{}
+
+// Validate checks the field values on
+// DriverConfig_Interceptor_StatementCounter with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DriverConfig_Interceptor_StatementCounter) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// DriverConfig_Interceptor_StatementCounter with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in
+// DriverConfig_Interceptor_StatementCounterMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_StatementCounter) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_StatementCounter) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Top != nil {
+		// no validation rules for Top
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_StatementCounterMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_StatementCounterMultiError is an error wrapping
+// multiple validation errors returned by
+// DriverConfig_Interceptor_StatementCounter.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_StatementCounterMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_StatementCounterMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_StatementCounterMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_StatementCounterValidationError is the validation
+// error returned by DriverConfig_Interceptor_StatementCounter.Validate if the
+// designated constraints aren't met.
+type DriverConfig_Interceptor_StatementCounterValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_StatementCounterValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_StatementCounterValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_StatementCounterValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_StatementCounterValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_StatementCounterValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_StatementCounterValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_StatementCounterValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_StatementCounter.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_StatementCounterValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_StatementCounterValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor_Chaos with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no
+// violations.
+func (m *DriverConfig_Interceptor_Chaos) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_Interceptor_Chaos with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// DriverConfig_Interceptor_ChaosMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_Chaos) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_Chaos) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetRules() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_Interceptor_ChaosValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_Interceptor_ChaosValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_Interceptor_ChaosValidationError{
+					field:  fmt.Sprintf("Rules[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Seed != nil {
+		// no validation rules for Seed
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_ChaosMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_ChaosMultiError is an error wrapping multiple
+// validation errors returned by DriverConfig_Interceptor_Chaos.ValidateAll()
+// if the designated constraints aren't met.
+type DriverConfig_Interceptor_ChaosMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_ChaosMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_ChaosMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_ChaosValidationError is the validation error
+// returned by DriverConfig_Interceptor_Chaos.Validate if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_ChaosValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_ChaosValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_ChaosValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_ChaosValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_ChaosValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_ChaosValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_ChaosValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_ChaosValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_Chaos.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_ChaosValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_ChaosValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor_Chaos_Rule
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *DriverConfig_Interceptor_Chaos_Rule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DriverConfig_Interceptor_Chaos_Rule
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// DriverConfig_Interceptor_Chaos_RuleMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_Chaos_Rule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_Chaos_Rule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Fault.(type) {
+	case *DriverConfig_Interceptor_Chaos_Rule_Latency:
+		if v == nil {
+			err := DriverConfig_Interceptor_Chaos_RuleValidationError{
+				field:  "Fault",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetLatency()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverConfig_Interceptor_Chaos_RuleValidationError{
+						field:  "Latency",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverConfig_Interceptor_Chaos_RuleValidationError{
+						field:  "Latency",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLatency()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverConfig_Interceptor_Chaos_RuleValidationError{
+					field:  "Latency",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DriverConfig_Interceptor_Chaos_Rule_Error:
+		if v == nil {
+			err := DriverConfig_Interceptor_Chaos_RuleValidationError{
+				field:  "Fault",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for Error
+	case *DriverConfig_Interceptor_Chaos_Rule_DropConnection:
+		if v == nil {
+			err := DriverConfig_Interceptor_Chaos_RuleValidationError{
+				field:  "Fault",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for DropConnection
+	case *DriverConfig_Interceptor_Chaos_Rule_FailCommit:
+		if v == nil {
+			err := DriverConfig_Interceptor_Chaos_RuleValidationError{
+				field:  "Fault",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for FailCommit
+	default:
+		_ = v // ensures v is used
+	}
+	if m.Pattern != nil {
+		// no validation rules for Pattern
+	}
+
+	if m.Probability != nil {
+		// no validation rules for Probability
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_Chaos_RuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_Chaos_RuleMultiError is an error wrapping multiple
+// validation errors returned by
+// DriverConfig_Interceptor_Chaos_Rule.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_Chaos_RuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_Chaos_RuleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_Chaos_RuleMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_Chaos_RuleValidationError is the validation error
+// returned by DriverConfig_Interceptor_Chaos_Rule.Validate if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_Chaos_RuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_Chaos_RuleValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_Chaos_RuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_Chaos_Rule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_Chaos_RuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_Chaos_RuleValidationError{}
+
+// Validate checks the field values on DriverConfig_Interceptor_Chaos_Latency
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *DriverConfig_Interceptor_Chaos_Latency) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// DriverConfig_Interceptor_Chaos_Latency with the rules defined in the proto
+// definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in
+// DriverConfig_Interceptor_Chaos_LatencyMultiError, or nil if none found.
+func (m *DriverConfig_Interceptor_Chaos_Latency) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DriverConfig_Interceptor_Chaos_Latency) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Distribution != nil {
+		// no validation rules for Distribution
+	}
+
+	if m.Mean != nil {
+		// no validation rules for Mean
+	}
+
+	if m.Stddev != nil {
+		// no validation rules for Stddev
+	}
+
+	if m.Min != nil {
+		// no validation rules for Min
+	}
+
+	if m.Max != nil {
+		// no validation rules for Max
+	}
+
+	if len(errors) > 0 {
+		return DriverConfig_Interceptor_Chaos_LatencyMultiError(errors)
+	}
+
+	return nil
+}
+
+// DriverConfig_Interceptor_Chaos_LatencyMultiError is an error wrapping
+// multiple validation errors returned by
+// DriverConfig_Interceptor_Chaos_Latency.ValidateAll() if the designated
+// constraints aren't met.
+type DriverConfig_Interceptor_Chaos_LatencyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DriverConfig_Interceptor_Chaos_LatencyMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DriverConfig_Interceptor_Chaos_LatencyMultiError) AllErrors() []error { return m }
+
+// DriverConfig_Interceptor_Chaos_LatencyValidationError is the validation
+// error returned by DriverConfig_Interceptor_Chaos_Latency.Validate if the
+// designated constraints aren't met.
+type DriverConfig_Interceptor_Chaos_LatencyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) ErrorName() string {
+	return "DriverConfig_Interceptor_Chaos_LatencyValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DriverConfig_Interceptor_Chaos_LatencyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDriverConfig_Interceptor_Chaos_Latency.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DriverConfig_Interceptor_Chaos_LatencyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DriverConfig_Interceptor_Chaos_LatencyValidationError{}
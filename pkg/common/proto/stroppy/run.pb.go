@@ -74,8 +74,16 @@ type DriverRunConfig struct {
 	// *
 	// Periodic InsertSpec progress reporting. Matches TS DriverSetup.insertProgress.
 	InsertProgress *DriverConfig_InsertProgressConfig `protobuf:"bytes,16,opt,name=insert_progress,json=insertProgress,proto3,oneof" json:"insert_progress,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// *
+	// Dialect and error mapping for driverType generic_sql.
+	// Copied into DriverConfig.generic_sql.
+	GenericSql *DriverConfig_GenericSqlConfig `protobuf:"bytes,17,opt,name=generic_sql,json=genericSql,proto3,oneof" json:"generic_sql,omitempty"`
+	// *
+	// Call interceptors wrapped around this driver, first outermost.
+	// Copied into DriverConfig.interceptors.
+	Interceptors  []*DriverConfig_Interceptor `protobuf:"bytes,18,rep,name=interceptors,proto3" json:"interceptors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DriverRunConfig) Reset() {
@@ -213,6 +221,20 @@ func (x *DriverRunConfig) GetInsertProgress() *DriverConfig_InsertProgressConfig
 	return nil
 }
 
+func (x *DriverRunConfig) GetGenericSql() *DriverConfig_GenericSqlConfig {
+	if x != nil {
+		return x.GenericSql
+	}
+	return nil
+}
+
+func (x *DriverRunConfig) GetInterceptors() []*DriverConfig_Interceptor {
+	if x != nil {
+		return x.Interceptors
+	}
+	return nil
+}
+
 // *
 // RunConfig is the top-level stroppy config file schema.
 //
@@ -535,7 +557,7 @@ var File_proto_stroppy_run_proto protoreflect.FileDescriptor
 
 const file_proto_stroppy_run_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/stroppy/run.proto\x12\astroppy\x1a\x1aproto/stroppy/config.proto\"\x87\x0f\n" +
+	"\x17proto/stroppy/run.proto\x12\astroppy\x1a\x1aproto/stroppy/config.proto\"\xac\x10\n" +
 	"\x0fDriverRunConfig\x12$\n" +
 	"\vdriver_type\x18\x01 \x01(\tH\x00R\n" +
 	"driverType\x88\x01\x01\x12\x15\n" +
@@ -557,7 +579,10 @@ const file_proto_stroppy_run_proto_rawDesc = "" +
 	"\x15default_insert_method\x18\r \x01(\tH\vR\x13defaultInsertMethod\x88\x01\x01\x12E\n" +
 	"\bpostgres\x18\x0e \x01(\v2$.stroppy.DriverConfig.PostgresConfigH\fR\bpostgres\x88\x01\x01\x126\n" +
 	"\x03sql\x18\x0f \x01(\v2\x1f.stroppy.DriverConfig.SqlConfigH\rR\x03sql\x88\x01\x01\x12X\n" +
-	"\x0finsert_progress\x18\x10 \x01(\v2*.stroppy.DriverConfig.InsertProgressConfigH\x0eR\x0einsertProgress\x88\x01\x01\x1a\x9d\a\n" +
+	"\x0finsert_progress\x18\x10 \x01(\v2*.stroppy.DriverConfig.InsertProgressConfigH\x0eR\x0einsertProgress\x88\x01\x01\x12L\n" +
+	"\vgeneric_sql\x18\x11 \x01(\v2&.stroppy.DriverConfig.GenericSqlConfigH\x0fR\n" +
+	"genericSql\x88\x01\x01\x12E\n" +
+	"\finterceptors\x18\x12 \x03(\v2!.stroppy.DriverConfig.InterceptorR\finterceptors\x1a\x9d\a\n" +
 	"\n" +
 	"PoolConfig\x12 \n" +
 	"\tmax_conns\x18\x01 \x01(\x05H\x00R\bmaxConns\x88\x01\x01\x12 \n" +
@@ -606,7 +631,8 @@ const file_proto_stroppy_run_proto_rawDesc = "" +
 	"\x16_default_insert_methodB\v\n" +
 	"\t_postgresB\x06\n" +
 	"\x04_sqlB\x12\n" +
-	"\x10_insert_progress\"\x8d\x04\n" +
+	"\x10_insert_progressB\x0e\n" +
+	"\f_generic_sql\"\x8d\x04\n" +
 	"\tRunConfig\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1b\n" +
 	"\x06script\x18\x02 \x01(\tH\x00R\x06script\x88\x01\x01\x12\x15\n" +
@@ -652,22 +678,26 @@ var file_proto_stroppy_run_proto_goTypes = []any{
 	(*DriverConfig_PostgresConfig)(nil), // 5: stroppy.DriverConfig.PostgresConfig
 	(*DriverConfig_SqlConfig)(nil),      // 6: stroppy.DriverConfig.SqlConfig
 	(*DriverConfig_InsertProgressConfig)(nil), // 7: stroppy.DriverConfig.InsertProgressConfig
-	(*GlobalConfig)(nil),                      // 8: stroppy.GlobalConfig
+	(*DriverConfig_GenericSqlConfig)(nil),     // 8: stroppy.DriverConfig.GenericSqlConfig
+	(*DriverConfig_Interceptor)(nil),          // 9: stroppy.DriverConfig.Interceptor
+	(*GlobalConfig)(nil),                      // 10: stroppy.GlobalConfig
 }
 var file_proto_stroppy_run_proto_depIdxs = []int32{
-	2, // 0: stroppy.DriverRunConfig.pool:type_name -> stroppy.DriverRunConfig.PoolConfig
-	5, // 1: stroppy.DriverRunConfig.postgres:type_name -> stroppy.DriverConfig.PostgresConfig
-	6, // 2: stroppy.DriverRunConfig.sql:type_name -> stroppy.DriverConfig.SqlConfig
-	7, // 3: stroppy.DriverRunConfig.insert_progress:type_name -> stroppy.DriverConfig.InsertProgressConfig
-	8, // 4: stroppy.RunConfig.global:type_name -> stroppy.GlobalConfig
-	3, // 5: stroppy.RunConfig.drivers:type_name -> stroppy.RunConfig.DriversEntry
-	4, // 6: stroppy.RunConfig.env:type_name -> stroppy.RunConfig.EnvEntry
-	0, // 7: stroppy.RunConfig.DriversEntry.value:type_name -> stroppy.DriverRunConfig
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: stroppy.DriverRunConfig.pool:type_name -> stroppy.DriverRunConfig.PoolConfig
+	5,  // 1: stroppy.DriverRunConfig.postgres:type_name -> stroppy.DriverConfig.PostgresConfig
+	6,  // 2: stroppy.DriverRunConfig.sql:type_name -> stroppy.DriverConfig.SqlConfig
+	7,  // 3: stroppy.DriverRunConfig.insert_progress:type_name -> stroppy.DriverConfig.InsertProgressConfig
+	8,  // 4: stroppy.DriverRunConfig.generic_sql:type_name -> stroppy.DriverConfig.GenericSqlConfig
+	9,  // 5: stroppy.DriverRunConfig.interceptors:type_name -> stroppy.DriverConfig.Interceptor
+	10, // 6: stroppy.RunConfig.global:type_name -> stroppy.GlobalConfig
+	3,  // 7: stroppy.RunConfig.drivers:type_name -> stroppy.RunConfig.DriversEntry
+	4,  // 8: stroppy.RunConfig.env:type_name -> stroppy.RunConfig.EnvEntry
+	0,  // 9: stroppy.RunConfig.DriversEntry.value:type_name -> stroppy.DriverRunConfig
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_stroppy_run_proto_init() }
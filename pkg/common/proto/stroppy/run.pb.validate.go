@@ -57,6 +57,40 @@ func (m *DriverRunConfig) validate(all bool) error {
 
 	var errors []error
 
+	for idx, item := range m.GetInterceptors() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverRunConfigValidationError{
+						field:  fmt.Sprintf("Interceptors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverRunConfigValidationError{
+						field:  fmt.Sprintf("Interceptors[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverRunConfigValidationError{
+					field:  fmt.Sprintf("Interceptors[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if m.DriverType != nil {
 		// no validation rules for DriverType
 	}
@@ -233,6 +267,39 @@ func (m *DriverRunConfig) validate(all bool) error {
 
 	}
 
+	if m.GenericSql != nil {
+
+		if all {
+			switch v := interface{}(m.GetGenericSql()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DriverRunConfigValidationError{
+						field:  "GenericSql",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DriverRunConfigValidationError{
+						field:  "GenericSql",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGenericSql()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DriverRunConfigValidationError{
+					field:  "GenericSql",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return DriverRunConfigMultiError(errors)
 	}
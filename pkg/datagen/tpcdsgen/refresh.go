@@ -0,0 +1,145 @@
+package tpcdsgen
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stroppy-io/stroppy/pkg/gen"
+	"github.com/stroppy-io/stroppy/third_party/gotpcds/dsdgen"
+)
+
+// Data maintenance (refresh) sets. dsdgen builds the spec's update sets as
+// flat "s_*" source tables that the LF_* functions denormalize into the fact
+// tables; this port skips the source tables and generates the fact rows
+// directly: update set N inserts the next block of tickets past the loaded
+// range, drawn from the same channel generators, so the rows are well-formed
+// (valid keys into every dimension, returns paired with their sales) and never
+// collide with loaded or earlier-set tickets. The DF_* deletes remove a few
+// whole sold-date days per set. Inventory (LF_I/DF_I) is not generated.
+//
+// These sets are a refresh load for measuring queries under concurrent writes,
+// not the spec's update sets: porting those needs dsdgen's s_* table
+// generators, the delete.dat date ranges and the inventory sets.
+
+// ErrBadRefreshSet is returned for refresh set numbers below 1.
+var ErrBadRefreshSet = errors.New("tpcdsgen: refresh set must be >= 1")
+
+// ErrNotFactTable is returned when a refresh source is requested for a table
+// the data maintenance functions do not insert into.
+var ErrNotFactTable = errors.New("tpcdsgen: not a TPC-DS fact table")
+
+const (
+	// refreshTicketDivisor sizes an update set: each channel gets 1/1000 of its
+	// loaded tickets (at least one), about 0.1% of the fact rows per set.
+	refreshTicketDivisor = 1000
+	// deleteWindowsPerSet is the number of sold-date windows one set deletes,
+	// as in the spec's delete.dat (three date ranges per refresh run).
+	deleteWindowsPerSet = 3
+	// deleteWindowStride spreads consecutive windows across the sales date
+	// range; it is coprime with the range's 1826 days, so the first 1826
+	// windows are all distinct.
+	deleteWindowStride = 617
+)
+
+// RefreshTables are the fact tables the LF_* functions insert into, each
+// sales table before its returns.
+var RefreshTables = [...]string{
+	"store_sales", "store_returns",
+	"catalog_sales", "catalog_returns",
+	"web_sales", "web_returns",
+}
+
+// RefreshTicketCount returns how many new tickets (orders) one update set adds
+// to table's channel at scale sf.
+func RefreshTicketCount(table string, sf float64) (int64, error) {
+	spec, ok := factTables[table]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrNotFactTable, table)
+	}
+
+	return refreshTickets(spec, sf), nil
+}
+
+func refreshTickets(spec factSpec, sf float64) int64 {
+	return max(1, spec.tbl.TicketCount(sf)/refreshTicketDivisor)
+}
+
+// NewRefreshBatchSource returns a [gen.BatchSource] over the rows update set
+// `set` inserts into fact table `table` at scale sf. A returns table yields
+// the returns of the set's new sales tickets, so loading the pair keeps every
+// return matched to its sale.
+func NewRefreshBatchSource(table string, sf float64, set int) (gen.BatchSource, error) {
+	if sf <= 0 {
+		return nil, fmt.Errorf("%w: %g", ErrNonPositiveScale, sf)
+	}
+
+	if set < 1 {
+		return nil, fmt.Errorf("%w, got %d", ErrBadRefreshSet, set)
+	}
+
+	spec, ok := factTables[table]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFactTable, table)
+	}
+
+	schema, cols := textSchema(spec.tbl.Columns)
+	count := refreshTickets(spec, sf)
+
+	return &refreshBatchSource{
+		factBatchSource: &factBatchSource{spec: spec, sf: sf, schema: schema, cols: cols},
+		first:           spec.tbl.TicketCount(sf) + int64(set-1)*count,
+		count:           count,
+	}, nil
+}
+
+// refreshBatchSource is a factBatchSource over one update set's ticket block.
+// Units are 0-based within the block; first is the block's 0-based offset in
+// the channel's ticket sequence.
+type refreshBatchSource struct {
+	*factBatchSource
+	first int64
+	count int64
+}
+
+func (s *refreshBatchSource) Units() int64 { return s.count }
+
+func (s *refreshBatchSource) TotalRows() int64 { return s.count * s.spec.rowsPerTicket }
+
+func (s *refreshBatchSource) Prepare(start, count int64, batchRows int) (gen.Cursor, error) {
+	if start < 0 || start > s.count {
+		return nil, fmt.Errorf("tpcdsgen: refresh prepare start %d of %d: %w", start, s.count, gen.ErrPrepareRange)
+	}
+
+	if count < 0 || start+count > s.count {
+		count = s.count - start
+	}
+
+	return s.factBatchSource.Prepare(s.first+start, count, batchRows)
+}
+
+// DateWindow is an inclusive range of date_dim surrogate keys (Julian days).
+type DateWindow struct {
+	First int64
+	Last  int64
+}
+
+// DeleteWindows returns the sold-date windows update set `set` deletes from
+// each sales channel (DF_SS, DF_CS, DF_WS), together with their returns.
+// Each window is one day of the 1998-2002 sales range; sets never repeat a
+// day until the range is exhausted.
+func DeleteWindows(set int) ([]DateWindow, error) {
+	if set < 1 {
+		return nil, fmt.Errorf("%w, got %d", ErrBadRefreshSet, set)
+	}
+
+	days := int64(dsdgen.JulianDateMaximum - dsdgen.JulianDateMinimum + 1)
+	windows := make([]DateWindow, deleteWindowsPerSet)
+
+	for i := range windows {
+		n := int64(set-1)*deleteWindowsPerSet + int64(i)
+		day := int64(dsdgen.JulianDateMinimum) + n*deleteWindowStride%days
+		windows[i] = DateWindow{First: day, Last: day}
+	}
+
+	return windows, nil
+}
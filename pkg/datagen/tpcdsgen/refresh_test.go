@@ -0,0 +1,128 @@
+package tpcdsgen_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/datagen/tpcdsgen"
+)
+
+// refreshTickets drains update set `set` of table at SF 1 and returns the
+// ticket number of every row.
+func refreshTickets(t *testing.T, table, ticketColumn string, set int) []int64 {
+	t.Helper()
+
+	src, err := tpcdsgen.NewRefreshBatchSource(table, 1, set)
+	if err != nil {
+		t.Fatalf("%s set %d: %v", table, set, err)
+	}
+
+	column := slices.Index(src.Schema().ColumnNames(), ticketColumn)
+	if column < 0 {
+		t.Fatalf("%s has no column %s", table, ticketColumn)
+	}
+
+	var tickets []int64
+
+	for _, row := range drainTypedRange(t, src, 0, src.Units()) {
+		ticket, err := strconv.ParseInt(row[column], 10, 64)
+		if err != nil {
+			t.Fatalf("%s: ticket %q: %v", table, row[column], err)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets
+}
+
+func TestRefreshSetsAppendNewTickets(t *testing.T) {
+	loaded, err := tpcdsgen.New("store_sales", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	perSet, err := tpcdsgen.RefreshTicketCount("store_sales", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if perSet != loaded.Units()/1000 {
+		t.Fatalf("store_sales refresh tickets = %d, want %d", perSet, loaded.Units()/1000)
+	}
+
+	for set := 1; set <= 2; set++ {
+		first := loaded.Units() + int64(set-1)*perSet + 1
+		last := first + perSet - 1
+
+		sales := refreshTickets(t, "store_sales", "ss_ticket_number", set)
+		if len(sales) <= int(perSet) {
+			t.Fatalf("set %d: %d tickets produced %d rows, want line-item fan-out", set, perSet, len(sales))
+		}
+
+		for _, ticket := range sales {
+			if ticket < first || ticket > last {
+				t.Fatalf("set %d: sales ticket %d outside [%d, %d]", set, ticket, first, last)
+			}
+		}
+
+		returns := refreshTickets(t, "store_returns", "sr_ticket_number", set)
+		if len(returns) == 0 {
+			t.Fatalf("set %d produced no store_returns rows", set)
+		}
+
+		for _, ticket := range returns {
+			if !slices.Contains(sales, ticket) {
+				t.Fatalf("set %d: return ticket %d has no sale in the set", set, ticket)
+			}
+		}
+	}
+}
+
+func TestRefreshSourceErrors(t *testing.T) {
+	if _, err := tpcdsgen.NewRefreshBatchSource("store_sales", 1, 0); !errors.Is(err, tpcdsgen.ErrBadRefreshSet) {
+		t.Fatalf("want ErrBadRefreshSet, got %v", err)
+	}
+
+	if _, err := tpcdsgen.NewRefreshBatchSource("inventory", 1, 1); !errors.Is(err, tpcdsgen.ErrNotFactTable) {
+		t.Fatalf("want ErrNotFactTable, got %v", err)
+	}
+
+	if _, err := tpcdsgen.DeleteWindows(0); !errors.Is(err, tpcdsgen.ErrBadRefreshSet) {
+		t.Fatalf("want ErrBadRefreshSet, got %v", err)
+	}
+}
+
+func TestDeleteWindowsAreDistinctSalesDays(t *testing.T) {
+	const (
+		firstSalesDay = 2450815 // 1998-01-01
+		lastSalesDay  = 2452640 // 2002-12-31
+	)
+
+	seen := make(map[int64]int)
+
+	for set := 1; set <= 100; set++ {
+		windows, err := tpcdsgen.DeleteWindows(set)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(windows) != 3 {
+			t.Fatalf("set %d: %d windows, want 3", set, len(windows))
+		}
+
+		for _, w := range windows {
+			if w.First < firstSalesDay || w.Last > lastSalesDay || w.First > w.Last {
+				t.Fatalf("set %d: window %+v outside the sales range", set, w)
+			}
+
+			if prev, ok := seen[w.First]; ok {
+				t.Fatalf("set %d repeats day %d of set %d", set, w.First, prev)
+			}
+
+			seen[w.First] = set
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tpchgen
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/stroppy-io/stroppy/third_party/gotpc/dbgen"
+)
+
+// Refresh functions (spec §2.5): dbgen update set n holds the orders RF1
+// inserts and the order keys RF2 deletes. Sets are numbered from 1; a run that
+// refreshes more than once must use a new set each time.
+
+// UpdateOrder is one new order of an update set, projected onto the orders
+// and lineitem columns the way the load projects them.
+type UpdateOrder struct {
+	Order []any
+	Lines [][]any
+}
+
+// Columns returns the column names of a TPC-H table, in projection order.
+func Columns(table string) ([]string, error) {
+	spec, ok := specs[table]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTable, table)
+	}
+
+	return spec.columns, nil
+}
+
+// UpdateOrderCount returns the orders one refresh function touches at scale
+// sf: SF × 1500 (0.1% of ORDERS).
+func UpdateOrderCount(sf float64) int64 {
+	dbgen.EnsureInit(sf)
+
+	return dbgen.UpdateOrderCount(sf)
+}
+
+// UpdateOrders streams the new orders of update set `set` at scale sf. Orders
+// are generated lazily, so a large set is never held in memory.
+func UpdateOrders(sf float64, set int) iter.Seq[UpdateOrder] {
+	return func(yield func(UpdateOrder) bool) {
+		gen := dbgen.NewGenerator(sf)
+		gen.SeekUpdateSet(set)
+
+		for idx := range dbgen.UpdateOrderCount(sf) {
+			gen.RowStart(dbgen.TOrder)
+			o := gen.MakeUpdateOrder(set, idx+1)
+			gen.RowStop(dbgen.TOrder)
+
+			if !yield(UpdateOrder{Order: orderRow(o), Lines: lineitemRows(o)}) {
+				return
+			}
+		}
+	}
+}
+
+// DeleteKeys streams the order keys RF2 deletes for update set `set` at
+// scale sf.
+func DeleteKeys(sf float64, set int) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		dbgen.EnsureInit(sf)
+
+		for idx := range dbgen.UpdateOrderCount(sf) {
+			if !yield(dbgen.UpdateDeleteKey(set, idx+1)) {
+				return
+			}
+		}
+	}
+}
@@ -165,12 +165,7 @@ var specs = map[string]tableSpec{
 		entityCount: func(sf float64) int64 { return scaled(dbgen.BaseRowCount(dbgen.TOrder), sf) },
 		seek:        func(g *dbgen.Generator, skip int64) { g.SeekOrderLine(skip) },
 		project: func(g *dbgen.Generator, idx int64) [][]any {
-			o := g.MakeOrder(idx)
-
-			return [][]any{{
-				int64(o.OKey), int64(o.CustKey), o.Status, dbgen.Money(int64(o.TotalPrice)),
-				o.Date, o.OrderPriority, o.Clerk, o.ShipPriority, o.Comment,
-			}}
+			return [][]any{orderRow(g.MakeOrder(idx))}
 		},
 	},
 	"lineitem": {
@@ -185,23 +180,34 @@ var specs = map[string]tableSpec{
 		rowsPerEntity: linesPerOrderNominal,
 		seek:          func(g *dbgen.Generator, skip int64) { g.SeekOrderLine(skip) },
 		project: func(g *dbgen.Generator, idx int64) [][]any {
-			o := g.MakeOrder(idx)
-
-			rows := make([][]any, 0, len(o.Lines))
-			for _, ln := range o.Lines {
-				rows = append(rows, []any{
-					int64(ln.OKey), int64(ln.PartKey), int64(ln.SuppKey), int64(ln.LCnt),
-					int64(ln.Quantity), dbgen.Money(int64(ln.EPrice)), dbgen.Money(int64(ln.Discount)),
-					dbgen.Money(int64(ln.Tax)), ln.RFlag, ln.LStatus,
-					ln.SDate, ln.CDate, ln.RDate, ln.ShipInstruct, ln.ShipMode, ln.Comment,
-				})
-			}
-
-			return rows
+			return lineitemRows(g.MakeOrder(idx))
 		},
 	},
 }
 
+// orderRow projects one order onto the orders columns.
+func orderRow(o *dbgen.Order) []any {
+	return []any{
+		int64(o.OKey), int64(o.CustKey), o.Status, dbgen.Money(int64(o.TotalPrice)),
+		o.Date, o.OrderPriority, o.Clerk, o.ShipPriority, o.Comment,
+	}
+}
+
+// lineitemRows projects the lines of one order onto the lineitem columns.
+func lineitemRows(o *dbgen.Order) [][]any {
+	rows := make([][]any, 0, len(o.Lines))
+	for _, ln := range o.Lines {
+		rows = append(rows, []any{
+			int64(ln.OKey), int64(ln.PartKey), int64(ln.SuppKey), int64(ln.LCnt),
+			int64(ln.Quantity), dbgen.Money(int64(ln.EPrice)), dbgen.Money(int64(ln.Discount)),
+			dbgen.Money(int64(ln.Tax)), ln.RFlag, ln.LStatus,
+			ln.SDate, ln.CDate, ln.RDate, ln.ShipInstruct, ln.ShipMode, ln.Comment,
+		})
+	}
+
+	return rows
+}
+
 // generator is a source.Partitionable bound to one TPC-H table at one scale.
 type generator struct {
 	spec tableSpec
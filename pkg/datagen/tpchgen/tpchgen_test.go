@@ -167,3 +167,70 @@ func TestUnknownTableRejected(t *testing.T) {
 		t.Fatalf("want ErrUnknownTable, got %v", err)
 	}
 }
+
+func TestUpdateSetsAtSF001(t *testing.T) {
+	if got := tpchgen.UpdateOrderCount(sf); got != 15 {
+		t.Fatalf("UpdateOrderCount = %d, want 15 (SF × 1500)", got)
+	}
+
+	loaded := map[int64]bool{}
+	for _, r := range drain(t, "orders") {
+		loaded[r[0].(int64)] = true
+	}
+
+	inserted := map[int64]bool{}
+
+	for set := 1; set <= 2; set++ {
+		var orders int
+
+		for order := range tpchgen.UpdateOrders(sf, set) {
+			key := order.Order[0].(int64)
+			if loaded[key] || inserted[key] {
+				t.Fatalf("set %d: new order key %d collides", set, key)
+			}
+
+			inserted[key] = true
+
+			if len(order.Lines) < 1 || len(order.Lines) > 7 {
+				t.Fatalf("set %d: order %d has %d lines", set, key, len(order.Lines))
+			}
+
+			for _, line := range order.Lines {
+				if line[0].(int64) != key {
+					t.Fatalf("set %d: line key %v, want %d", set, line[0], key)
+				}
+			}
+
+			orders++
+		}
+
+		if orders != 15 {
+			t.Fatalf("set %d: %d new orders, want 15", set, orders)
+		}
+
+		for key := range tpchgen.DeleteKeys(sf, set) {
+			if !loaded[key] {
+				t.Fatalf("set %d: delete key %d is not a loaded order", set, key)
+			}
+
+			delete(loaded, key) // sets must not delete the same order twice
+		}
+	}
+
+	var first, again []any
+	for order := range tpchgen.UpdateOrders(sf, 2) {
+		first = order.Order
+
+		break
+	}
+
+	for order := range tpchgen.UpdateOrders(sf, 2) {
+		again = order.Order
+
+		break
+	}
+
+	if len(first) == 0 || first[0] != again[0] || first[8] != again[8] {
+		t.Fatalf("update set 2 is not deterministic: %v vs %v", first, again)
+	}
+}
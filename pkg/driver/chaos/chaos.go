@@ -0,0 +1,261 @@
+// Package chaos injects faults into any [driver.Driver]: latency, errors of a
+// chosen [driver.ErrorKind], dropped connections and failed commits. Faults
+// are drawn from one seeded generator, so retry policies, error actions and
+// workload rollback paths can be exercised reproducibly.
+//
+// Every VU draws from that one generator in call order, and the interceptor
+// cannot tell VUs apart. A seed therefore replays the exact faults only for a
+// single VU; with several VUs it reproduces the fault rates, while which call
+// gets which fault depends on how the VUs interleave.
+//
+// It registers the "chaos" kind of DriverConfig.Interceptor; [Wrap] applies
+// a config to a driver directly.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/common/logger"
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+// Kind is the DriverConfig.Interceptor oneof field name of the chaos interceptor.
+const Kind = "chaos"
+
+var (
+	// ErrInjected is the cause of every error a rule injects.
+	ErrInjected = errors.New("chaos: injected fault")
+	// ErrConnectionDropped is the cause of drop_connection faults.
+	ErrConnectionDropped = errors.New("chaos: connection dropped")
+)
+
+// Error is a fault injected by a rule. The wrapped driver's ClassifyError
+// reports Kind for it.
+type Error struct {
+	Kind driver.ErrorKind
+	Rule int
+
+	cause error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v (rule %d, %s)", e.cause, e.Rule, e.Kind)
+}
+
+func (e *Error) Unwrap() error { return e.cause }
+
+func init() {
+	driver.RegisterInterceptor(Kind, func(cfg *stroppy.DriverConfig_Interceptor, lg *zap.Logger) (driver.Interceptor, error) {
+		return New(cfg.GetChaos(), lg)
+	})
+}
+
+// Wrap returns drv with the faults of cfg injected into its calls.
+func Wrap(drv driver.Driver, cfg *stroppy.DriverConfig_Interceptor_Chaos, lg *zap.Logger) (driver.Driver, error) {
+	interceptor, err := New(cfg, lg)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.Intercept(drv, interceptor), nil
+}
+
+// New builds the chaos interceptor for cfg. Without a seed one is picked at
+// random and logged, so a single-VU run can be replayed.
+func New(cfg *stroppy.DriverConfig_Interceptor_Chaos, lg *zap.Logger) (driver.Interceptor, error) {
+	if lg == nil {
+		lg = logger.NewFromEnv()
+	}
+
+	rules, err := newRules(cfg.GetRules())
+	if err != nil {
+		return driver.Interceptor{}, err
+	}
+
+	seed := rand.Uint64() //nolint:gosec // fault injection, not security
+	if cfg.Seed != nil {
+		seed = cfg.GetSeed()
+	}
+
+	c := &chaos{
+		rules:  rules,
+		rng:    rand.New(rand.NewPCG(seed, seed)), //nolint:gosec // reproducible by design
+		logger: lg.Named(Kind),
+	}
+
+	c.logger.Info("Chaos enabled", zap.Uint64("seed", seed), zap.Int("rules", len(rules)))
+
+	return driver.Interceptor{
+		Name:          Kind,
+		RunQuery:      c.runQuery,
+		Begin:         c.begin,
+		Commit:        c.commit,
+		ClassifyError: classify,
+	}, nil
+}
+
+type chaos struct {
+	rules  []*rule
+	logger *zap.Logger
+
+	mu  sync.Mutex
+	rng *rand.Rand // shared by every VU, drawn in call order
+}
+
+// tx remembers which fail_commit rules its statements matched and whether
+// a drop_connection fault already ended it.
+type tx struct {
+	driver.Tx
+
+	commitRules []bool
+	dropped     error
+}
+
+func (t *tx) Rollback(ctx context.Context) error {
+	if t.dropped != nil {
+		return nil // rolled back when the connection dropped
+	}
+
+	return t.Tx.Rollback(ctx)
+}
+
+func (c *chaos) begin(
+	ctx context.Context,
+	isolation stroppy.TxIsolationLevel,
+	next driver.BeginHandler,
+) (driver.Tx, error) {
+	inner, err := next(ctx, isolation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx{Tx: inner, commitRules: make([]bool, len(c.rules))}, nil
+}
+
+func (c *chaos) runQuery(ctx context.Context, query driver.Query, next driver.QueryHandler) (*driver.QueryResult, error) {
+	t, _ := query.Tx.(*tx)
+	if t != nil && t.dropped != nil {
+		return nil, t.dropped
+	}
+
+	stmt := statement{sql: query.SQL}
+
+	for _, r := range c.rules {
+		if !r.matches(&stmt) {
+			continue
+		}
+
+		if r.fault == faultFailCommit {
+			if t != nil {
+				t.commitRules[r.index] = true
+			}
+
+			continue
+		}
+
+		if !c.fire(r) {
+			continue
+		}
+
+		c.logger.Debug("Injected fault", zap.Int("rule", r.index), zap.Stringer("fault", r.fault),
+			zap.String("sql", query.SQL))
+
+		switch r.fault {
+		case faultLatency:
+			if err := sleep(ctx, c.delay(r)); err != nil {
+				return nil, err
+			}
+		case faultError:
+			return nil, &Error{Kind: r.kind, Rule: r.index, cause: ErrInjected}
+		case faultDropConnection:
+			err := &Error{Kind: driver.ErrorKindTransient, Rule: r.index, cause: ErrConnectionDropped}
+			if t != nil {
+				_ = t.Tx.Rollback(ctx)
+				t.dropped = err
+			}
+
+			return nil, err
+		case faultFailCommit: // recorded on the transaction above
+		}
+	}
+
+	return next(ctx, query)
+}
+
+func (c *chaos) commit(ctx context.Context, dtx driver.Tx, next driver.CommitHandler) error {
+	t, ok := dtx.(*tx)
+	if !ok {
+		return next(ctx, dtx)
+	}
+
+	if t.dropped != nil {
+		return t.dropped
+	}
+
+	for _, r := range c.rules {
+		if r.fault != faultFailCommit || !r.unscoped() && !t.commitRules[r.index] || !c.fire(r) {
+			continue
+		}
+
+		c.logger.Debug("Injected fault", zap.Int("rule", r.index), zap.Stringer("fault", r.fault))
+
+		if err := t.Tx.Rollback(ctx); err != nil {
+			return driver.JoinErrors(&Error{Kind: r.kind, Rule: r.index, cause: ErrInjected}, err)
+		}
+
+		return &Error{Kind: r.kind, Rule: r.index, cause: ErrInjected}
+	}
+
+	return next(ctx, dtx)
+}
+
+func classify(err error, next driver.ClassifyHandler) driver.ErrorFacts {
+	if injected, ok := errors.AsType[*Error](err); ok {
+		return driver.ErrorFacts{Kind: injected.Kind}
+	}
+
+	return next(err)
+}
+
+// fire draws whether a matching rule's fault happens this time.
+func (c *chaos) fire(r *rule) bool {
+	if r.probability >= 1 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.rng.Float64() < r.probability
+}
+
+func (c *chaos) delay(r *rule) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return r.latency.sample(c.rng)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
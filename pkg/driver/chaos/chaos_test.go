@@ -0,0 +1,274 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/stroppy-io/stroppy/pkg/bench"
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/stats"
+)
+
+// fakeDriver counts the calls that reach the database.
+type fakeDriver struct {
+	queries, commits, rollbacks int
+}
+
+func (d *fakeDriver) RunQuery(context.Context, string, map[string]any) (*driver.QueryResult, error) {
+	d.queries++
+
+	return &driver.QueryResult{}, nil
+}
+
+func (d *fakeDriver) Begin(context.Context, stroppy.TxIsolationLevel) (driver.Tx, error) {
+	return &fakeTx{drv: d}, nil
+}
+
+func (d *fakeDriver) Insert(context.Context, *driver.InsertRequest) (*stats.Query, error) {
+	return &stats.Query{}, nil
+}
+
+func (d *fakeDriver) ClassifyError(err error) driver.ErrorFacts { return driver.DefaultErrorFacts(err) }
+
+func (d *fakeDriver) Teardown(context.Context) error { return nil }
+
+type fakeTx struct{ drv *fakeDriver }
+
+func (tx *fakeTx) RunQuery(ctx context.Context, sql string, args map[string]any) (*driver.QueryResult, error) {
+	return tx.drv.RunQuery(ctx, sql, args)
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.drv.commits++
+
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	tx.drv.rollbacks++
+
+	return nil
+}
+
+func (tx *fakeTx) Isolation() stroppy.TxIsolationLevel { return stroppy.TxIsolationLevel_SERIALIZABLE }
+
+func wrap(t *testing.T, seed uint64, rules ...*stroppy.DriverConfig_Interceptor_Chaos_Rule) (driver.Driver, *fakeDriver) {
+	t.Helper()
+
+	inner := &fakeDriver{}
+
+	drv, err := Wrap(inner, &stroppy.DriverConfig_Interceptor_Chaos{Seed: proto.Uint64(seed), Rules: rules}, zap.NewNop())
+	require.NoError(t, err)
+
+	return drv, inner
+}
+
+func errorRule(kind string, probability float64) *stroppy.DriverConfig_Interceptor_Chaos_Rule {
+	return &stroppy.DriverConfig_Interceptor_Chaos_Rule{
+		Probability: proto.Float64(probability),
+		Fault:       &stroppy.DriverConfig_Interceptor_Chaos_Rule_Error{Error: kind},
+	}
+}
+
+func TestInjectedErrorsDriveRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	run := func(seed uint64) []int {
+		drv, _ := wrap(t, seed, errorRule("serialization", 0.5))
+
+		var retries []int
+
+		policy := bench.RetryPolicy{
+			MaxAttempts: 10,
+			Classify:    drv.ClassifyError,
+			Actions:     bench.DefaultErrorActions(),
+			OnRetry: func(attempt int, _ error, decision bench.RetryDecision) {
+				require.Equal(t, driver.ErrorKindSerialization, decision.Facts.Kind)
+				retries = append(retries, attempt)
+			},
+		}
+
+		for range 20 {
+			require.NoError(t, bench.Retry0(context.Background(), policy, func() error {
+				_, err := drv.RunQuery(context.Background(), "select 1", nil)
+
+				return err
+			}))
+		}
+
+		return retries
+	}
+
+	first := run(42)
+	require.NotEmpty(t, first)
+	require.Equal(t, first, run(42), "same seed, same faults")
+	require.NotEqual(t, first, run(43))
+
+	drv, _ := wrap(t, 1, errorRule("unsupported", 1))
+	_, err := drv.RunQuery(context.Background(), "select 1", nil)
+	require.ErrorIs(t, err, ErrInjected)
+	require.Equal(t, driver.ErrorKindUnsupported, drv.ClassifyError(err).Kind)
+	require.Equal(t, driver.ErrorKindUnknown, drv.ClassifyError(errors.New("other")).Kind)
+}
+
+func TestRuleScope(t *testing.T) {
+	t.Parallel()
+
+	byTable := errorRule("deadlock", 1)
+	byTable.Tables = []string{"Stock"}
+	byPattern := errorRule("lock_timeout", 1)
+	byPattern.Pattern = proto.String(`(?i)^delete\b`)
+
+	drv, inner := wrap(t, 1, byTable, byPattern)
+
+	for _, tc := range []struct {
+		sql  string
+		want driver.ErrorKind
+	}{
+		{sql: `UPDATE public."stock" SET s_quantity = 1`, want: driver.ErrorKindDeadlock},
+		{sql: "select * from warehouse w join stock s on s.w_id = w.id", want: driver.ErrorKindDeadlock},
+		{sql: "DELETE FROM new_order WHERE no_o_id = 1", want: driver.ErrorKindLockTimeout},
+		{sql: "select * from stock_level", want: ""},
+		{sql: "insert into orders values (1)", want: ""},
+	} {
+		_, err := drv.RunQuery(context.Background(), tc.sql, nil)
+		if tc.want == "" {
+			require.NoError(t, err, tc.sql)
+
+			continue
+		}
+
+		require.Equal(t, tc.want, drv.ClassifyError(err).Kind, tc.sql)
+	}
+
+	require.Equal(t, 2, inner.queries)
+}
+
+func TestDropConnection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	drv, inner := wrap(t, 1, &stroppy.DriverConfig_Interceptor_Chaos_Rule{
+		Pattern: proto.String("order_line"),
+		Fault:   &stroppy.DriverConfig_Interceptor_Chaos_Rule_DropConnection{DropConnection: true},
+	})
+
+	tx, err := drv.Begin(ctx, stroppy.TxIsolationLevel_SERIALIZABLE)
+	require.NoError(t, err)
+
+	_, err = tx.RunQuery(ctx, "select * from district", nil)
+	require.NoError(t, err)
+
+	_, err = tx.RunQuery(ctx, "insert into order_line values (1)", nil)
+	require.ErrorIs(t, err, ErrConnectionDropped)
+	require.Equal(t, driver.ErrorKindTransient, drv.ClassifyError(err).Kind)
+
+	_, err = tx.RunQuery(ctx, "select * from district", nil)
+	require.ErrorIs(t, err, ErrConnectionDropped)
+	require.ErrorIs(t, tx.Commit(ctx), ErrConnectionDropped)
+	require.NoError(t, tx.Rollback(ctx))
+
+	require.Equal(t, 1, inner.queries)
+	require.Equal(t, 0, inner.commits)
+	require.Equal(t, 1, inner.rollbacks)
+}
+
+func TestFailCommit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	drv, inner := wrap(t, 1, &stroppy.DriverConfig_Interceptor_Chaos_Rule{
+		Tables: []string{"new_order"},
+		Fault:  &stroppy.DriverConfig_Interceptor_Chaos_Rule_FailCommit{},
+	})
+
+	commit := func(sql string) error {
+		tx, err := drv.Begin(ctx, stroppy.TxIsolationLevel_SERIALIZABLE)
+		require.NoError(t, err)
+
+		_, err = tx.RunQuery(ctx, sql, nil)
+		require.NoError(t, err)
+
+		return tx.Commit(ctx)
+	}
+
+	err := commit("insert into new_order values (1, 2, 3)")
+	require.ErrorIs(t, err, ErrInjected)
+	require.Equal(t, driver.ErrorKindSerialization, drv.ClassifyError(err).Kind)
+	require.NoError(t, commit("select * from customer"))
+
+	require.Equal(t, 1, inner.commits)
+	require.Equal(t, 1, inner.rollbacks)
+}
+
+func TestLatency(t *testing.T) {
+	t.Parallel()
+
+	l, err := newLatency(&stroppy.DriverConfig_Interceptor_Chaos_Latency{
+		Distribution: proto.String("normal"),
+		Mean:         proto.String("10ms"),
+		Stddev:       proto.String("50ms"),
+		Min:          proto.String("5ms"),
+		Max:          proto.String("20ms"),
+	})
+	require.NoError(t, err)
+
+	c := &chaos{rules: []*rule{{latency: l}}, rng: rand.New(rand.NewPCG(1, 1))}
+
+	for range 1000 {
+		d := c.delay(c.rules[0])
+		require.GreaterOrEqual(t, d, 5*time.Millisecond)
+		require.LessOrEqual(t, d, 20*time.Millisecond)
+	}
+
+	drv, _ := wrap(t, 1, &stroppy.DriverConfig_Interceptor_Chaos_Rule{
+		Fault: &stroppy.DriverConfig_Interceptor_Chaos_Rule_Latency{Latency: &stroppy.DriverConfig_Interceptor_Chaos_Latency{
+			Mean: proto.String("1h"),
+		}},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = drv.RunQuery(ctx, "select 1", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestInvalidRules(t *testing.T) {
+	t.Parallel()
+
+	for name, r := range map[string]*stroppy.DriverConfig_Interceptor_Chaos_Rule{
+		"no fault":     {},
+		"error kind":   errorRule("flaky", 1),
+		"probability":  errorRule("transient", 1.5),
+		"pattern":      {Pattern: proto.String("("), Fault: &stroppy.DriverConfig_Interceptor_Chaos_Rule_DropConnection{DropConnection: true}},
+		"distribution": {Fault: &stroppy.DriverConfig_Interceptor_Chaos_Rule_Latency{Latency: &stroppy.DriverConfig_Interceptor_Chaos_Latency{Distribution: proto.String("pareto")}}},
+		"uniform":      {Fault: &stroppy.DriverConfig_Interceptor_Chaos_Rule_Latency{Latency: &stroppy.DriverConfig_Interceptor_Chaos_Latency{Distribution: proto.String("uniform"), Min: proto.String("1s")}}},
+	} {
+		_, err := New(&stroppy.DriverConfig_Interceptor_Chaos{Rules: []*stroppy.DriverConfig_Interceptor_Chaos_Rule{r}}, zap.NewNop())
+		require.ErrorIs(t, err, ErrInvalidRule, name)
+	}
+}
+
+func TestRegisteredInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptors, err := driver.NewInterceptors([]*stroppy.DriverConfig_Interceptor{{
+		Kind: &stroppy.DriverConfig_Interceptor_Chaos_{Chaos: &stroppy.DriverConfig_Interceptor_Chaos{
+			Rules: []*stroppy.DriverConfig_Interceptor_Chaos_Rule{errorRule("timeout", 1)},
+		}},
+	}}, zap.NewNop())
+	require.NoError(t, err)
+
+	drv := driver.Intercept(&fakeDriver{}, interceptors...)
+	_, err = drv.RunQuery(context.Background(), "select 1", nil)
+	require.Equal(t, driver.ErrorKindTimeout, drv.ClassifyError(err).Kind)
+}
@@ -0,0 +1,267 @@
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+	"time"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+var ErrInvalidRule = errors.New("invalid chaos rule")
+
+type fault uint8
+
+const (
+	faultLatency fault = iota
+	faultError
+	faultDropConnection
+	faultFailCommit
+)
+
+func (f fault) String() string {
+	switch f {
+	case faultLatency:
+		return "latency"
+	case faultError:
+		return "error"
+	case faultDropConnection:
+		return "drop_connection"
+	case faultFailCommit:
+		return "fail_commit"
+	default:
+		return "unknown"
+	}
+}
+
+type rule struct {
+	index       int
+	pattern     *regexp.Regexp
+	tables      map[string]struct{}
+	probability float64
+
+	fault   fault
+	kind    driver.ErrorKind
+	latency latency
+}
+
+func newRules(configs []*stroppy.DriverConfig_Interceptor_Chaos_Rule) ([]*rule, error) {
+	rules := make([]*rule, 0, len(configs))
+
+	for i, cfg := range configs {
+		r, err := newRule(i, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d: %w", ErrInvalidRule, i, err)
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func newRule(index int, cfg *stroppy.DriverConfig_Interceptor_Chaos_Rule) (*rule, error) {
+	r := &rule{index: index, probability: 1}
+
+	if cfg.Pattern != nil {
+		pattern, err := regexp.Compile(cfg.GetPattern())
+		if err != nil {
+			return nil, fmt.Errorf("pattern: %w", err)
+		}
+
+		r.pattern = pattern
+	}
+
+	if len(cfg.GetTables()) > 0 {
+		r.tables = make(map[string]struct{}, len(cfg.GetTables()))
+		for _, table := range cfg.GetTables() {
+			r.tables[strings.ToLower(table)] = struct{}{}
+		}
+	}
+
+	if cfg.Probability != nil {
+		r.probability = cfg.GetProbability()
+		if r.probability < 0 || r.probability > 1 {
+			return nil, fmt.Errorf("probability %v not in [0, 1]", r.probability)
+		}
+	}
+
+	var err error
+
+	switch fault := cfg.GetFault().(type) {
+	case *stroppy.DriverConfig_Interceptor_Chaos_Rule_Latency:
+		r.fault = faultLatency
+		r.latency, err = newLatency(fault.Latency)
+	case *stroppy.DriverConfig_Interceptor_Chaos_Rule_Error:
+		r.fault = faultError
+		r.kind, err = driver.ParseErrorKind(fault.Error)
+	case *stroppy.DriverConfig_Interceptor_Chaos_Rule_DropConnection:
+		r.fault = faultDropConnection
+		if !fault.DropConnection {
+			err = errors.New("drop_connection must be true")
+		}
+	case *stroppy.DriverConfig_Interceptor_Chaos_Rule_FailCommit:
+		r.fault = faultFailCommit
+		r.kind = driver.ErrorKindSerialization
+
+		if fault.FailCommit != "" {
+			r.kind, err = driver.ParseErrorKind(fault.FailCommit)
+		}
+	default:
+		err = errors.New("no fault set")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// unscoped reports whether the rule applies to every statement.
+func (r *rule) unscoped() bool { return r.pattern == nil && r.tables == nil }
+
+func (r *rule) matches(stmt *statement) bool {
+	if r.pattern != nil && !r.pattern.MatchString(stmt.sql) {
+		return false
+	}
+
+	if r.tables == nil {
+		return true
+	}
+
+	for _, table := range stmt.tables() {
+		if _, ok := r.tables[table]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// statement extracts the tables of its SQL once, on the first rule asking.
+type statement struct {
+	sql       string
+	tableList []string
+	parsed    bool
+}
+
+var tableRef = regexp.MustCompile("(?i)\\b(?:from|join|into|update)\\s+([\\w.\"`]+)")
+
+func (s *statement) tables() []string {
+	if s.parsed {
+		return s.tableList
+	}
+
+	s.parsed = true
+
+	for _, match := range tableRef.FindAllStringSubmatch(s.sql, -1) {
+		name := strings.ToLower(strings.Trim(match[1], "\"`"))
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+			name = strings.Trim(name[dot+1:], "\"`")
+		}
+
+		s.tableList = append(s.tableList, name)
+	}
+
+	return s.tableList
+}
+
+type distribution uint8
+
+const (
+	distConstant distribution = iota
+	distUniform
+	distNormal
+	distExponential
+)
+
+type latency struct {
+	dist             distribution
+	mean, stddev     time.Duration
+	floor, ceiling   time.Duration
+	hasFloor, hasCap bool
+}
+
+func newLatency(cfg *stroppy.DriverConfig_Interceptor_Chaos_Latency) (latency, error) {
+	var l latency
+
+	durations := []struct {
+		name string
+		set  bool
+		text string
+		dst  *time.Duration
+	}{
+		{"mean", cfg.Mean != nil, cfg.GetMean(), &l.mean},
+		{"stddev", cfg.Stddev != nil, cfg.GetStddev(), &l.stddev},
+		{"min", cfg.Min != nil, cfg.GetMin(), &l.floor},
+		{"max", cfg.Max != nil, cfg.GetMax(), &l.ceiling},
+	}
+
+	for _, d := range durations {
+		if !d.set {
+			continue
+		}
+
+		parsed, err := time.ParseDuration(d.text)
+		if err != nil || parsed < 0 {
+			return l, fmt.Errorf("latency %s %q", d.name, d.text)
+		}
+
+		*d.dst = parsed
+	}
+
+	l.hasFloor, l.hasCap = cfg.Min != nil, cfg.Max != nil
+
+	switch cfg.GetDistribution() {
+	case "", "constant":
+		l.dist = distConstant
+	case "uniform":
+		l.dist = distUniform
+		if !l.hasCap || l.ceiling < l.floor {
+			return l, errors.New("uniform latency needs max >= min")
+		}
+	case "normal":
+		l.dist = distNormal
+	case "exponential":
+		l.dist = distExponential
+	default:
+		return l, fmt.Errorf("unknown latency distribution %q", cfg.GetDistribution())
+	}
+
+	if l.hasFloor && l.hasCap && l.ceiling < l.floor {
+		return l, errors.New("latency max < min")
+	}
+
+	return l, nil
+}
+
+func (l latency) sample(rng *rand.Rand) time.Duration {
+	var d time.Duration
+
+	switch l.dist {
+	case distConstant:
+		d = l.mean
+	case distUniform:
+		d = l.floor + time.Duration(rng.Int64N(int64(l.ceiling-l.floor)+1))
+	case distNormal:
+		d = l.mean + time.Duration(math.Round(rng.NormFloat64()*float64(l.stddev)))
+	case distExponential:
+		d = time.Duration(math.Round(rng.ExpFloat64() * float64(l.mean)))
+	}
+
+	if l.hasFloor {
+		d = max(d, l.floor)
+	}
+
+	if l.hasCap {
+		d = min(d, l.ceiling)
+	}
+
+	return max(d, 0)
+}
@@ -73,14 +73,29 @@ func RegisterDriver(
 	registry[driverType] = constructor
 }
 
+// Dispatch opens the driver registered for the configured driver type and
+// wraps it in the interceptors the config lists.
 func Dispatch(
 	ctx context.Context,
 	opts Options,
 ) (Driver, error) {
 	drvType := opts.Config.GetDriverType()
-	if constructor, ok := registry[drvType]; ok {
-		return constructor(ctx, opts)
+
+	constructor, ok := registry[drvType]
+	if !ok {
+		return nil, fmt.Errorf("driver type '%s': %w", drvType.String(), ErrNoRegisteredDriver)
+	}
+
+	// Interceptors are built first so a bad config fails before connecting.
+	interceptors, err := NewInterceptors(opts.Config.GetInterceptors(), opts.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("driver type '%s': %w", drvType.String(), err)
+	}
+
+	drv, err := constructor(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("driver type '%s': %w", drvType.String(), ErrNoRegisteredDriver)
+	return Intercept(drv, interceptors...), nil
 }
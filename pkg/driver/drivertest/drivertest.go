@@ -0,0 +1,100 @@
+// Package drivertest holds helpers for the tests of database/sql based
+// drivers: running statements, reading a single integer back, and a small
+// typed source to insert.
+package drivertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// Querier runs a statement: a [driver.Driver] or one of its transactions.
+type Querier interface {
+	RunQuery(ctx context.Context, sql string, args map[string]any) (*driver.QueryResult, error)
+}
+
+// Exec runs sql on q and fails t on error.
+func Exec(t testing.TB, q Querier, sql string, args map[string]any) {
+	t.Helper()
+
+	res, err := q.RunQuery(context.Background(), sql, args)
+	if err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+
+	_ = res.Rows.Close()
+}
+
+// QueryInt runs sql on q and returns the int64 in its single row's first
+// column, failing t on anything else.
+func QueryInt(t testing.TB, q Querier, sql string, args map[string]any) int64 {
+	t.Helper()
+
+	res, err := q.RunQuery(context.Background(), sql, args)
+	if err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+
+	defer res.Rows.Close()
+
+	rows := res.Rows.ReadAll(1)
+	if err := res.Rows.Err(); err != nil || len(rows) != 1 {
+		t.Fatalf("%s: rows %v, err %v", sql, rows, err)
+	}
+
+	n, ok := rows[0][0].(int64)
+	if !ok {
+		t.Fatalf("%s: got %v (%T), want int64", sql, rows[0][0], rows[0][0])
+	}
+
+	return n
+}
+
+// SeqTable is the table [SeqSource] rows fit.
+const SeqTable = "CREATE TABLE t (id INTEGER PRIMARY KEY, at DATETIME)"
+
+// SeqSource yields total rows of (id, at): ids 0..total-1 and timestamps one
+// second apart from 2026-01-02 03:04:05 UTC.
+func SeqSource(total int64) *gen.IndexedSource {
+	b := gen.NewSchemaBuilder()
+	idCol := b.Int64("id")
+	atCol := b.Time("at")
+	schema := b.Build()
+
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	fn := func(r gen.Row, entity uint64) error {
+		r.SetInt64(idCol, int64(entity)) //nolint:gosec // G115: bounded by total
+		r.SetTime(atCol, base.Add(time.Duration(entity)*time.Second))
+
+		return nil
+	}
+
+	return gen.NewIndexedSource(schema, gen.Root{}, "test/drivertest@1", total, 64, fn)
+}
+
+// CheckInsert creates table t on drv, inserts SeqSource(total) with method
+// and checks every row arrived with its timestamp intact.
+func CheckInsert(t testing.TB, drv driver.Driver, method driver.InsertMethod, workers int, total int64) {
+	t.Helper()
+
+	Exec(t, drv, SeqTable, nil)
+
+	res, err := drv.Insert(context.Background(), &driver.InsertRequest{
+		Table: "t", Method: method, Workers: workers, Source: SeqSource(total),
+	})
+	if err != nil {
+		t.Fatalf("method %s: %v", method, err)
+	}
+
+	if res.Rows != total || QueryInt(t, drv, "SELECT COUNT(*) FROM t", nil) != total {
+		t.Fatalf("method %s: rows %d, want %d", method, res.Rows, total)
+	}
+
+	if got := QueryInt(t, drv, "SELECT COUNT(*) FROM t WHERE at < '2026-01-02 03:05:05'", nil); got != 60 {
+		t.Fatalf("method %s: %d rows before the first minute, want 60", method, got)
+	}
+}
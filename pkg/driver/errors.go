@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 // ErrorKind describes a database-independent property of a driver error.
@@ -19,6 +20,22 @@ const (
 	ErrorKindTimeout       ErrorKind = "timeout"
 )
 
+// ErrUnknownErrorKind is returned by ParseErrorKind for a name that is not
+// one of the ErrorKind constants.
+var ErrUnknownErrorKind = errors.New("unknown error kind")
+
+// ParseErrorKind resolves an error kind by its name ("serialization",
+// "deadlock", ...), as written in driver configs.
+func ParseErrorKind(name string) (ErrorKind, error) {
+	switch kind := ErrorKind(name); kind {
+	case ErrorKindUnknown, ErrorKindSerialization, ErrorKindDeadlock, ErrorKindLockTimeout,
+		ErrorKindTransient, ErrorKindUnsupported, ErrorKindCanceled, ErrorKindTimeout:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownErrorKind, name)
+	}
+}
+
 // ErrorFacts are backend-neutral properties used by workload error policy.
 type ErrorFacts struct {
 	Kind                ErrorKind
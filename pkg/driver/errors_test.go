@@ -112,3 +112,14 @@ func TestJoinErrorsReportsTimeoutOnce(t *testing.T) {
 		t.Fatalf("deadline message count = %d, want 1: %v", count, got)
 	}
 }
+
+func TestParseErrorKind(t *testing.T) {
+	t.Parallel()
+
+	kind, err := ParseErrorKind("lock_timeout")
+	require.NoError(t, err)
+	require.Equal(t, ErrorKindLockTimeout, kind)
+
+	_, err = ParseErrorKind("retry")
+	require.ErrorIs(t, err, ErrUnknownErrorKind)
+}
@@ -0,0 +1,125 @@
+package driver
+
+import (
+	"strings"
+)
+
+// Fingerprint normalizes sql so that executions of one statement with
+// different values share a key: comments are dropped, string and numeric
+// literals and bind parameters (:name, $1, ?) become "?", runs of whitespace
+// collapse to one space, the text is lower-cased, and value lists such as
+// IN (?, ?, ?) or multi-row VALUES fold to a single "(?)".
+func Fingerprint(sql string) string {
+	tokens := make([]string, 0, len(sql)/4)
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+
+			i += end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				end = len(sql) - i - 2
+			}
+
+			i += end + 4
+		case c == '\'':
+			tokens = append(tokens, "?")
+			i = quotedEnd(sql, i)
+		case c == '"' || c == '`':
+			end := quotedEnd(sql, i)
+			tokens = append(tokens, sql[i:end])
+			i = end
+		case strings.HasPrefix(sql[i:], "::"):
+			tokens = append(tokens, "::")
+			i += 2
+		case (c == ':' || c == '$' || c == '@') && identifierEnd(sql, i+1) > i+1:
+			tokens = append(tokens, "?")
+			i = identifierEnd(sql, i+1)
+		case isDigit(c):
+			tokens = append(tokens, "?")
+			i = numberEnd(sql, i)
+		case isIdentifierStart(c):
+			end := identifierEnd(sql, i)
+			tokens = append(tokens, strings.ToLower(sql[i:end]))
+			i = end
+		default:
+			tokens = append(tokens, sql[i:i+1])
+			i++
+		}
+	}
+
+	return foldValueLists(tightenPunctuation.Replace(strings.Join(tokens, " ")))
+}
+
+var tightenPunctuation = strings.NewReplacer(" ,", ",", "( ", "(", " )", ")", " . ", ".", " :: ", "::", " ;", ";")
+
+// foldValueLists collapses "(?, ?, ...)" to "(?)" and then repeated
+// "(?), (?)" groups to one "(?)".
+func foldValueLists(s string) string {
+	for _, fold := range [][2]string{{"?, ?", "?"}, {"(?), (?)", "(?)"}} {
+		for strings.Contains(s, fold[0]) {
+			s = strings.ReplaceAll(s, fold[0], fold[1])
+		}
+	}
+
+	return s
+}
+
+func quotedEnd(sql string, start int) int {
+	quote := sql[start]
+
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] == '\\' && quote == '\'' {
+			i++
+
+			continue
+		}
+
+		if sql[i] == quote {
+			if i+1 < len(sql) && sql[i+1] == quote {
+				i++
+
+				continue
+			}
+
+			return i + 1
+		}
+	}
+
+	return len(sql)
+}
+
+func numberEnd(sql string, start int) int {
+	i := start
+	for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.' || sql[i] == 'e' || sql[i] == 'E' ||
+		(sql[i] == '-' || sql[i] == '+') && (sql[i-1] == 'e' || sql[i-1] == 'E')) {
+		i++
+	}
+
+	return i
+}
+
+func identifierEnd(sql string, start int) int {
+	i := start
+	for i < len(sql) && (isIdentifierStart(sql[i]) || isDigit(sql[i]) || sql[i] == '$') {
+		i++
+	}
+
+	return i
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentifierStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
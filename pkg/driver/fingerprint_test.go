@@ -0,0 +1,35 @@
+package driver
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{
+			sql:  "SELECT *\n  FROM t -- trailing\n WHERE id = 42 AND name = 'O''Brien'",
+			want: "select * from t where id = ? and name = ?",
+		},
+		{
+			sql:  "select /* hint */ a.b from t a where a.id in (1, 2, 3) and x::int > :min",
+			want: "select a.b from t a where a.id in (?) and x::int > ?",
+		},
+		{
+			sql:  "INSERT INTO t (a, b) VALUES ($1, $2), ($3, $4), ($5, $6)",
+			want: "insert into t (a, b) values (?)",
+		},
+		{
+			sql:  `UPDATE "Orders" SET total = total - 1.5e3 WHERE id = ?;`,
+			want: `update "Orders" set total = total - ? where id = ?;`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Fingerprint(tt.sql); got != tt.want {
+			t.Errorf("Fingerprint(%q) = %q, want %q", tt.sql, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package genericsql
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver/queries"
+)
+
+var _ queries.Dialect = (*dialect)(nil)
+
+var ErrInvalidDialect = errors.New("invalid generic_sql dialect")
+
+// Placeholder styles accepted by GenericSqlConfig.placeholder.
+const (
+	placeholderQuestion = "question"
+	placeholderDollar   = "dollar"
+	placeholderColon    = "colon"
+	placeholderAtP      = "at_p"
+)
+
+// Value encodings accepted by the *_as fields of GenericSqlConfig.
+const (
+	asString = "string"
+	asBytes  = "bytes"
+	asTime   = "time"
+	asText   = "text"
+	asUnix   = "unix"
+	asFloat  = "float"
+	asBool   = "bool"
+	asInt    = "int"
+)
+
+const (
+	defaultTimeLayout    = "2006-01-02 15:04:05.999999"
+	defaultTimeoutGrace  = time.Second
+	defaultMaxBindParams = 65535
+
+	// timeoutHintMillis is the template variable StatementTimeoutHint
+	// replaces with the statement timeout in milliseconds.
+	timeoutHintMillis = "{ms}"
+)
+
+// dialect is a [queries.Dialect] assembled from a GenericSqlConfig block
+// instead of code, so one driver serves every database/sql engine.
+type dialect struct {
+	placeholder   func(index int) string
+	deduplicate   bool
+	timeoutHint   string
+	timeoutGrace  time.Duration
+	uuidAs        string
+	timeAs        string
+	timeLayout    string
+	decimalAs     string
+	boolAs        string
+	maxBindParams int
+}
+
+// newDialect validates cfg and fills in the defaults of unset fields.
+// A nil cfg yields "?" placeholders and driver-native values.
+func newDialect(cfg *stroppy.DriverConfig_GenericSqlConfig) (*dialect, error) {
+	if cfg == nil {
+		cfg = &stroppy.DriverConfig_GenericSqlConfig{}
+	}
+
+	d := &dialect{
+		deduplicate:   cfg.GetDeduplicate(),
+		timeoutHint:   cfg.GetTimeoutHint(),
+		timeoutGrace:  defaultTimeoutGrace,
+		uuidAs:        valueOr(cfg.GetUuidAs(), asString),
+		timeAs:        valueOr(cfg.GetTimeAs(), asTime),
+		timeLayout:    valueOr(cfg.GetTimeLayout(), defaultTimeLayout),
+		decimalAs:     valueOr(cfg.GetDecimalAs(), asString),
+		boolAs:        valueOr(cfg.GetBoolAs(), asBool),
+		maxBindParams: defaultMaxBindParams,
+	}
+
+	numbered := true
+
+	switch style := valueOr(cfg.GetPlaceholder(), placeholderQuestion); style {
+	case placeholderQuestion:
+		d.placeholder = func(int) string { return "?" }
+		numbered = false
+	case placeholderDollar:
+		d.placeholder = numberedPlaceholder("$")
+	case placeholderColon:
+		d.placeholder = numberedPlaceholder(":")
+	case placeholderAtP:
+		d.placeholder = numberedPlaceholder("@p")
+	default:
+		return nil, fmt.Errorf("%w: placeholder %q (want question, dollar, colon or at_p)", ErrInvalidDialect, style)
+	}
+
+	if d.deduplicate && !numbered {
+		return nil, fmt.Errorf("%w: deduplicate needs a numbered placeholder style", ErrInvalidDialect)
+	}
+
+	if d.timeoutHint != "" && !strings.Contains(d.timeoutHint, timeoutHintMillis) {
+		return nil, fmt.Errorf("%w: timeout_hint %q has no %s", ErrInvalidDialect, d.timeoutHint, timeoutHintMillis)
+	}
+
+	if cfg.TimeoutGrace != nil {
+		grace, err := time.ParseDuration(cfg.GetTimeoutGrace())
+		if err != nil || grace < 0 {
+			return nil, fmt.Errorf("%w: timeout_grace %q", ErrInvalidDialect, cfg.GetTimeoutGrace())
+		}
+
+		d.timeoutGrace = grace
+	}
+
+	if cfg.MaxBindParams != nil {
+		if cfg.GetMaxBindParams() < 1 {
+			return nil, fmt.Errorf("%w: max_bind_params %d", ErrInvalidDialect, cfg.GetMaxBindParams())
+		}
+
+		d.maxBindParams = int(cfg.GetMaxBindParams())
+	}
+
+	for _, check := range []struct {
+		field, value string
+		want         []string
+	}{
+		{"uuid_as", d.uuidAs, []string{asString, asBytes}},
+		{"time_as", d.timeAs, []string{asTime, asText, asUnix}},
+		{"decimal_as", d.decimalAs, []string{asString, asFloat}},
+		{"bool_as", d.boolAs, []string{asBool, asInt}},
+	} {
+		if !slices.Contains(check.want, check.value) {
+			return nil, fmt.Errorf("%w: %s %q (want %s)",
+				ErrInvalidDialect, check.field, check.value, strings.Join(check.want, " or "))
+		}
+	}
+
+	return d, nil
+}
+
+func numberedPlaceholder(prefix string) func(int) string {
+	return func(index int) string { return prefix + strconv.Itoa(index+1) }
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+func (d *dialect) Placeholder(index int) string { return d.placeholder(index) }
+func (d *dialect) Deduplicate() bool            { return d.deduplicate }
+
+// StatementTimeoutHint inserts the configured hint right after a leading
+// SELECT keyword. Other statements, and dialects without a hint, rely on the
+// client-side deadline alone.
+func (d *dialect) StatementTimeoutHint(sql string, timeout time.Duration) (string, bool) {
+	if d.timeoutHint == "" || timeout < time.Millisecond {
+		return sql, false
+	}
+
+	selectEnd, ok := selectKeywordEnd(sql)
+	if !ok {
+		return sql, false
+	}
+
+	hint := strings.ReplaceAll(d.timeoutHint, timeoutHintMillis, strconv.FormatInt(timeout.Milliseconds(), 10))
+	if strings.Contains(sql, hint) {
+		return sql, true
+	}
+
+	return sql[:selectEnd] + " " + hint + sql[selectEnd:], true
+}
+
+// StatementDeadline pads the client-side deadline by the configured grace
+// when a hint bounds the statement server-side, so the server error arrives
+// before the client cancels and discards the connection.
+func (d *dialect) StatementDeadline(timeout time.Duration) time.Duration {
+	if d.timeoutHint == "" || timeout < time.Millisecond {
+		return timeout
+	}
+
+	return timeout + d.timeoutGrace
+}
+
+func selectKeywordEnd(sql string) (int, bool) {
+	const keyword = "SELECT"
+
+	start := len(sql) - len(strings.TrimLeft(sql, " \t\r\n"))
+	end := start + len(keyword)
+
+	if end > len(sql) || !strings.EqualFold(sql[start:end], keyword) {
+		return 0, false
+	}
+
+	if end < len(sql) && isIdentifierByte(sql[end]) {
+		return 0, false
+	}
+
+	return end, true
+}
+
+func isIdentifierByte(char byte) bool {
+	return char >= 'a' && char <= 'z' ||
+		char >= 'A' && char <= 'Z' ||
+		char >= '0' && char <= '9' ||
+		char == '_' || char == '$' || char >= 0x80
+}
+
+func (d *dialect) Convert(val any) (any, error) {
+	switch v := val.(type) { //nolint:varnamelen // switch type assertion idiom
+	case nil:
+		return nil, nil //nolint:nilnil // allow to set nil in db
+	case uuid.UUID:
+		if d.uuidAs == asBytes {
+			return v[:], nil
+		}
+
+		return v.String(), nil
+	case time.Time:
+		return d.convertTime(v), nil
+	case *time.Time:
+		if v == nil {
+			return nil, nil //nolint:nilnil // allow to set nil in db
+		}
+
+		return d.convertTime(*v), nil
+	case decimal.Decimal:
+		return d.convertDecimal(v), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return nil, nil //nolint:nilnil // allow to set nil in db
+		}
+
+		return d.convertDecimal(*v), nil
+	case bool:
+		if d.boolAs == asInt {
+			if v {
+				return int64(1), nil
+			}
+
+			return int64(0), nil
+		}
+
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func (d *dialect) convertTime(v time.Time) any {
+	switch d.timeAs {
+	case asText:
+		return v.UTC().Format(d.timeLayout)
+	case asUnix:
+		return v.Unix()
+	default:
+		return v
+	}
+}
+
+func (d *dialect) convertDecimal(v decimal.Decimal) any {
+	if d.decimalAs == asFloat {
+		return v.InexactFloat64()
+	}
+
+	return v.String()
+}
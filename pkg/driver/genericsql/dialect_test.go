@@ -0,0 +1,167 @@
+package genericsql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/proto"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+func TestNewDialectRejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *stroppy.DriverConfig_GenericSqlConfig
+	}{
+		{"placeholder", &stroppy.DriverConfig_GenericSqlConfig{Placeholder: proto.String("percent")}},
+		{"deduplicate question", &stroppy.DriverConfig_GenericSqlConfig{Deduplicate: proto.Bool(true)}},
+		{"hint without ms", &stroppy.DriverConfig_GenericSqlConfig{TimeoutHint: proto.String("/*+ TIMEOUT */")}},
+		{"grace", &stroppy.DriverConfig_GenericSqlConfig{TimeoutGrace: proto.String("soon")}},
+		{"max bind params", &stroppy.DriverConfig_GenericSqlConfig{MaxBindParams: proto.Int32(0)}},
+		{"uuid_as", &stroppy.DriverConfig_GenericSqlConfig{UuidAs: proto.String("binary")}},
+		{"time_as", &stroppy.DriverConfig_GenericSqlConfig{TimeAs: proto.String("epoch")}},
+	}
+
+	for _, tt := range tests {
+		if _, err := newDialect(tt.cfg); !errors.Is(err, ErrInvalidDialect) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, ErrInvalidDialect)
+		}
+	}
+}
+
+func TestDialectPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	for style, want := range map[string]string{
+		"":         "?",
+		"question": "?",
+		"dollar":   "$3",
+		"colon":    ":3",
+		"at_p":     "@p3",
+	} {
+		d, err := newDialect(&stroppy.DriverConfig_GenericSqlConfig{Placeholder: proto.String(style)})
+		if err != nil {
+			t.Fatalf("%q: %v", style, err)
+		}
+
+		if got := d.Placeholder(2); got != want {
+			t.Errorf("%q: Placeholder(2) = %q, want %q", style, got, want)
+		}
+	}
+}
+
+func TestDialectTimeoutHint(t *testing.T) {
+	t.Parallel()
+
+	d, err := newDialect(&stroppy.DriverConfig_GenericSqlConfig{
+		TimeoutHint:  proto.String("/*+ MAX_EXECUTION_TIME({ms}) */"),
+		TimeoutGrace: proto.String("250ms"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, active := d.StatementTimeoutHint("  select 1", 1500*time.Millisecond)
+	if want := "  select /*+ MAX_EXECUTION_TIME(1500) */ 1"; got != want || !active {
+		t.Fatalf("hinted = %q, %v; want %q, true", got, active, want)
+	}
+
+	if again, active := d.StatementTimeoutHint(got, 1500*time.Millisecond); again != got || !active {
+		t.Fatalf("re-hinted = %q, %v; want unchanged and active", again, active)
+	}
+
+	for _, sql := range []string{"UPDATE t SET a = 1", "SELECTED", "WITH x AS (SELECT 1) SELECT * FROM x"} {
+		if got, active := d.StatementTimeoutHint(sql, time.Second); got != sql || active {
+			t.Errorf("%q: hinted = %q, %v; want unchanged", sql, got, active)
+		}
+	}
+
+	if got := d.StatementDeadline(time.Second); got != 1250*time.Millisecond {
+		t.Fatalf("deadline = %s, want 1.25s", got)
+	}
+
+	plain, err := newDialect(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, active := plain.StatementTimeoutHint("SELECT 1", time.Second); got != "SELECT 1" || active {
+		t.Fatalf("no hint configured: %q, %v", got, active)
+	}
+
+	if got := plain.StatementDeadline(time.Second); got != time.Second {
+		t.Fatalf("no hint configured: deadline %s", got)
+	}
+}
+
+func TestDialectConvert(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("0190a6f0-7b4c-7d2e-8f00-0123456789ab")
+	at := time.Date(2026, 1, 2, 3, 4, 5, 600000000, time.UTC)
+	price := decimal.RequireFromString("12.50")
+
+	native, err := newDialect(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, err := newDialect(&stroppy.DriverConfig_GenericSqlConfig{
+		UuidAs:    proto.String("bytes"),
+		TimeAs:    proto.String("text"),
+		DecimalAs: proto.String("float"),
+		BoolAs:    proto.String("int"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unix, err := newDialect(&stroppy.DriverConfig_GenericSqlConfig{TimeAs: proto.String("unix")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		d    *dialect
+		in   any
+		want any
+	}{
+		{"uuid string", native, id, id.String()},
+		{"time native", native, at, at},
+		{"time pointer", native, &at, at},
+		{"decimal string", native, price, "12.5"},
+		{"bool native", native, true, true},
+		{"nil decimal pointer", native, (*decimal.Decimal)(nil), nil},
+		{"time text", converted, at, "2026-01-02 03:04:05.6"},
+		{"decimal float", converted, &price, 12.5},
+		{"bool int", converted, false, int64(0)},
+		{"time unix", unix, at, at.Unix()},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.d.Convert(tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("%s: Convert = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+
+	raw, err := converted.Convert(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b, ok := raw.([]byte); !ok || uuid.UUID(b) != id {
+		t.Fatalf("uuid bytes = %#v", raw)
+	}
+}
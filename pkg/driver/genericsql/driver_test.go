@@ -0,0 +1,113 @@
+package genericsql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	_ "modernc.org/sqlite" // links the "sqlite" database/sql driver
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/drivertest"
+)
+
+func newTestDriver(t *testing.T, cfg *stroppy.DriverConfig_GenericSqlConfig) *Driver {
+	t.Helper()
+
+	url := "sqlite://" + filepath.Join(t.TempDir(), "generic.db") + "?_pragma=busy_timeout(5000)&_pragma=synchronous(OFF)"
+
+	d, err := NewDriver(context.Background(), driver.Options{Config: &stroppy.DriverConfig{
+		Url:        url,
+		GenericSql: cfg,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = d.Teardown(context.Background()) })
+
+	return d
+}
+
+func TestParseURL(t *testing.T) {
+	t.Parallel()
+
+	name, dsn, err := parseURL("sqlite://file:x.db?mode=memory")
+	if err != nil || name != "sqlite" || dsn != "file:x.db?mode=memory" {
+		t.Fatalf("parseURL = %q, %q, %v", name, dsn, err)
+	}
+
+	if _, _, err := parseURL("x.db"); !errors.Is(err, ErrInvalidURL) {
+		t.Fatalf("no scheme: err = %v, want %v", err, ErrInvalidURL)
+	}
+
+	if _, _, err := parseURL("nosuchdb://localhost"); !errors.Is(err, ErrDriverNotFound) {
+		t.Fatalf("unlinked driver: err = %v, want %v", err, ErrDriverNotFound)
+	}
+}
+
+// TestBindParamChunking checks PLAIN_BULK splits its INSERTs at
+// max_bind_params and that the native method, which has no generic form, is
+// refused. The insert methods themselves are covered by the sqlite driver's
+// tests over the same database/sql layer.
+func TestBindParamChunking(t *testing.T) {
+	t.Parallel()
+
+	// 2 columns x 100 bind params caps PLAIN_BULK at 50 rows per INSERT.
+	d := newTestDriver(t, &stroppy.DriverConfig_GenericSqlConfig{
+		TimeAs:        proto.String("text"),
+		MaxBindParams: proto.Int32(100),
+	})
+	drivertest.CheckInsert(t, d, driver.InsertPlainBulk, 1, 3000)
+
+	_, err := newTestDriver(t, nil).Insert(context.Background(), &driver.InsertRequest{
+		Table: "t", Method: driver.InsertNative, Workers: 1, Source: drivertest.SeqSource(1),
+	})
+	if !errors.Is(err, driver.ErrInsertMethodNotSupported) {
+		t.Fatalf("native insert error = %v, want %v", err, driver.ErrInsertMethodNotSupported)
+	}
+}
+
+func TestNumberedDeduplicatedParams(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDriver(t, &stroppy.DriverConfig_GenericSqlConfig{
+		Placeholder: proto.String("dollar"),
+		Deduplicate: proto.Bool(true),
+		BoolAs:      proto.String("int"),
+	})
+
+	drivertest.Exec(t, d, "CREATE TABLE kv (k INTEGER, v INTEGER, flag INTEGER)", nil)
+	drivertest.Exec(t, d, "INSERT INTO kv VALUES (:k, :k * 10, :flag)", map[string]any{"k": 7, "flag": true})
+
+	got := drivertest.QueryInt(t, d, "SELECT v + flag FROM kv WHERE k = :k AND v = :k * 10", map[string]any{"k": 7})
+	if got != 71 {
+		t.Fatalf("v + flag = %d, want 71", got)
+	}
+}
+
+func TestClassifySQLiteResultCode(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDriver(t, &stroppy.DriverConfig_GenericSqlConfig{
+		ErrorCodes: []*stroppy.DriverConfig_GenericSqlConfig_ErrorCode{
+			// SQLITE_CONSTRAINT_PRIMARYKEY, read from sqlite.Error.Code().
+			{Code: "1555", Kind: "unsupported"},
+		},
+	})
+
+	drivertest.Exec(t, d, "CREATE TABLE u (id INTEGER PRIMARY KEY)", nil)
+	drivertest.Exec(t, d, "INSERT INTO u VALUES (1)", nil)
+
+	_, err := d.RunQuery(context.Background(), "INSERT INTO u VALUES (1)", nil)
+	if err == nil {
+		t.Fatal("duplicate key inserted")
+	}
+
+	if got := d.ClassifyError(err).Kind; got != driver.ErrorKindUnsupported {
+		t.Fatalf("ClassifyError(%v) = %s, want %s", err, got, driver.ErrorKindUnsupported)
+	}
+}
@@ -0,0 +1,156 @@
+package genericsql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+// errorRule is one compiled GenericSqlConfig.ErrorCode entry.
+type errorRule struct {
+	code    string
+	message string
+	facts   driver.ErrorFacts
+}
+
+func newErrorRules(cfg *stroppy.DriverConfig_GenericSqlConfig) ([]errorRule, error) {
+	rules := make([]errorRule, 0, len(cfg.GetErrorCodes()))
+
+	for i, entry := range cfg.GetErrorCodes() {
+		if entry.GetCode() == "" && entry.GetMessage() == "" {
+			return nil, fmt.Errorf("%w: error_codes[%d] sets neither code nor message", ErrInvalidDialect, i)
+		}
+
+		kind, err := driver.ParseErrorKind(entry.GetKind())
+		if err != nil {
+			return nil, fmt.Errorf("%w: error_codes[%d]: %w", ErrInvalidDialect, i, err)
+		}
+
+		rules = append(rules, errorRule{
+			code:    entry.GetCode(),
+			message: entry.GetMessage(),
+			facts:   driver.ErrorFacts{Kind: kind, Backoff: entry.GetBackoff()},
+		})
+	}
+
+	return rules, nil
+}
+
+// ClassifyError applies the configured error_codes rules in order and falls
+// back to [driver.DefaultErrorFacts] when none matches.
+func (d *Driver) ClassifyError(err error) driver.ErrorFacts {
+	if err == nil || len(d.errorRules) == 0 {
+		return driver.DefaultErrorFacts(err)
+	}
+
+	codes := errorCodes(err)
+	text := err.Error()
+
+	for _, rule := range d.errorRules {
+		if rule.code != "" && !containsCode(codes, rule.code) {
+			continue
+		}
+
+		if rule.message != "" && !strings.Contains(text, rule.message) {
+			continue
+		}
+
+		return rule.facts
+	}
+
+	return driver.DefaultErrorFacts(err)
+}
+
+func containsCode(codes []string, code string) bool {
+	for _, candidate := range codes {
+		if strings.EqualFold(candidate, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// codeAccessors are the methods and fields database/sql drivers expose their
+// error codes through: SQLSTATE (pgconn.PgError.Code, mysql.MySQLError.SQLState),
+// vendor numbers (mysql.MySQLError.Number, mssql.Error.Number) and result
+// codes (sqlite.Error.Code()).
+var codeAccessors = []string{"SQLState", "Code", "Number"}
+
+// errorCodes collects the codes of every error in the chain of err, each
+// rendered as text.
+func errorCodes(err error) []string {
+	var codes []string
+
+	walkErrors(err, func(e error) {
+		value := reflect.ValueOf(e)
+
+		for _, name := range codeAccessors {
+			if method := value.MethodByName(name); method.IsValid() &&
+				method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+				codes = appendCode(codes, method.Call(nil)[0])
+			}
+
+			elem := value
+			for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+
+			if elem.Kind() == reflect.Struct {
+				if field := elem.FieldByName(name); field.IsValid() && field.CanInterface() {
+					codes = appendCode(codes, field)
+				}
+			}
+		}
+	})
+
+	return codes
+}
+
+func walkErrors(err error, visit func(error)) {
+	if err == nil {
+		return
+	}
+
+	visit(err)
+
+	switch wrapped := err.(type) { //nolint:errorlint // walking the chain by hand
+	case interface{ Unwrap() []error }:
+		for _, cause := range wrapped.Unwrap() {
+			walkErrors(cause, visit)
+		}
+	default:
+		walkErrors(errors.Unwrap(err), visit)
+	}
+}
+
+func appendCode(codes []string, value reflect.Value) []string {
+	switch value.Kind() { //nolint:exhaustive // other kinds carry no code
+	case reflect.String:
+		if value.String() != "" {
+			return append(codes, value.String())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return append(codes, strconv.FormatInt(value.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(codes, strconv.FormatUint(value.Uint(), 10))
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			raw := make([]byte, value.Len())
+			for i := range raw {
+				raw[i] = byte(value.Index(i).Uint())
+			}
+
+			if code := strings.TrimRight(string(raw), "\x00"); code != "" {
+				return append(codes, code)
+			}
+		}
+	}
+
+	return codes
+}
@@ -0,0 +1,120 @@
+package genericsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+// stateError exposes its SQLSTATE through a method, like pgconn.PgError.
+type stateError struct{ state string }
+
+func (e *stateError) Error() string    { return "state " + e.state }
+func (e *stateError) SQLState() string { return e.state }
+
+// numberError exposes a vendor number and a byte-array SQLSTATE through
+// fields, like mysql.MySQLError.
+type numberError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *numberError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+func newClassifier(t *testing.T, codes ...*stroppy.DriverConfig_GenericSqlConfig_ErrorCode) *Driver {
+	t.Helper()
+
+	rules, err := newErrorRules(&stroppy.DriverConfig_GenericSqlConfig{ErrorCodes: codes})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Driver{errorRules: rules}
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	d := newClassifier(t,
+		&stroppy.DriverConfig_GenericSqlConfig_ErrorCode{Code: "40001", Kind: "serialization"},
+		&stroppy.DriverConfig_GenericSqlConfig_ErrorCode{Code: "1213", Kind: "deadlock"},
+		&stroppy.DriverConfig_GenericSqlConfig_ErrorCode{Code: "1205", Kind: "lock_timeout", Backoff: true},
+		&stroppy.DriverConfig_GenericSqlConfig_ErrorCode{Code: "HY000", Message: "try restarting", Kind: "transient"},
+		&stroppy.DriverConfig_GenericSqlConfig_ErrorCode{Message: "connection reset", Kind: "transient"},
+	)
+
+	tests := []struct {
+		name string
+		err  error
+		want driver.ErrorFacts
+	}{
+		{
+			"sqlstate method",
+			fmt.Errorf("commit: %w", &stateError{state: "40001"}),
+			driver.ErrorFacts{Kind: driver.ErrorKindSerialization},
+		},
+		{
+			"number field",
+			&numberError{Number: 1213, SQLState: [5]byte{'4', '0', '0', '0', '1'}},
+			driver.ErrorFacts{Kind: driver.ErrorKindSerialization},
+		},
+		{
+			"number field after sqlstate miss",
+			&numberError{Number: 1205, SQLState: [5]byte{'H', 'Y', '0', '0', '0'}},
+			driver.ErrorFacts{Kind: driver.ErrorKindLockTimeout, Backoff: true},
+		},
+		{
+			"code and message",
+			&numberError{Number: 1105, SQLState: [5]byte{'H', 'Y', '0', '0', '0'}, Message: "please try restarting"},
+			driver.ErrorFacts{Kind: driver.ErrorKindTransient},
+		},
+		{
+			"code without message",
+			&numberError{Number: 1105, SQLState: [5]byte{'H', 'Y', '0', '0', '0'}, Message: "syntax"},
+			driver.ErrorFacts{Kind: driver.ErrorKindUnknown},
+		},
+		{
+			"message only in joined error",
+			errors.Join(errors.New("query failed"), errors.New("read: connection reset by peer")),
+			driver.ErrorFacts{Kind: driver.ErrorKindTransient},
+		},
+		{
+			"default facts",
+			fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+			driver.ErrorFacts{Kind: driver.ErrorKindTimeout},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := d.ClassifyError(tt.err); got != tt.want {
+			t.Errorf("%s: ClassifyError = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNewErrorRulesRejects(t *testing.T) {
+	t.Parallel()
+
+	for _, entry := range []*stroppy.DriverConfig_GenericSqlConfig_ErrorCode{
+		{Kind: "deadlock"},
+		{Code: "1213", Kind: "retry"},
+	} {
+		_, err := newErrorRules(&stroppy.DriverConfig_GenericSqlConfig{
+			ErrorCodes: []*stroppy.DriverConfig_GenericSqlConfig_ErrorCode{entry},
+		})
+		if !errors.Is(err, ErrInvalidDialect) {
+			t.Errorf("%v: err = %v, want %v", entry, err, ErrInvalidDialect)
+		}
+	}
+
+	if _, err := newErrorRules(&stroppy.DriverConfig_GenericSqlConfig{
+		ErrorCodes: []*stroppy.DriverConfig_GenericSqlConfig_ErrorCode{{Code: "5", Kind: "deadlock"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,85 @@
+package genericsql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/datagen/source"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/common"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver"
+	"github.com/stroppy-io/stroppy/pkg/driver/stats"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// Insert runs a typed [driver.InsertRequest] through the generic driver.
+// Each worker drains its [gen.Cursor] partition through multi-row INSERTs.
+func (d *Driver) Insert(
+	ctx context.Context,
+	req *driver.InsertRequest,
+) (*stats.Query, error) {
+	if err := driver.ValidateInsert(req); err != nil {
+		return nil, err
+	}
+
+	if !genericMethodSupported(req.Method) {
+		return nil, fmt.Errorf("%w: %s", driver.ErrInsertMethodNotSupported, req.Method)
+	}
+
+	workers := req.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	columns := req.Source.Schema().ColumnNames()
+	start := time.Now()
+
+	rows, err := common.RunParallelBatch(ctx, req.Source, workers, d.bulkSize,
+		func(workerCtx context.Context, _ common.Chunk, cur gen.Cursor) error {
+			src := common.NewBatchRowSource(cur, columns, len(columns))
+
+			return d.runInsertChunk(workerCtx, req.Table, req.Method, src)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats.Query{Elapsed: time.Since(start), Rows: rows}, nil
+}
+
+// genericMethodSupported reports whether the generic driver serves method.
+// NATIVE and COLUMNAR need an engine-specific bulk path database/sql does
+// not expose.
+func genericMethodSupported(method driver.InsertMethod) bool {
+	switch method {
+	case driver.InsertPlainBulk, driver.InsertPlainQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// runInsertChunk drains one partition per method. PLAIN_BULK batches are
+// capped so one statement stays within max_bind_params; PLAIN_QUERY is the
+// same path with batchSize=1.
+func (d *Driver) runInsertChunk(
+	ctx context.Context,
+	table string,
+	method driver.InsertMethod,
+	src source.RowSource,
+) error {
+	switch method {
+	case driver.InsertPlainBulk:
+		batchSize := d.bulkSize
+		if columns := len(src.Columns()); columns > 0 {
+			batchSize = max(min(batchSize, d.dialect.maxBindParams/columns), 1)
+		}
+
+		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, batchSize, d.queryTimeout)
+	case driver.InsertPlainQuery:
+		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, 1, d.queryTimeout)
+	default:
+		return fmt.Errorf("%w: %s", driver.ErrInsertMethodNotSupported, method)
+	}
+}
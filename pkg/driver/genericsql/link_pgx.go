@@ -0,0 +1,5 @@
+//go:build generic_sql_pgx
+
+package genericsql
+
+import _ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
@@ -53,6 +53,15 @@ var insertMethodsByDriver = map[stroppy.DriverConfig_DriverType][]InsertMethod{
 	stroppy.DriverConfig_DRIVER_TYPE_CSV: {
 		InsertNative,
 	},
+	stroppy.DriverConfig_DRIVER_TYPE_SQLITE: {
+		InsertPlainQuery,
+		InsertPlainBulk,
+		InsertNative,
+	},
+	stroppy.DriverConfig_DRIVER_TYPE_GENERIC_SQL: {
+		InsertPlainQuery,
+		InsertPlainBulk,
+	},
 }
 
 // InsertCapabilities returns the driver→insert-method matrix ordered by
@@ -16,6 +16,10 @@ const (
 	StagePostgresBulkInsertExec = "postgres_bulk_insert_exec"
 	// StagePostgresColumnarExec means the PostgreSQL driver is executing an unnest INSERT.
 	StagePostgresColumnarExec = "postgres_columnar_exec"
+	// StageMySQLLoadData means the MySQL driver is inside LOAD DATA LOCAL INFILE.
+	StageMySQLLoadData = "mysql_load_data"
+	// StageSQLitePreparedInsert means the SQLite driver is running a prepared-statement transaction.
+	StageSQLitePreparedInsert = "sqlite_prepared_insert"
 	// StageYDBBulkUpsert means the YDB driver is flushing a BulkUpsert batch.
 	StageYDBBulkUpsert = "ydb_bulk_upsert"
 	// StageCSVWrite means the CSV driver is writing generated rows to a shard file.
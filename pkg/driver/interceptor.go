@@ -0,0 +1,238 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/stroppy-io/stroppy/pkg/common/logger"
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver/stats"
+)
+
+type (
+	// Query is one statement passed down an interceptor chain. Tx is the
+	// driver transaction the statement runs in, nil outside a transaction.
+	Query struct {
+		SQL  string
+		Args map[string]any
+		Tx   Tx
+	}
+
+	QueryHandler    func(ctx context.Context, query Query) (*QueryResult, error)
+	BeginHandler    func(ctx context.Context, isolation stroppy.TxIsolationLevel) (Tx, error)
+	CommitHandler   func(ctx context.Context, tx Tx) error
+	InsertHandler   func(ctx context.Context, req *InsertRequest) (*stats.Query, error)
+	ClassifyHandler func(err error) ErrorFacts
+
+	// Interceptor wraps the calls of a [Driver] and of the transactions it
+	// begins. Each hook runs the call itself through next and may observe,
+	// change or short-circuit it; a nil hook passes the call through.
+	// RunQuery sees statements run on the driver and inside transactions
+	// alike, Commit sees the transaction being committed. ClassifyError lets
+	// an interceptor classify the errors it returns itself.
+	Interceptor struct {
+		Name string
+
+		RunQuery func(ctx context.Context, query Query, next QueryHandler) (*QueryResult, error)
+		Begin    func(ctx context.Context, isolation stroppy.TxIsolationLevel, next BeginHandler) (Tx, error)
+		Commit   func(ctx context.Context, tx Tx, next CommitHandler) error
+		Insert   func(ctx context.Context, req *InsertRequest, next InsertHandler) (*stats.Query, error)
+
+		ClassifyError func(err error, next ClassifyHandler) ErrorFacts
+
+		// Teardown runs after the driver tore down, e.g. to report what the
+		// interceptor collected.
+		Teardown func(ctx context.Context) error
+	}
+
+	interceptorFactory = func(cfg *stroppy.DriverConfig_Interceptor, lg *zap.Logger) (Interceptor, error)
+)
+
+var ErrUnknownInterceptor = errors.New("unknown interceptor")
+
+var interceptorRegistry = map[protoreflect.Name]interceptorFactory{}
+
+// RegisterInterceptor makes an interceptor available to driver configs under
+// kind, the name of its DriverConfig.Interceptor oneof field.
+func RegisterInterceptor(kind protoreflect.Name, factory interceptorFactory) {
+	interceptorRegistry[kind] = factory
+}
+
+// NewInterceptors builds the interceptors a driver config lists, in order.
+func NewInterceptors(configs []*stroppy.DriverConfig_Interceptor, lg *zap.Logger) ([]Interceptor, error) {
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	if lg == nil {
+		lg = logger.NewFromEnv()
+	}
+
+	lg = lg.Named("interceptor")
+	interceptors := make([]Interceptor, 0, len(configs))
+
+	for i, cfg := range configs {
+		msg := cfg.ProtoReflect()
+
+		field := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("kind"))
+		if field == nil {
+			return nil, fmt.Errorf("interceptor %d: %w: none set", i, ErrUnknownInterceptor)
+		}
+
+		factory, ok := interceptorRegistry[field.Name()]
+		if !ok {
+			return nil, fmt.Errorf("interceptor %d: %w %q", i, ErrUnknownInterceptor, field.Name())
+		}
+
+		interceptor, err := factory(cfg, lg)
+		if err != nil {
+			return nil, fmt.Errorf("interceptor %d (%s): %w", i, field.Name(), err)
+		}
+
+		if interceptor.Name == "" {
+			interceptor.Name = string(field.Name())
+		}
+
+		interceptors = append(interceptors, interceptor)
+	}
+
+	return interceptors, nil
+}
+
+// Intercept wraps drv in interceptors, the first one outermost. Without
+// interceptors drv is returned as is.
+func Intercept(drv Driver, interceptors ...Interceptor) Driver {
+	if len(interceptors) == 0 {
+		return drv
+	}
+
+	d := &interceptedDriver{
+		inner: drv,
+		chain: interceptors,
+		runQuery: func(ctx context.Context, query Query) (*QueryResult, error) {
+			if query.Tx != nil {
+				return query.Tx.RunQuery(ctx, query.SQL, query.Args)
+			}
+
+			return drv.RunQuery(ctx, query.SQL, query.Args)
+		},
+		begin:    drv.Begin,
+		commit:   func(ctx context.Context, tx Tx) error { return tx.Commit(ctx) },
+		insert:   drv.Insert,
+		classify: drv.ClassifyError,
+	}
+
+	// Compose inside out so the first interceptor runs first.
+	for _, interceptor := range slices.Backward(interceptors) {
+		if hook := interceptor.RunQuery; hook != nil {
+			next := d.runQuery
+			d.runQuery = func(ctx context.Context, query Query) (*QueryResult, error) {
+				return hook(ctx, query, next)
+			}
+		}
+
+		if hook := interceptor.Begin; hook != nil {
+			next := d.begin
+			d.begin = func(ctx context.Context, isolation stroppy.TxIsolationLevel) (Tx, error) {
+				return hook(ctx, isolation, next)
+			}
+		}
+
+		if hook := interceptor.Commit; hook != nil {
+			next := d.commit
+			d.commit = func(ctx context.Context, tx Tx) error {
+				return hook(ctx, tx, next)
+			}
+		}
+
+		if hook := interceptor.Insert; hook != nil {
+			next := d.insert
+			d.insert = func(ctx context.Context, req *InsertRequest) (*stats.Query, error) {
+				return hook(ctx, req, next)
+			}
+		}
+
+		if hook := interceptor.ClassifyError; hook != nil {
+			next := d.classify
+			d.classify = func(err error) ErrorFacts {
+				return hook(err, next)
+			}
+		}
+	}
+
+	return d
+}
+
+// interceptedDriver holds each call's composed chain; the innermost handler
+// calls the wrapped driver, or the driver transaction for a query or commit
+// inside one.
+type interceptedDriver struct {
+	inner Driver
+	chain []Interceptor
+
+	runQuery QueryHandler
+	begin    BeginHandler
+	commit   CommitHandler
+	insert   InsertHandler
+	classify ClassifyHandler
+}
+
+var _ Driver = (*interceptedDriver)(nil)
+
+func (d *interceptedDriver) RunQuery(ctx context.Context, sql string, args map[string]any) (*QueryResult, error) {
+	return d.runQuery(ctx, Query{SQL: sql, Args: args})
+}
+
+func (d *interceptedDriver) Begin(ctx context.Context, isolation stroppy.TxIsolationLevel) (Tx, error) {
+	tx, err := d.begin(ctx, isolation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interceptedTx{inner: tx, drv: d}, nil
+}
+
+func (d *interceptedDriver) Insert(ctx context.Context, req *InsertRequest) (*stats.Query, error) {
+	return d.insert(ctx, req)
+}
+
+func (d *interceptedDriver) ClassifyError(err error) ErrorFacts {
+	return d.classify(err)
+}
+
+// Teardown tears the driver down, then runs the interceptors' Teardown hooks.
+func (d *interceptedDriver) Teardown(ctx context.Context) error {
+	errs := []error{d.inner.Teardown(ctx)}
+
+	for _, interceptor := range d.chain {
+		if interceptor.Teardown != nil {
+			if err := interceptor.Teardown(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("interceptor %s: %w", interceptor.Name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type interceptedTx struct {
+	inner Tx
+	drv   *interceptedDriver
+}
+
+var _ Tx = (*interceptedTx)(nil)
+
+func (tx *interceptedTx) RunQuery(ctx context.Context, sql string, args map[string]any) (*QueryResult, error) {
+	return tx.drv.runQuery(ctx, Query{SQL: sql, Args: args, Tx: tx.inner})
+}
+
+func (tx *interceptedTx) Commit(ctx context.Context) error { return tx.drv.commit(ctx, tx.inner) }
+
+func (tx *interceptedTx) Rollback(ctx context.Context) error { return tx.inner.Rollback(ctx) }
+
+func (tx *interceptedTx) Isolation() stroppy.TxIsolationLevel { return tx.inner.Isolation() }
@@ -0,0 +1,291 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/proto"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver/stats"
+)
+
+// recordingDriver logs the calls that reach it, prefixed "tx:" inside a
+// transaction.
+type recordingDriver struct {
+	calls    []string
+	queryErr error
+	torn     bool
+}
+
+func (d *recordingDriver) RunQuery(_ context.Context, sql string, _ map[string]any) (*QueryResult, error) {
+	d.calls = append(d.calls, sql)
+
+	return &QueryResult{}, d.queryErr
+}
+
+func (d *recordingDriver) Begin(context.Context, stroppy.TxIsolationLevel) (Tx, error) {
+	d.calls = append(d.calls, "begin")
+
+	return &recordingTx{drv: d}, nil
+}
+
+func (d *recordingDriver) Insert(context.Context, *InsertRequest) (*stats.Query, error) {
+	d.calls = append(d.calls, "insert")
+
+	return &stats.Query{}, nil
+}
+
+func (d *recordingDriver) ClassifyError(err error) ErrorFacts { return DefaultErrorFacts(err) }
+
+func (d *recordingDriver) Teardown(context.Context) error {
+	d.torn = true
+
+	return nil
+}
+
+type recordingTx struct{ drv *recordingDriver }
+
+func (tx *recordingTx) RunQuery(_ context.Context, sql string, _ map[string]any) (*QueryResult, error) {
+	tx.drv.calls = append(tx.drv.calls, "tx:"+sql)
+
+	return &QueryResult{}, nil
+}
+
+func (tx *recordingTx) Commit(context.Context) error {
+	tx.drv.calls = append(tx.drv.calls, "commit")
+
+	return nil
+}
+
+func (tx *recordingTx) Rollback(context.Context) error {
+	tx.drv.calls = append(tx.drv.calls, "rollback")
+
+	return nil
+}
+
+func (tx *recordingTx) Isolation() stroppy.TxIsolationLevel {
+	return stroppy.TxIsolationLevel_SERIALIZABLE
+}
+
+func tagging(name string, trace *[]string) Interceptor {
+	return Interceptor{
+		Name: name,
+		RunQuery: func(ctx context.Context, query Query, next QueryHandler) (*QueryResult, error) {
+			*trace = append(*trace, name+":"+query.SQL)
+
+			return next(ctx, query)
+		},
+		Commit: func(ctx context.Context, tx Tx, next CommitHandler) error {
+			*trace = append(*trace, name+":commit")
+
+			return next(ctx, tx)
+		},
+		Teardown: func(context.Context) error {
+			*trace = append(*trace, name+":teardown")
+
+			return nil
+		},
+	}
+}
+
+func TestInterceptChainOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	inner := &recordingDriver{}
+
+	var trace []string
+
+	drv := Intercept(inner, tagging("a", &trace), tagging("b", &trace))
+
+	_, err := drv.RunQuery(ctx, "select 1", nil)
+	require.NoError(t, err)
+
+	tx, err := drv.Begin(ctx, stroppy.TxIsolationLevel_SERIALIZABLE)
+	require.NoError(t, err)
+
+	_, err = tx.RunQuery(ctx, "update t", nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+	require.Equal(t, stroppy.TxIsolationLevel_SERIALIZABLE, tx.Isolation())
+
+	_, err = drv.Insert(ctx, &InsertRequest{})
+	require.NoError(t, err)
+	require.NoError(t, drv.Teardown(ctx))
+
+	require.Equal(t, []string{
+		"a:select 1", "b:select 1",
+		"a:update t", "b:update t",
+		"a:commit", "b:commit",
+		"a:teardown", "b:teardown",
+	}, trace)
+	require.Equal(t, []string{"select 1", "begin", "tx:update t", "commit", "insert"}, inner.calls)
+	require.True(t, inner.torn)
+}
+
+func TestInterceptShortCircuit(t *testing.T) {
+	t.Parallel()
+
+	inner := &recordingDriver{}
+	injected := errors.New("injected")
+
+	drv := Intercept(inner, Interceptor{
+		Commit: func(context.Context, Tx, CommitHandler) error { return injected },
+	})
+
+	tx, err := drv.Begin(context.Background(), stroppy.TxIsolationLevel_SERIALIZABLE)
+	require.NoError(t, err)
+	require.ErrorIs(t, tx.Commit(context.Background()), injected)
+	require.NoError(t, tx.Rollback(context.Background()))
+	require.Equal(t, []string{"begin", "rollback"}, inner.calls)
+}
+
+func TestInterceptWithoutInterceptors(t *testing.T) {
+	t.Parallel()
+
+	inner := &recordingDriver{}
+	require.Same(t, Driver(inner), Intercept(inner))
+}
+
+func TestNewInterceptors(t *testing.T) {
+	t.Parallel()
+
+	interceptors, err := NewInterceptors(nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, interceptors)
+
+	interceptors, err = NewInterceptors([]*stroppy.DriverConfig_Interceptor{
+		{Kind: &stroppy.DriverConfig_Interceptor_SlowQuery_{SlowQuery: &stroppy.DriverConfig_Interceptor_SlowQuery{}}},
+		{Kind: &stroppy.DriverConfig_Interceptor_StatementCounter_{}},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, interceptors, 2)
+	require.Equal(t, InterceptorSlowQuery, interceptors[0].Name)
+	require.Equal(t, InterceptorStatementCounter, interceptors[1].Name)
+
+	_, err = NewInterceptors([]*stroppy.DriverConfig_Interceptor{{}}, zap.NewNop())
+	require.ErrorIs(t, err, ErrUnknownInterceptor)
+
+	_, err = NewInterceptors([]*stroppy.DriverConfig_Interceptor{
+		{Kind: &stroppy.DriverConfig_Interceptor_SlowQuery_{SlowQuery: &stroppy.DriverConfig_Interceptor_SlowQuery{
+			Threshold: proto.String("soon"),
+		}}},
+	}, zap.NewNop())
+	require.ErrorIs(t, err, ErrInvalidInterceptor)
+
+	_, err = NewInterceptors([]*stroppy.DriverConfig_Interceptor{
+		{Kind: &stroppy.DriverConfig_Interceptor_SqlSample_{SqlSample: &stroppy.DriverConfig_Interceptor_SqlSample{
+			Rate: proto.Float64(2),
+		}}},
+	}, zap.NewNop())
+	require.ErrorIs(t, err, ErrInvalidInterceptor)
+
+	_, err = NewInterceptors([]*stroppy.DriverConfig_Interceptor{
+		{Kind: &stroppy.DriverConfig_Interceptor_StatementCounter_{
+			StatementCounter: &stroppy.DriverConfig_Interceptor_StatementCounter{Top: proto.Int32(-1)},
+		}},
+	}, zap.NewNop())
+	require.ErrorIs(t, err, ErrInvalidInterceptor)
+}
+
+func TestSlowQueryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	slow, err := NewSlowQueryInterceptor(&stroppy.DriverConfig_Interceptor_SlowQuery{
+		Threshold: proto.String("0s"),
+		LogArgs:   proto.Bool(true),
+	}, zap.New(core))
+	require.NoError(t, err)
+
+	drv := Intercept(&recordingDriver{}, slow)
+
+	_, err = drv.RunQuery(context.Background(), "select :id", map[string]any{"id": 1})
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("Slow query").All()
+	require.Len(t, entries, 1)
+	require.Equal(t, "select :id", entries[0].ContextMap()["sql"])
+	require.Contains(t, entries[0].ContextMap(), "args")
+
+	fast, err := NewSlowQueryInterceptor(nil, zap.New(core))
+	require.NoError(t, err)
+
+	_, err = Intercept(&recordingDriver{}, fast).RunQuery(context.Background(), "select 1", nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.FilterMessage("Slow query").Len())
+}
+
+func TestSQLSampleInterceptor(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	sample, err := NewSQLSampleInterceptor(&stroppy.DriverConfig_Interceptor_SqlSample{
+		Rate:            proto.Float64(1),
+		MaxPerStatement: proto.Int32(2),
+	}, zap.New(core))
+	require.NoError(t, err)
+
+	drv := Intercept(&recordingDriver{}, sample)
+
+	for i := range 5 {
+		_, err = drv.RunQuery(context.Background(), "select "+strings.Repeat("1", i+1), nil)
+		require.NoError(t, err)
+	}
+
+	_, err = drv.RunQuery(context.Background(), "select 'x'", nil)
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("SQL sample").All()
+	require.Len(t, entries, 2, "one fingerprint, capped at max_per_statement")
+	require.Equal(t, "select ?", entries[0].ContextMap()["fingerprint"])
+}
+
+func TestStatementCounterInterceptor(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	core, logs := observer.New(zapcore.InfoLevel)
+	inner := &recordingDriver{}
+
+	counter, err := NewStatementCounterInterceptor(&stroppy.DriverConfig_Interceptor_StatementCounter{
+		Top: proto.Int32(1),
+	}, zap.New(core))
+	require.NoError(t, err)
+
+	drv := Intercept(inner, counter)
+
+	for _, id := range []string{"1", "2", "3"} {
+		_, err := drv.RunQuery(ctx, "SELECT * FROM t WHERE id = "+id, nil)
+		require.NoError(t, err)
+	}
+
+	tx, err := drv.Begin(ctx, stroppy.TxIsolationLevel_SERIALIZABLE)
+	require.NoError(t, err)
+
+	_, err = tx.RunQuery(ctx, "UPDATE t SET x = 1", nil)
+	require.NoError(t, err)
+
+	inner.queryErr = errors.New("boom")
+	_, err = drv.RunQuery(ctx, "select * from t where id = 4", nil)
+	require.Error(t, err)
+
+	require.NoError(t, drv.Teardown(ctx))
+
+	require.EqualValues(t, 2, logs.FilterMessage("Statement counts").All()[0].ContextMap()["fingerprints"])
+
+	top := logs.FilterMessage("Statement").All()
+	require.Len(t, top, 1)
+	require.Equal(t, "select * from t where id = ?", top[0].ContextMap()["fingerprint"])
+	require.Equal(t, int64(4), top[0].ContextMap()["calls"])
+	require.Equal(t, int64(1), top[0].ContextMap()["errors"])
+}
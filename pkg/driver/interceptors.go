@@ -0,0 +1,276 @@
+package driver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+)
+
+// Built-in interceptor kinds, the DriverConfig.Interceptor oneof field names.
+const (
+	InterceptorSlowQuery        = "slow_query"
+	InterceptorSQLSample        = "sql_sample"
+	InterceptorStatementCounter = "statement_counter"
+)
+
+const (
+	defaultSlowQueryThreshold = time.Second
+	defaultSampleRate         = 0.01
+	defaultSamplesPerStmt     = 10
+	defaultCounterTop         = 20
+)
+
+var ErrInvalidInterceptor = errors.New("invalid interceptor config")
+
+func init() {
+	RegisterInterceptor(InterceptorSlowQuery, func(cfg *stroppy.DriverConfig_Interceptor, lg *zap.Logger) (Interceptor, error) {
+		return NewSlowQueryInterceptor(cfg.GetSlowQuery(), lg)
+	})
+	RegisterInterceptor(InterceptorSQLSample, func(cfg *stroppy.DriverConfig_Interceptor, lg *zap.Logger) (Interceptor, error) {
+		return NewSQLSampleInterceptor(cfg.GetSqlSample(), lg)
+	})
+	RegisterInterceptor(InterceptorStatementCounter, func(cfg *stroppy.DriverConfig_Interceptor, lg *zap.Logger) (Interceptor, error) {
+		return NewStatementCounterInterceptor(cfg.GetStatementCounter(), lg)
+	})
+}
+
+// NewSlowQueryInterceptor logs every statement that takes at least the
+// configured threshold, on the driver or inside a transaction.
+func NewSlowQueryInterceptor(cfg *stroppy.DriverConfig_Interceptor_SlowQuery, lg *zap.Logger) (Interceptor, error) {
+	if cfg == nil {
+		cfg = &stroppy.DriverConfig_Interceptor_SlowQuery{}
+	}
+
+	threshold := defaultSlowQueryThreshold
+
+	if cfg.Threshold != nil {
+		parsed, err := time.ParseDuration(cfg.GetThreshold())
+		if err != nil || parsed < 0 {
+			return Interceptor{}, fmt.Errorf("%w: slow_query threshold %q", ErrInvalidInterceptor, cfg.GetThreshold())
+		}
+
+		threshold = parsed
+	}
+
+	lg = lg.Named(InterceptorSlowQuery)
+	logArgs := cfg.GetLogArgs()
+
+	return Interceptor{
+		Name: InterceptorSlowQuery,
+		RunQuery: func(ctx context.Context, query Query, next QueryHandler) (*QueryResult, error) {
+			start := time.Now()
+			res, err := next(ctx, query)
+
+			if elapsed := time.Since(start); elapsed >= threshold {
+				lg.Warn("Slow query", queryFields(query, logArgs, elapsed, err)...)
+			}
+
+			return res, err
+		},
+	}, nil
+}
+
+// NewSQLSampleInterceptor logs the SQL text of a random fraction of
+// executions, at most max_per_statement times per fingerprint, so every
+// distinct statement of a mix shows up without logging each execution.
+func NewSQLSampleInterceptor(cfg *stroppy.DriverConfig_Interceptor_SqlSample, lg *zap.Logger) (Interceptor, error) {
+	if cfg == nil {
+		cfg = &stroppy.DriverConfig_Interceptor_SqlSample{}
+	}
+
+	rate := defaultSampleRate
+	if cfg.Rate != nil {
+		rate = cfg.GetRate()
+	}
+
+	if rate < 0 || rate > 1 {
+		return Interceptor{}, fmt.Errorf("%w: sql_sample rate %v not in [0, 1]", ErrInvalidInterceptor, rate)
+	}
+
+	perStatement := int64(defaultSamplesPerStmt)
+	if cfg.MaxPerStatement != nil {
+		perStatement = int64(cfg.GetMaxPerStatement())
+	}
+
+	lg = lg.Named(InterceptorSQLSample)
+	logArgs := cfg.GetLogArgs()
+	fingerprints := &fingerprintCache{}
+
+	var taken sync.Map // fingerprint -> *atomic.Int64
+
+	return Interceptor{
+		Name: InterceptorSQLSample,
+		RunQuery: func(ctx context.Context, query Query, next QueryHandler) (*QueryResult, error) {
+			if rate == 0 || rand.Float64() >= rate { //nolint:gosec // sampling, not security
+				return next(ctx, query)
+			}
+
+			fingerprint := fingerprints.get(query.SQL)
+
+			counter, _ := taken.LoadOrStore(fingerprint, &atomic.Int64{})
+
+			n := counter.(*atomic.Int64).Add(1) //nolint:forcetypeassert // only counters are stored
+			if perStatement > 0 && n > perStatement {
+				return next(ctx, query)
+			}
+
+			start := time.Now()
+			res, err := next(ctx, query)
+
+			fields := append(queryFields(query, logArgs, time.Since(start), err), zap.String("fingerprint", fingerprint))
+			lg.Info("SQL sample", fields...)
+
+			return res, err
+		},
+	}, nil
+}
+
+// StatementStat is what the statement counter collected for one fingerprint.
+type StatementStat struct {
+	Fingerprint string
+	Calls       int64
+	Errors      int64
+	Elapsed     time.Duration
+}
+
+// NewStatementCounterInterceptor counts statements, errors and time spent per
+// normalized SQL fingerprint and logs the busiest fingerprints at teardown.
+func NewStatementCounterInterceptor(
+	cfg *stroppy.DriverConfig_Interceptor_StatementCounter,
+	lg *zap.Logger,
+) (Interceptor, error) {
+	if cfg == nil {
+		cfg = &stroppy.DriverConfig_Interceptor_StatementCounter{}
+	}
+
+	top := defaultCounterTop
+	if cfg.Top != nil {
+		top = int(cfg.GetTop())
+	}
+
+	if top < 0 {
+		return Interceptor{}, fmt.Errorf("%w: statement_counter top %d is negative", ErrInvalidInterceptor, top)
+	}
+
+	lg = lg.Named(InterceptorStatementCounter)
+	counter := &statementCounter{}
+
+	return Interceptor{
+		Name: InterceptorStatementCounter,
+		RunQuery: func(ctx context.Context, query Query, next QueryHandler) (*QueryResult, error) {
+			start := time.Now()
+			res, err := next(ctx, query)
+			counter.add(query.SQL, time.Since(start), err)
+
+			return res, err
+		},
+		Teardown: func(context.Context) error {
+			stats := counter.snapshot()
+
+			lg.Info("Statement counts", zap.Int("fingerprints", len(stats)))
+
+			for _, stat := range stats[:min(top, len(stats))] {
+				lg.Info("Statement",
+					zap.String("fingerprint", stat.Fingerprint),
+					zap.Int64("calls", stat.Calls),
+					zap.Int64("errors", stat.Errors),
+					zap.Duration("mean", stat.Elapsed/time.Duration(stat.Calls)),
+				)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+type statementCounter struct {
+	fingerprints fingerprintCache
+
+	mu    sync.Mutex
+	stats map[string]*StatementStat
+}
+
+func (c *statementCounter) add(sql string, elapsed time.Duration, err error) {
+	fingerprint := c.fingerprints.get(sql)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stats == nil {
+		c.stats = make(map[string]*StatementStat)
+	}
+
+	stat, ok := c.stats[fingerprint]
+	if !ok {
+		stat = &StatementStat{Fingerprint: fingerprint}
+		c.stats[fingerprint] = stat
+	}
+
+	stat.Calls++
+	stat.Elapsed += elapsed
+
+	if err != nil {
+		stat.Errors++
+	}
+}
+
+// snapshot returns the collected stats, most executed first.
+func (c *statementCounter) snapshot() []StatementStat {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make([]StatementStat, 0, len(c.stats))
+	for _, fingerprint := range slices.Sorted(maps.Keys(c.stats)) {
+		stats = append(stats, *c.stats[fingerprint])
+	}
+
+	slices.SortStableFunc(stats, func(a, b StatementStat) int { return cmp.Compare(b.Calls, a.Calls) })
+
+	return stats
+}
+
+// fingerprintCache memoizes Fingerprint by SQL text; workloads run the same
+// few statement strings over and over.
+type fingerprintCache struct {
+	byText sync.Map // sql -> fingerprint
+}
+
+func (c *fingerprintCache) get(sql string) string {
+	if fingerprint, ok := c.byText.Load(sql); ok {
+		return fingerprint.(string) //nolint:forcetypeassert // only strings are stored
+	}
+
+	fingerprint := Fingerprint(sql)
+	c.byText.Store(sql, fingerprint)
+
+	return fingerprint
+}
+
+func queryFields(query Query, logArgs bool, elapsed time.Duration, err error) []zap.Field {
+	fields := []zap.Field{
+		zap.Duration("elapsed", elapsed),
+		zap.Bool("in_tx", query.Tx != nil),
+		zap.String("sql", query.SQL),
+	}
+
+	if logArgs {
+		fields = append(fields, zap.Any("args", query.Args))
+	}
+
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	return fields
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"sync"
 	"time"
 
 	gomysql "github.com/go-sql-driver/mysql"
@@ -37,6 +38,10 @@ type Driver struct {
 	sqlCfg       *stroppy.DriverConfig_SqlConfig
 	bulkSize     int
 	queryTimeout time.Duration
+	loc          *time.Location // session time zone for LOAD DATA's DATETIME text
+
+	localInfileOnce sync.Once
+	localInfile     bool
 }
 
 var _ driver.Driver = (*Driver)(nil)
@@ -52,7 +57,7 @@ func NewDriver(
 
 	cfg := opts.Config
 
-	connector, err := prepareConnector(cfg, opts.DialFunc, lg)
+	connector, mysqlCfg, err := prepareConnector(cfg, opts.DialFunc, lg)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +93,7 @@ func NewDriver(
 		sqlCfg:       sqlCfg,
 		bulkSize:     bulkSize,
 		queryTimeout: opts.QueryTimeout,
+		loc:          mysqlCfg.Loc,
 	}, nil
 }
 
@@ -95,10 +101,10 @@ func prepareConnector(
 	driverCfg *stroppy.DriverConfig,
 	dialFunc func(ctx context.Context, network, addr string) (net.Conn, error),
 	lg *zap.Logger,
-) (godriver.Connector, error) {
+) (godriver.Connector, *gomysql.Config, error) {
 	mysqlCfg, err := gomysql.ParseDSN(driverCfg.GetUrl())
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse mysql DSN: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse mysql DSN: %w", err)
 	}
 
 	applySecurityOverrides(lg, mysqlCfg, driverCfg)
@@ -113,10 +119,10 @@ func prepareConnector(
 
 	connector, err := gomysql.NewConnector(mysqlCfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create mysql connector: %w", err)
+		return nil, nil, fmt.Errorf("failed to create mysql connector: %w", err)
 	}
 
-	return connector, nil
+	return connector, mysqlCfg, nil
 }
 
 // applySecurityOverrides applies proto-level security fields to the mysql
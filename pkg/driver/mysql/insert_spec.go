@@ -51,8 +51,7 @@ func (d *Driver) Insert(
 }
 
 // mysqlMethodSupported reports whether the mysql driver serves method.
-// NATIVE collapses to PLAIN_BULK; COLUMNAR is not served (mysql has no
-// array-unnest primitive).
+// COLUMNAR is not served (mysql has no array-unnest primitive).
 func mysqlMethodSupported(method driver.InsertMethod) bool {
 	switch method {
 	case driver.InsertNative, driver.InsertPlainBulk, driver.InsertPlainQuery:
@@ -62,10 +61,11 @@ func mysqlMethodSupported(method driver.InsertMethod) bool {
 	}
 }
 
-// runInsertChunk drains one partition into mysql per method. PLAIN_QUERY
-// degrades to a bulk path with batchSize=1 so both arms share one
-// codepath. The bound-parameter cap (65535) is applied centrally in
-// sqldriver.RunBulkInsert.
+// runInsertChunk drains one partition into mysql per method. NATIVE streams
+// through LOAD DATA LOCAL INFILE, or takes the PLAIN_BULK path when the
+// server has local_infile off. PLAIN_QUERY degrades to a bulk path with
+// batchSize=1 so both arms share one codepath. The bound-parameter cap
+// (65535) is applied centrally in sqldriver.RunBulkInsert.
 func (d *Driver) runInsertChunk(
 	ctx context.Context,
 	table string,
@@ -73,7 +73,13 @@ func (d *Driver) runInsertChunk(
 	src source.RowSource,
 ) error {
 	switch method {
-	case driver.InsertNative, driver.InsertPlainBulk:
+	case driver.InsertNative:
+		if d.nativeInsertEnabled(ctx) {
+			return d.loadDataRuntime(ctx, table, src)
+		}
+
+		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, d.bulkSize, d.queryTimeout)
+	case driver.InsertPlainBulk:
 		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, d.bulkSize, d.queryTimeout)
 	case driver.InsertPlainQuery:
 		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, 1, d.queryTimeout)
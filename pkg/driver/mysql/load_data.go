@@ -0,0 +1,423 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+
+	"github.com/stroppy-io/stroppy/pkg/datagen/source"
+	"github.com/stroppy-io/stroppy/pkg/driver/insertprogress"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver/queries"
+)
+
+// loadDataStatementRows caps the rows one LOAD DATA statement streams. A
+// partition can hold hundreds of millions of rows; bounding the statement
+// keeps each InnoDB transaction, and each per-statement timeout, the size of
+// a batch rather than of the whole table.
+const loadDataStatementRows = 100_000
+
+// loadDataBufferSize is the encoded bytes the reader keeps ahead of the
+// driver's 16 KiB packet reads.
+const loadDataBufferSize = 64 << 10
+
+// readerSeq names the per-statement reader handlers, which go-sql-driver
+// keeps in one process-wide registry.
+var readerSeq atomic.Uint64
+
+// nativeInsertEnabled reports whether the server accepts LOAD DATA LOCAL.
+// MySQL 8 ships with local_infile OFF; NATIVE then degrades to the multi-row
+// INSERT path instead of failing every load. Checked once per driver.
+func (d *Driver) nativeInsertEnabled(ctx context.Context) bool {
+	d.localInfileOnce.Do(func() {
+		var enabled int
+
+		err := d.db.QueryRowContext(ctx, "SELECT @@GLOBAL.local_infile").Scan(&enabled)
+
+		switch {
+		case err != nil:
+			d.logger.Warn("cannot read local_infile; NATIVE inserts fall back to multi-row INSERT", zap.Error(err))
+		case enabled == 0:
+			d.logger.Warn("local_infile is OFF on the server; NATIVE inserts fall back to multi-row INSERT " +
+				"(SET GLOBAL local_infile = 1 enables LOAD DATA)")
+		default:
+			d.localInfile = true
+		}
+	})
+
+	return d.localInfile
+}
+
+// loadDataRuntime drains src into table through LOAD DATA LOCAL INFILE,
+// streaming the rows as tab-separated text from a registered reader handler
+// in statements of up to loadDataStatementRows rows. Like every LOCAL load,
+// the server downgrades row errors such as duplicate keys to warnings;
+// execLoadData turns them back into an error.
+func (d *Driver) loadDataRuntime(ctx context.Context, table string, src source.RowSource) error {
+	columns := src.Columns()
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: table %q", sqldriver.ErrEmptyColumnOrder, table)
+	}
+
+	reader := newTSVReader(src, d.dialect, d.loc, loadDataStatementRows)
+	reader.progress = insertprogress.NewGeneratedRowCounter(ctx)
+
+	defer reader.progress.Flush()
+
+	name := "stroppy_" + strconv.FormatUint(readerSeq.Add(1), 10)
+	gomysql.RegisterReaderHandler(name, func() io.Reader { return reader })
+
+	defer gomysql.DeregisterReaderHandler(name)
+
+	query := buildLoadDataQuery(name, table, columns)
+
+	for {
+		if err := insertprogress.Canceled(ctx); err != nil {
+			return err
+		}
+
+		insertprogress.SetStage(ctx, insertprogress.StageRuntimeNext)
+
+		more, err := reader.next()
+		if err != nil {
+			return fmt.Errorf("mysql: source.Next %q: %w", table, err)
+		}
+
+		if !more {
+			return nil
+		}
+
+		if err := d.execLoadData(ctx, table, query, reader); err != nil {
+			return err
+		}
+	}
+}
+
+// execLoadData runs one LOAD DATA statement in its own transaction and
+// commits it only if the server kept every row it was sent. LOCAL turns row
+// errors into warnings, so the statement alone succeeds past skipped
+// duplicates and truncated values; the affected row count and SHOW WARNINGS,
+// read inside the transaction, catch both. A source error ends the streamed
+// file early, and the rollback drops the rows it already carried, so a
+// failed statement loads nothing; the statements committed before it stay.
+func (d *Driver) execLoadData(ctx context.Context, table, query string, reader *tsvReader) error {
+	stmtCtx, cancel := sqldriver.StatementTimeout(ctx, d.queryTimeout)
+	defer cancel()
+
+	tx, err := d.db.BeginTx(stmtCtx, nil)
+	if err != nil {
+		return fmt.Errorf("mysql: LOAD DATA %q: begin: %w", table, err)
+	}
+
+	// Rolls back an uncommitted statement; a no-op after Commit.
+	defer func() { _ = tx.Rollback() }()
+
+	insertprogress.SetStage(ctx, insertprogress.StageMySQLLoadData)
+
+	start := time.Now()
+
+	res, err := tx.ExecContext(stmtCtx, query)
+	reader.progress.Flush()
+
+	if reader.err != nil {
+		return fmt.Errorf("mysql: source.Next %q: %w", table, reader.err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("mysql: LOAD DATA %q: %w", table, err)
+	}
+
+	loaded, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mysql: LOAD DATA %q: %w", table, err)
+	}
+
+	warnings, err := loadDataWarnings(stmtCtx, tx)
+	if err != nil {
+		return fmt.Errorf("mysql: SHOW WARNINGS after LOAD DATA %q: %w", table, err)
+	}
+
+	if err := checkLoadData(loaded, reader.rows, warnings); err != nil {
+		return fmt.Errorf("mysql: LOAD DATA %q: %w", table, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("mysql: LOAD DATA %q: commit: %w", table, err)
+	}
+
+	insertprogress.AddConfirmed(ctx, loaded)
+	insertprogress.AddBatch(ctx, loaded, time.Since(start))
+
+	return nil
+}
+
+// ErrLoadDataRowsRejected reports a LOAD DATA statement that succeeded while
+// the server skipped or altered rows, downgrading the errors to warnings.
+var ErrLoadDataRowsRejected = errors.New("server rejected rows")
+
+// loadDataWarningLimit caps the warnings quoted in a rejected load's error.
+const loadDataWarningLimit = 3
+
+// loadDataWarnings reads the Warning and Error rows the last statement of tx
+// left behind. Notes are informational and skipped.
+func loadDataWarnings(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SHOW WARNINGS LIMIT "+strconv.Itoa(loadDataWarningLimit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var warnings []string
+
+	for rows.Next() {
+		var (
+			level, message string
+			code           int
+		)
+
+		if err := rows.Scan(&level, &code, &message); err != nil {
+			return nil, err
+		}
+
+		if level != "Note" {
+			warnings = append(warnings, fmt.Sprintf("%s %d: %s", level, code, message))
+		}
+	}
+
+	return warnings, rows.Err()
+}
+
+// checkLoadData fails a statement that loaded fewer rows than it sent or left
+// warnings behind.
+func checkLoadData(loaded, sent int64, warnings []string) error {
+	if loaded == sent && len(warnings) == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %d of %d rows loaded", ErrLoadDataRowsRejected, loaded, sent)
+	if len(warnings) > 0 {
+		err = fmt.Errorf("%w (%s)", err, strings.Join(warnings, "; "))
+	}
+
+	return err
+}
+
+// buildLoadDataQuery spells the separators as hex literals so the statement
+// means the same under NO_BACKSLASH_ESCAPES. CHARACTER SET binary loads the
+// streamed bytes unconverted: []byte values reach binary columns exactly as
+// generated, and text, which the reader writes as UTF-8, lands as is in the
+// utf8mb4 columns the workloads declare.
+func buildLoadDataQuery(reader, table string, columns []string) string {
+	return "LOAD DATA LOCAL INFILE 'Reader::" + reader + "' INTO TABLE " + table +
+		" CHARACTER SET binary FIELDS TERMINATED BY X'09' ESCAPED BY X'5C' LINES TERMINATED BY X'0A' (" +
+		strings.Join(columns, ", ") + ")"
+}
+
+// tsvReader encodes rows from a source.RowSource into LOAD DATA's default
+// text format on demand: tab-separated fields, newline-terminated lines, \N
+// for NULL and backslash escapes for the bytes that would break a field. The
+// driver pulls it from inside ExecContext, so no goroutine or full-batch
+// buffer sits between the generator and the socket.
+//
+// One statement reads at most limit rows; next readies the following one.
+type tsvReader struct {
+	src      source.RowSource
+	dialect  queries.Dialect
+	loc      *time.Location
+	limit    int64
+	progress insertprogress.RowCounter
+
+	pending []any // row pulled by next, not yet encoded
+	rows    int64 // rows encoded in the current statement
+	eof     bool
+	buf     []byte
+	off     int
+	err     error // source or conversion failure, reported after the statement
+}
+
+func newTSVReader(src source.RowSource, dialect queries.Dialect, loc *time.Location, limit int64) *tsvReader {
+	return &tsvReader{
+		src:     src,
+		dialect: dialect,
+		loc:     loc,
+		limit:   limit,
+		buf:     make([]byte, 0, loadDataBufferSize),
+	}
+}
+
+// next starts a statement: it pulls the first row and reports whether there
+// is one.
+func (r *tsvReader) next() (bool, error) {
+	r.rows = 0
+	r.buf = r.buf[:0]
+	r.off = 0
+
+	if r.eof {
+		return false, nil
+	}
+
+	row, err := r.src.Next()
+	if errors.Is(err, io.EOF) {
+		r.eof = true
+
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	r.pending = row
+
+	return true, nil
+}
+
+// Read fills p with encoded rows and returns io.EOF once the statement's rows
+// are out. A source error ends the stream early; the caller reports it.
+func (r *tsvReader) Read(p []byte) (int, error) {
+	for len(r.buf)-r.off < len(p) {
+		if !r.fill() {
+			break
+		}
+	}
+
+	n := copy(p, r.buf[r.off:])
+	r.off += n
+
+	if r.off == len(r.buf) {
+		r.buf = r.buf[:0]
+		r.off = 0
+
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+
+	return n, nil
+}
+
+// fill encodes one more row into buf, reporting false when the statement has
+// no rows left.
+func (r *tsvReader) fill() bool {
+	if r.err != nil || r.eof || r.rows >= r.limit {
+		return false
+	}
+
+	row := r.pending
+	r.pending = nil
+
+	if row == nil {
+		next, err := r.src.Next()
+		if errors.Is(err, io.EOF) {
+			r.eof = true
+
+			return false
+		}
+
+		if err != nil {
+			r.err = err
+
+			return false
+		}
+
+		row = next
+	}
+
+	line := len(r.buf)
+
+	for i, value := range row {
+		if i > 0 {
+			r.buf = append(r.buf, '\t')
+		}
+
+		converted, err := r.dialect.Convert(value)
+		if err != nil {
+			r.buf = r.buf[:line] // never send half a row
+			r.err = fmt.Errorf("column %d: %w", i, err)
+
+			return false
+		}
+
+		r.buf = appendTSVValue(r.buf, converted, r.loc)
+	}
+
+	r.buf = append(r.buf, '\n')
+	r.rows++
+	r.progress.Add(1)
+
+	return true
+}
+
+// mysqlTimeLayout is the DATETIME text go-sql-driver sends for time.Time
+// arguments.
+const mysqlTimeLayout = "2006-01-02 15:04:05.999999"
+
+func appendTSVValue(buf []byte, value any, loc *time.Location) []byte {
+	switch v := value.(type) { //nolint:varnamelen // switch type assertion idiom
+	case nil:
+		return append(buf, `\N`...)
+	case string:
+		return appendTSVEscaped(buf, v)
+	case []byte:
+		return appendTSVEscaped(buf, v)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float64:
+		return strconv.AppendFloat(buf, v, 'g', -1, 64)
+	case float32:
+		return strconv.AppendFloat(buf, float64(v), 'g', -1, 32)
+	case bool:
+		if v {
+			return append(buf, '1')
+		}
+
+		return append(buf, '0')
+	case time.Time:
+		if loc != nil {
+			v = v.In(loc)
+		}
+
+		return v.AppendFormat(buf, mysqlTimeLayout)
+	default:
+		return appendTSVEscaped(buf, fmt.Sprint(v))
+	}
+}
+
+// appendTSVEscaped writes s with the escapes LOAD DATA reverses under
+// ESCAPED BY '\': the escape byte itself, the field and line terminators,
+// carriage return and NUL. Every other byte, binary or not, passes through.
+func appendTSVEscaped[T string | []byte](buf []byte, s T) []byte {
+	for i := range len(s) {
+		switch c := s[i]; c {
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case 0:
+			buf = append(buf, '\\', '0')
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return buf
+}
@@ -0,0 +1,245 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// sliceSource is a source.RowSource over fixed rows. Like the runtime
+// adapters it hands out one reused scratch slice.
+type sliceSource struct {
+	columns []string
+	rows    [][]any
+	scratch []any
+	err     error // returned once rows run out, instead of io.EOF
+}
+
+func (s *sliceSource) Columns() []string { return s.columns }
+
+func (s *sliceSource) Next() ([]any, error) {
+	if len(s.rows) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		return nil, io.EOF
+	}
+
+	s.scratch = append(s.scratch[:0], s.rows[0]...)
+	s.rows = s.rows[1:]
+
+	return s.scratch, nil
+}
+
+// readStatements drains r the way the LOAD DATA loop does, stopping at a
+// reader error, and returns the text each statement streamed.
+func readStatements(t *testing.T, r *tsvReader) []string {
+	t.Helper()
+
+	var statements []string
+
+	for {
+		more, err := r.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !more {
+			return statements
+		}
+
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		statements = append(statements, string(data))
+
+		if r.err != nil {
+			return statements
+		}
+	}
+}
+
+func TestTSVReaderEncoding(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 890000000, time.FixedZone("X", 3600))
+
+	src := &sliceSource{
+		columns: []string{"a", "b", "c", "d", "e", "f"},
+		rows: [][]any{
+			{int64(-7), 2.5, true, "plain", nil, at},
+			{int64(1), float64(1e21), false, "tab\there\nnl\\bs\rcr\x00nul", "", []byte{0xff, '\t', 0x01}},
+			{int64(2), decimal.RequireFromString("12.30"), nil, `\N`, "ünï", "x"},
+		},
+	}
+
+	got := readStatements(t, newTSVReader(src, mysqlDialect{}, time.UTC, 100))
+
+	want := "-7\t2.5\t1\tplain\t\\N\t2026-03-04 04:06:07.89\n" +
+		"1\t1e+21\t0\ttab\\there\\nnl\\\\bs\\rcr\\0nul\t\t\xff\\t\x01\n" +
+		"2\t12.3\t\\N\t\\\\N\tünï\tx\n"
+
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("encoded = %q, want %q", got, want)
+	}
+}
+
+func TestTSVReaderSplitsStatements(t *testing.T) {
+	src := &sliceSource{columns: []string{"id"}}
+	for i := range 5 {
+		src.rows = append(src.rows, []any{int64(i)})
+	}
+
+	got := readStatements(t, newTSVReader(src, mysqlDialect{}, nil, 2))
+
+	want := []string{"0\n1\n", "2\n3\n", "4\n"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("statements = %q, want %q", got, want)
+	}
+}
+
+func TestTSVReaderStopsOnSourceError(t *testing.T) {
+	errBroken := errors.New("broken generator")
+	src := &sliceSource{columns: []string{"id"}, rows: [][]any{{int64(1)}}, err: errBroken}
+	r := newTSVReader(src, mysqlDialect{}, nil, 100)
+
+	if got := readStatements(t, r); len(got) != 1 || got[0] != "1\n" {
+		t.Fatalf("statements = %q, want the rows before the error", got)
+	}
+
+	if !errors.Is(r.err, errBroken) {
+		t.Fatalf("reader error = %v, want %v", r.err, errBroken)
+	}
+}
+
+func TestBuildLoadDataQuery(t *testing.T) {
+	got := buildLoadDataQuery("stroppy_1", "item", []string{"i_id", "i_name"})
+	want := "LOAD DATA LOCAL INFILE 'Reader::stroppy_1' INTO TABLE item CHARACTER SET binary " +
+		"FIELDS TERMINATED BY X'09' ESCAPED BY X'5C' LINES TERMINATED BY X'0A' (i_id, i_name)"
+
+	if got != want {
+		t.Fatalf("query = %q, want %q", got, want)
+	}
+}
+
+func TestCheckLoadData(t *testing.T) {
+	t.Parallel()
+
+	if err := checkLoadData(5, 5, nil); err != nil {
+		t.Fatalf("all rows loaded: %v", err)
+	}
+
+	err := checkLoadData(4, 5, nil)
+	if !errors.Is(err, ErrLoadDataRowsRejected) || !strings.Contains(err.Error(), "4 of 5 rows loaded") {
+		t.Fatalf("short load: err = %v", err)
+	}
+
+	err = checkLoadData(5, 5, []string{"Warning 1265: Data truncated for column 'c' at row 2"})
+	if !errors.Is(err, ErrLoadDataRowsRejected) || !strings.Contains(err.Error(), "Data truncated") {
+		t.Fatalf("warned load: err = %v", err)
+	}
+}
+
+func TestRealMySQLLoadDataRejectsDuplicates(t *testing.T) {
+	d := realMySQLTimeoutDriver(t, 0)
+
+	if !d.nativeInsertEnabled(context.Background()) {
+		t.Skip("local_infile is OFF on the test server")
+	}
+
+	execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data_dup")
+	execRealMySQL(t, d, "CREATE TABLE stroppy_load_data_dup (id BIGINT PRIMARY KEY)")
+	t.Cleanup(func() { execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data_dup") })
+
+	src := &sliceSource{columns: []string{"id"}, rows: [][]any{{int64(1)}, {int64(2)}, {int64(1)}}}
+
+	err := d.loadDataRuntime(context.Background(), "stroppy_load_data_dup", src)
+	if !errors.Is(err, ErrLoadDataRowsRejected) {
+		t.Fatalf("duplicate key: err = %v, want %v", err, ErrLoadDataRowsRejected)
+	}
+}
+
+// TestRealMySQLLoadDataRollsBackFailedStatement checks that a source error
+// midway through a statement loads none of its rows while the statements
+// committed before it stay.
+func TestRealMySQLLoadDataRollsBackFailedStatement(t *testing.T) {
+	d := realMySQLTimeoutDriver(t, 0)
+
+	if !d.nativeInsertEnabled(context.Background()) {
+		t.Skip("local_infile is OFF on the test server")
+	}
+
+	execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data_partial")
+	execRealMySQL(t, d, "CREATE TABLE stroppy_load_data_partial (id BIGINT PRIMARY KEY)")
+	t.Cleanup(func() { execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data_partial") })
+
+	errBroken := errors.New("broken generator")
+	src := &sliceSource{columns: []string{"id"}, err: errBroken}
+
+	for i := range loadDataStatementRows + 10 {
+		src.rows = append(src.rows, []any{int64(i)})
+	}
+
+	if err := d.loadDataRuntime(context.Background(), "stroppy_load_data_partial", src); !errors.Is(err, errBroken) {
+		t.Fatalf("LOAD DATA error = %v, want %v", err, errBroken)
+	}
+
+	var count int64
+	if err := d.db.QueryRowContext(context.Background(),
+		"SELECT COUNT(*) FROM stroppy_load_data_partial").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+
+	if count != loadDataStatementRows {
+		t.Fatalf("count = %d, want the %d rows of the first statement only", count, loadDataStatementRows)
+	}
+}
+
+func TestRealMySQLLoadDataRoundTrip(t *testing.T) {
+	d := realMySQLTimeoutDriver(t, 0)
+
+	if !d.nativeInsertEnabled(context.Background()) {
+		t.Skip("local_infile is OFF on the test server")
+	}
+
+	execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data")
+	execRealMySQL(t, d, "CREATE TABLE stroppy_load_data (id BIGINT PRIMARY KEY, note VARCHAR(64) NULL, raw BLOB)")
+	t.Cleanup(func() { execRealMySQL(t, d, "DROP TABLE IF EXISTS stroppy_load_data") })
+
+	tricky := "a\tb\nc\\d\re\x00f"
+	src := &sliceSource{columns: []string{"id", "note", "raw"}}
+
+	for i := range 2*loadDataStatementRows + 3 {
+		src.rows = append(src.rows, []any{int64(i), tricky, []byte{0, 0xff, '\n'}})
+	}
+
+	src.rows[1][1] = nil
+
+	if err := d.loadDataRuntime(context.Background(), "stroppy_load_data", src); err != nil {
+		t.Fatalf("LOAD DATA: %v", err)
+	}
+
+	var (
+		count    int64
+		nulls    int64
+		matching int64
+	)
+
+	err := d.db.QueryRowContext(context.Background(),
+		"SELECT COUNT(*), SUM(note IS NULL), SUM(note = ? AND raw = ?) FROM stroppy_load_data",
+		tricky, []byte{0, 0xff, '\n'},
+	).Scan(&count, &nulls, &matching)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := int64(2*loadDataStatementRows + 3); count != want || nulls != 1 || matching != want-1 {
+		t.Fatalf("count=%d nulls=%d matching=%d, want %d rows, 1 NULL, the rest round-tripped", count, nulls, matching, want)
+	}
+}
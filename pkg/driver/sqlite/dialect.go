@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver/queries"
+)
+
+var _ queries.Dialect = sqliteDialect{}
+
+// timeLayout is the text form SQLite's date and time functions read and
+// write, so stored times compare and sort with CURRENT_TIMESTAMP, date() and
+// datetime() results.
+const timeLayout = "2006-01-02 15:04:05.999999999"
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Placeholder(_ int) string { return "?" }
+func (sqliteDialect) Deduplicate() bool        { return false }
+
+// StatementTimeoutHint returns sql unchanged; modernc.org/sqlite interrupts
+// the statement when its context expires.
+func (sqliteDialect) StatementTimeoutHint(sql string, _ time.Duration) (string, bool) {
+	return sql, false
+}
+
+// StatementDeadline returns timeout unchanged; there is no server-side hint
+// to outlast.
+func (sqliteDialect) StatementDeadline(timeout time.Duration) time.Duration { return timeout }
+
+func (sqliteDialect) Convert(val any) (any, error) {
+	switch v := val.(type) { //nolint:varnamelen // switch type assertion idiom
+	case nil:
+		return nil, nil //nolint:nilnil // allow to set nil in db
+	case uuid.UUID:
+		return v.String(), nil
+	case time.Time:
+		return v.UTC().Format(timeLayout), nil
+	case *time.Time:
+		return v.UTC().Format(timeLayout), nil
+	case decimal.Decimal:
+		return v.String(), nil
+	case *decimal.Decimal:
+		return v.String(), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,205 @@
+// Package sqlite implements an embedded Stroppy driver on top of
+// modernc.org/sqlite, a cgo-free port of SQLite. It needs no server, so the
+// bundled workloads, validation steps included, run end to end on a laptop
+// or in CI.
+//
+// The URL is a database file path, ":memory:" or a SQLite file: URI;
+// a "sqlite://" prefix is accepted and stripped. Query parameters are passed
+// to modernc.org/sqlite (_pragma=..., _txlock=..., _time_format=...). Unless
+// the URL sets them, the driver waits up to five seconds on a locked
+// database (busy_timeout), switches file databases to the write-ahead log
+// and begins transactions IMMEDIATE so concurrent writers queue on the lock
+// instead of failing their upgrade mid-transaction.
+//
+// An in-memory database lives and dies with its connection, so the pool is
+// pinned to one connection that is never recycled.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+
+	"github.com/stroppy-io/stroppy/pkg/common/logger"
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver/queries"
+)
+
+func init() {
+	driver.RegisterDriver(
+		stroppy.DriverConfig_DRIVER_TYPE_SQLITE,
+		func(ctx context.Context, opts driver.Options) (driver.Driver, error) {
+			return NewDriver(ctx, opts)
+		},
+	)
+}
+
+const (
+	urlScheme   = "sqlite://"
+	memoryDB    = ":memory:"
+	busyTimeout = "busy_timeout(5000)"
+)
+
+type Driver struct {
+	db           *sql.DB
+	dialect      queries.Dialect
+	logger       *zap.Logger
+	bulkSize     int
+	queryTimeout time.Duration
+}
+
+var _ driver.Driver = (*Driver)(nil)
+
+func NewDriver(
+	ctx context.Context,
+	opts driver.Options,
+) (*Driver, error) {
+	lg := opts.Logger
+	if lg == nil {
+		lg = logger.NewFromEnv().Named("sqlite")
+	}
+
+	cfg := opts.Config
+
+	dsn, memory, err := buildDSN(cfg.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	if err = sqldriver.ApplySQLConfig(db, cfg.GetSql()); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("failed to apply SQL config: %w", err)
+	}
+
+	if memory {
+		// Every connection to :memory: opens its own empty database.
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+	}
+
+	lg.Debug("Checking db connection...", zap.String("dsn", dsn))
+
+	if err = sqldriver.WaitForDB(ctx, lg, &sqldriver.DBPinger{DB: db}, 0); err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	const defaultBulkSize = 2500
+
+	bulkSize := defaultBulkSize
+	if cfg.BulkSize != nil {
+		bulkSize = int(cfg.GetBulkSize())
+	}
+
+	return &Driver{
+		db:           db,
+		dialect:      sqliteDialect{},
+		logger:       lg,
+		bulkSize:     bulkSize,
+		queryTimeout: opts.QueryTimeout,
+	}, nil
+}
+
+// buildDSN turns the configured URL into a modernc.org/sqlite DSN with the
+// driver defaults filled in, and reports whether it names an in-memory
+// database. An empty URL opens a private in-memory database.
+func buildDSN(raw string) (string, bool, error) {
+	raw = strings.TrimPrefix(raw, urlScheme)
+	if raw == "" {
+		raw = memoryDB
+	}
+
+	path, rawQuery, _ := strings.Cut(raw, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse sqlite URL query: %w", err)
+	}
+
+	memory := strings.Contains(path, memoryDB) || query.Get("mode") == "memory"
+
+	if !hasPragma(query, "busy_timeout") {
+		query.Add("_pragma", busyTimeout)
+	}
+
+	if !memory && !hasPragma(query, "journal_mode") {
+		query.Add("_pragma", "journal_mode(WAL)")
+	}
+
+	if !query.Has("_txlock") {
+		query.Set("_txlock", "immediate")
+	}
+
+	return path + "?" + query.Encode(), memory, nil
+}
+
+func hasPragma(query url.Values, name string) bool {
+	for _, pragma := range query["_pragma"] {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(pragma)), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Begin opens a transaction. SQLite transactions are serializable whatever
+// level is asked for, so every level but CONNECTION_ONLY maps to one BEGIN;
+// the Tx still reports the requested level to the metrics.
+func (d *Driver) Begin(ctx context.Context, isolation stroppy.TxIsolationLevel) (driver.Tx, error) {
+	if isolation == stroppy.TxIsolationLevel_CONNECTION_ONLY {
+		conn, err := d.db.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return sqldriver.NewConnOnlyTx(conn, sqldriver.NewRows, d.dialect, d.logger, d.queryTimeout, conn.Close), nil
+	}
+
+	sqlTx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqldriver.NewTx(
+		&sqldriver.SQLTxAdapter{Tx: sqlTx},
+		sqldriver.NewRows,
+		isolation,
+		d.dialect,
+		d.logger,
+		d.queryTimeout,
+	), nil
+}
+
+func (d *Driver) RunQuery(
+	ctx context.Context,
+	sqlStr string,
+	args map[string]any,
+) (*driver.QueryResult, error) {
+	return sqldriver.RunQuery(ctx, d.db, sqldriver.NewRows, d.dialect, d.logger, sqlStr, args, d.queryTimeout)
+}
+
+func (d *Driver) Teardown(ctx context.Context) error {
+	d.logger.Debug("Driver Teardown Start")
+	err := sqldriver.Teardown(ctx, d.db)
+	d.logger.Debug("Driver Teardown End")
+
+	return err
+}
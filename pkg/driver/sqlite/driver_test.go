@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	stroppy "github.com/stroppy-io/stroppy/pkg/common/proto/stroppy"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/drivertest"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+func newTestDriver(t *testing.T, url string) *Driver {
+	t.Helper()
+
+	d, err := NewDriver(context.Background(), driver.Options{Config: &stroppy.DriverConfig{Url: url}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = d.Teardown(context.Background()) })
+
+	return d
+}
+
+func TestBuildDSN(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url        string
+		wantDSN    string
+		wantMemory bool
+	}{
+		{"", ":memory:?_pragma=busy_timeout%285000%29&_txlock=immediate", true},
+		{
+			"sqlite:///tmp/x.db",
+			"/tmp/x.db?_pragma=busy_timeout%285000%29&_pragma=journal_mode%28WAL%29&_txlock=immediate",
+			false,
+		},
+		{
+			"bench.db?_pragma=journal_mode(DELETE)&_txlock=deferred",
+			"bench.db?_pragma=journal_mode%28DELETE%29&_pragma=busy_timeout%285000%29&_txlock=deferred",
+			false,
+		},
+		{"file:mem?mode=memory&cache=shared", "file:mem?_pragma=busy_timeout%285000%29&_txlock=immediate&" +
+			"cache=shared&mode=memory", true},
+	}
+
+	for _, tt := range tests {
+		dsn, memory, err := buildDSN(tt.url)
+		if err != nil {
+			t.Fatalf("buildDSN(%q): %v", tt.url, err)
+		}
+
+		if dsn != tt.wantDSN || memory != tt.wantMemory {
+			t.Fatalf("buildDSN(%q) = %q, %v; want %q, %v", tt.url, dsn, memory, tt.wantDSN, tt.wantMemory)
+		}
+	}
+}
+
+func TestInsertPerMethod(t *testing.T) {
+	t.Parallel()
+
+	for _, method := range []driver.InsertMethod{driver.InsertPlainQuery, driver.InsertPlainBulk, driver.InsertNative} {
+		d := newTestDriver(t, filepath.Join(t.TempDir(), "insert.db"))
+		drivertest.CheckInsert(t, d, method, 4, 3000)
+	}
+}
+
+// TestNativeInsertRollsBackFailedTransaction checks that a source error
+// inside a NATIVE transaction leaves none of its rows behind while the
+// transactions committed before it stay.
+func TestNativeInsertRollsBackFailedTransaction(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewDriver(context.Background(), driver.Options{Config: &stroppy.DriverConfig{
+		Url:      filepath.Join(t.TempDir(), "native.db"),
+		BulkSize: proto.Int32(1000),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = d.Teardown(context.Background()) })
+
+	drivertest.Exec(t, d, "CREATE TABLE t (id INTEGER PRIMARY KEY)", nil)
+
+	errBroken := errors.New("broken generator")
+	b := gen.NewSchemaBuilder()
+	idCol := b.Int64("id")
+
+	src := gen.NewIndexedSource(b.Build(), gen.Root{}, "test/sqlite-native@1", 3000, 64,
+		func(r gen.Row, entity uint64) error {
+			if entity == 1500 {
+				return errBroken
+			}
+
+			r.SetInt64(idCol, int64(entity)) //nolint:gosec // G115: bounded by the row count
+
+			return nil
+		})
+
+	_, err = d.Insert(context.Background(), &driver.InsertRequest{
+		Table: "t", Method: driver.InsertNative, Workers: 1, Source: src,
+	})
+	if !errors.Is(err, errBroken) {
+		t.Fatalf("insert error = %v, want %v", err, errBroken)
+	}
+
+	if got := drivertest.QueryInt(t, d, "SELECT COUNT(*) FROM t", nil); got != 1000 {
+		t.Fatalf("rows = %d, want the 1000 of the first transaction only", got)
+	}
+}
+
+func TestInsertRejectsColumnar(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDriver(t, "")
+
+	_, err := d.Insert(context.Background(), &driver.InsertRequest{
+		Table: "t", Method: driver.InsertColumnar, Workers: 1, Source: drivertest.SeqSource(1),
+	})
+	if !errors.Is(err, driver.ErrInsertMethodNotSupported) {
+		t.Fatalf("columnar insert error = %v, want %v", err, driver.ErrInsertMethodNotSupported)
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	d := newTestDriver(t, "")
+
+	drivertest.Exec(t, d, "CREATE TABLE acc (id INTEGER PRIMARY KEY, balance INTEGER)", nil)
+	drivertest.Exec(t, d, "INSERT INTO acc VALUES (:id, :balance)", map[string]any{"id": 1, "balance": 100})
+
+	for _, iso := range []stroppy.TxIsolationLevel{
+		stroppy.TxIsolationLevel_READ_COMMITTED,
+		stroppy.TxIsolationLevel_SERIALIZABLE,
+		stroppy.TxIsolationLevel_CONNECTION_ONLY,
+	} {
+		tx, err := d.Begin(ctx, iso)
+		if err != nil {
+			t.Fatalf("%s: begin: %v", iso, err)
+		}
+
+		if tx.Isolation() != iso {
+			t.Fatalf("tx isolation = %s, want %s", tx.Isolation(), iso)
+		}
+
+		res, err := tx.RunQuery(ctx, "UPDATE acc SET balance = balance + 1 WHERE id = 1", nil)
+		if err != nil {
+			t.Fatalf("%s: update: %v", iso, err)
+		}
+
+		_ = res.Rows.Close()
+
+		if err := tx.Commit(ctx); err != nil {
+			t.Fatalf("%s: commit: %v", iso, err)
+		}
+	}
+
+	tx, err := d.Begin(ctx, stroppy.TxIsolationLevel_SERIALIZABLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := tx.RunQuery(ctx, "UPDATE acc SET balance = 0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = res.Rows.Close()
+
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := drivertest.QueryInt(t, d, "SELECT balance FROM acc WHERE id = 1", nil); got != 103 {
+		t.Fatalf("balance = %d, want the three commits and not the rollback", got)
+	}
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"errors"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+// primaryCode masks an extended result code down to its primary code
+// (SQLITE_BUSY_RECOVERY -> SQLITE_BUSY, ...).
+const primaryCode = 0xff
+
+func (*Driver) ClassifyError(err error) driver.ErrorFacts {
+	if liteErr, ok := errors.AsType[*sqlite.Error](err); ok {
+		code := liteErr.Code()
+
+		switch {
+		case code == sqlite3.SQLITE_BUSY_SNAPSHOT:
+			// A read transaction tried to write past a newer commit; only a
+			// restart sees the new snapshot.
+			return driver.ErrorFacts{Kind: driver.ErrorKindSerialization}
+		case code&primaryCode == sqlite3.SQLITE_BUSY, code&primaryCode == sqlite3.SQLITE_LOCKED:
+			// busy_timeout already waited; back off before trying again.
+			return driver.ErrorFacts{Kind: driver.ErrorKindLockTimeout, Backoff: true}
+		}
+	}
+
+	return driver.DefaultErrorFacts(err)
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/pkg/driver"
+)
+
+// openRaw opens path with the driver defaults plus extra DSN parameters.
+func openRaw(t *testing.T, path, extra string) *sql.DB {
+	t.Helper()
+
+	dsn, _, err := buildDSN(path + "?" + extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// sqliteErrors provokes the lock errors ClassifyError maps, on a real database.
+func sqliteErrors(t *testing.T) map[string]error {
+	t.Helper()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "locks.db")
+
+	writer := openRaw(t, path, "")
+	if _, err := writer.ExecContext(ctx, "CREATE TABLE t (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := map[string]error{}
+
+	// busy: another connection holds the write lock and busy_timeout is 0.
+	holder, err := writer.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	impatient := openRaw(t, path, "_pragma=busy_timeout(0)")
+	_, errs["busy"] = impatient.ExecContext(ctx, "INSERT INTO t VALUES (1)")
+
+	if err := holder.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// busy_snapshot: a deferred read transaction tries to write after another
+	// connection committed past its snapshot.
+	reader := openRaw(t, path, "_txlock=deferred")
+
+	stale, err := reader.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err := stale.QueryRowContext(ctx, "SELECT COUNT(*) FROM t").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writer.ExecContext(ctx, "INSERT INTO t VALUES (2)"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, errs["busy_snapshot"] = stale.ExecContext(ctx, "INSERT INTO t VALUES (3)")
+	_ = stale.Rollback()
+
+	// locked: a connection drops a table its own open cursor still reads.
+	conn, err := writer.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, "SELECT id FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	rows.Next()
+
+	_, errs["locked"] = conn.ExecContext(ctx, "DROP TABLE t")
+
+	return errs
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	provoked := sqliteErrors(t)
+
+	tests := []struct {
+		name string
+		err  error
+		want driver.ErrorKind
+	}{
+		{name: "busy", err: provoked["busy"], want: driver.ErrorKindLockTimeout},
+		{name: "wrapped busy", err: fmt.Errorf("query: %w", provoked["busy"]), want: driver.ErrorKindLockTimeout},
+		{name: "busy snapshot", err: provoked["busy_snapshot"], want: driver.ErrorKindSerialization},
+		{name: "locked", err: provoked["locked"], want: driver.ErrorKindLockTimeout},
+		{name: "canceled", err: context.Canceled, want: driver.ErrorKindCanceled},
+		{name: "other", err: errors.New("boom"), want: driver.ErrorKindUnknown},
+	}
+
+	for _, tt := range tests {
+		if tt.err == nil {
+			t.Fatalf("%s: no error provoked", tt.name)
+		}
+
+		if got := (*Driver)(nil).ClassifyError(tt.err).Kind; got != tt.want {
+			t.Fatalf("%s: ClassifyError(%v).Kind = %q, want %q", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/datagen/source"
+	"github.com/stroppy-io/stroppy/pkg/driver"
+	"github.com/stroppy-io/stroppy/pkg/driver/common"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver"
+	"github.com/stroppy-io/stroppy/pkg/driver/stats"
+	"github.com/stroppy-io/stroppy/pkg/gen"
+)
+
+// maxVariables is SQLite's default SQLITE_MAX_VARIABLE_NUMBER, the bound
+// parameters one statement may carry; below the 65535 sqldriver clamps to.
+const maxVariables = 32766
+
+// Insert runs a typed [driver.InsertRequest] through the sqlite driver.
+// Each worker drains its [gen.Cursor] partition through prepared (NATIVE) or
+// multi-row INSERTs; concurrent workers serialize on the database write lock.
+func (d *Driver) Insert(
+	ctx context.Context,
+	req *driver.InsertRequest,
+) (*stats.Query, error) {
+	if err := driver.ValidateInsert(req); err != nil {
+		return nil, err
+	}
+
+	if !sqliteMethodSupported(req.Method) {
+		return nil, fmt.Errorf("%w: %s", driver.ErrInsertMethodNotSupported, req.Method)
+	}
+
+	workers := req.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	columns := req.Source.Schema().ColumnNames()
+	start := time.Now()
+
+	rows, err := common.RunParallelBatch(ctx, req.Source, workers, d.bulkSize,
+		func(workerCtx context.Context, _ common.Chunk, cur gen.Cursor) error {
+			src := common.NewBatchRowSource(cur, columns, len(columns))
+
+			return d.runInsertChunk(workerCtx, req.Table, req.Method, src)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats.Query{Elapsed: time.Since(start), Rows: rows}, nil
+}
+
+// sqliteMethodSupported reports whether the sqlite driver serves method.
+// COLUMNAR is not served (sqlite has no array-unnest primitive).
+func sqliteMethodSupported(method driver.InsertMethod) bool {
+	switch method {
+	case driver.InsertNative, driver.InsertPlainBulk, driver.InsertPlainQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// runInsertChunk drains one partition into sqlite per method. NATIVE runs a
+// prepared single-row INSERT inside transactions of bulkSize rows, the
+// bulk-load path SQLite itself recommends; PLAIN_BULK issues multi-row
+// INSERTs and PLAIN_QUERY is the same path with batchSize=1.
+func (d *Driver) runInsertChunk(
+	ctx context.Context,
+	table string,
+	method driver.InsertMethod,
+	src source.RowSource,
+) error {
+	switch method {
+	case driver.InsertNative:
+		return d.runPreparedInsert(ctx, table, src, d.bulkSize)
+	case driver.InsertPlainBulk:
+		batchSize := d.bulkSize
+		if columns := len(src.Columns()); columns > 0 {
+			batchSize = max(min(batchSize, maxVariables/columns), 1)
+		}
+
+		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, batchSize, d.queryTimeout)
+	case driver.InsertPlainQuery:
+		return sqldriver.RunBulkInsert(ctx, d.db, table, src, d.dialect, 1, d.queryTimeout)
+	default:
+		return fmt.Errorf("%w: %s", driver.ErrInsertMethodNotSupported, method)
+	}
+}
@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stroppy-io/stroppy/pkg/datagen/source"
+	"github.com/stroppy-io/stroppy/pkg/driver/insertprogress"
+	"github.com/stroppy-io/stroppy/pkg/driver/sqldriver"
+)
+
+// runPreparedInsert drains src into table through one prepared single-row
+// INSERT, executed row by row inside transactions of up to batchSize rows.
+// This is SQLite's own bulk-load path: the statement is compiled once per
+// transaction, no row is re-parsed, and no bind-parameter cap limits how
+// many rows a transaction commits. A transaction that fails, including on a
+// source error, rolls back whole; the transactions committed before it stay.
+func (d *Driver) runPreparedInsert(ctx context.Context, table string, src source.RowSource, batchSize int) error {
+	columns := src.Columns()
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: table %q", sqldriver.ErrEmptyColumnOrder, table)
+	}
+
+	query := buildPreparedInsertQuery(table, columns)
+	args := make([]any, len(columns))
+
+	progress := insertprogress.NewGeneratedRowCounter(ctx)
+	defer progress.Flush()
+
+	for {
+		if err := insertprogress.Canceled(ctx); err != nil {
+			return err
+		}
+
+		more, err := d.execPreparedBatch(ctx, table, query, src, args, max(batchSize, 1), &progress)
+		if err != nil || !more {
+			return err
+		}
+	}
+}
+
+// execPreparedBatch inserts up to batchSize rows of src in one transaction
+// and reports whether src has rows left.
+func (d *Driver) execPreparedBatch(
+	ctx context.Context,
+	table, query string,
+	src source.RowSource,
+	args []any,
+	batchSize int,
+	progress *insertprogress.RowCounter,
+) (bool, error) {
+	txCtx, cancel := sqldriver.StatementTimeout(ctx, d.queryTimeout)
+	defer cancel()
+
+	insertprogress.SetStage(ctx, insertprogress.StageSQLitePreparedInsert)
+
+	start := time.Now()
+
+	tx, err := d.db.BeginTx(txCtx, nil)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: begin insert %q: %w", table, err)
+	}
+
+	// Rolls back an uncommitted transaction; a no-op after Commit.
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(txCtx, query)
+	if err != nil {
+		return false, fmt.Errorf("sqlite: prepare insert %q: %w", table, err)
+	}
+	defer stmt.Close()
+
+	rows, more, err := execPreparedRows(txCtx, stmt, src, args, batchSize, d.dialect.Convert, progress)
+	progress.Flush()
+
+	if err != nil {
+		return false, fmt.Errorf("sqlite: insert %q: %w", table, err)
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("sqlite: commit insert %q: %w", table, err)
+	}
+
+	insertprogress.AddConfirmed(ctx, rows)
+	insertprogress.AddBatch(ctx, rows, time.Since(start))
+
+	return more, nil
+}
+
+// execPreparedRows runs stmt once per row of src, up to limit rows, and
+// returns the rows run and whether src has rows left.
+func execPreparedRows(
+	ctx context.Context,
+	stmt *sql.Stmt,
+	src source.RowSource,
+	args []any,
+	limit int,
+	convert func(any) (any, error),
+	progress *insertprogress.RowCounter,
+) (int64, bool, error) {
+	var rows int64
+
+	for rows < int64(limit) {
+		row, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, false, nil
+		}
+
+		if err != nil {
+			return rows, false, fmt.Errorf("source.Next: %w", err)
+		}
+
+		for i, v := range row {
+			if args[i], err = convert(v); err != nil {
+				return rows, false, fmt.Errorf("convert column %d: %w", i, err)
+			}
+		}
+
+		progress.Add(1)
+
+		if _, err := stmt.ExecContext(ctx, args...); err != nil {
+			return rows, false, err
+		}
+
+		rows++
+	}
+
+	return rows, true, nil
+}
+
+// buildPreparedInsertQuery returns the single-row INSERT the native path
+// prepares. Identifiers pass through unquoted, as in the bulk path.
+func buildPreparedInsertQuery(table string, columns []string) string {
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" +
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+}
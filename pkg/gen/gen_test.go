@@ -338,3 +338,36 @@ func TestAllocsMultiDraw(t *testing.T) {
 		t.Fatalf("multi-draw allocs = %v, want 0", n)
 	}
 }
+
+func TestNURandRangeAndSkew(t *testing.T) {
+	f := gen.New(7).Domain("nurand@1").Field("c_id")
+	counts := map[int64]int{}
+
+	for i := range uint64(100_000) {
+		v := f.NURand(i, 1023, 259, 1, 3000)
+		if v < 1 || v > 3000 {
+			t.Fatalf("NURand = %d outside [1, 3000]", v)
+		}
+
+		counts[v]++
+	}
+
+	if f.NURand(3, 1023, 259, 1, 3000) != f.NURand(3, 1023, 259, 1, 3000) {
+		t.Fatal("NURand is not a pure function of the entity")
+	}
+
+	// The OR with a [0, 1023] draw makes some ids several times likelier than
+	// a uniform draw's 100000/3000 ~ 33 hits.
+	hottest := 0
+	for _, n := range counts {
+		hottest = max(hottest, n)
+	}
+
+	if hottest < 100 {
+		t.Fatalf("hottest id drawn %d times, want a skewed distribution", hottest)
+	}
+
+	if got := f.NURand(0, 255, 0, 5, 5); got != 5 {
+		t.Fatalf("NURand over [5, 5] = %d", got)
+	}
+}
@@ -93,6 +93,24 @@ func (f Field) Chance(entity uint64, p float64) bool {
 	return f.Float64(entity) < p
 }
 
+// NURand returns the TPC-C §2.1.6 non-uniform random value for entity:
+//
+//	(((uniform[0, a] | uniform[lo, hi]) + c) % (hi - lo + 1)) + lo
+//
+// The two uniform draws take consecutive sub-draws of the entity. a must be
+// non-negative; hi <= lo yields lo.
+func (f Field) NURand(entity uint64, a, c, lo, hi int64) int64 {
+	if hi <= lo {
+		return lo
+	}
+
+	d := f.At(entity)
+	x := int64(d.uniform(uint64(a) + 1))        //nolint:gosec // a+1 and the draw fit in int64
+	y := lo + int64(d.uniform(uint64(hi-lo)+1)) //nolint:gosec // the draw is below hi-lo+1
+
+	return ((x|y)+c)%(hi-lo+1) + lo
+}
+
 // uniform maps a raw 64-bit output at (entity, sub) into [0, n) exactly. It
 // uses Daniel Lemire's multiply-high method: the candidate is the high 64
 // bits of x*n, and a draw is accepted when the low 64 bits are at least the
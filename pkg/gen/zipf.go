@@ -0,0 +1,77 @@
+package gen
+
+import "math"
+
+// Zipfian maps uniform draws in [0, 1) to item numbers in [0, items) whose
+// popularity falls off as 1/rank^theta, item 0 the most popular. It is Gray
+// et al.'s "Quickly generating billion-record synthetic databases" generator
+// as ported by YCSB. It holds no random state, so one value is shared
+// read-only across goroutines; feed it Field.Float64 draws for a
+// deterministic stream.
+type Zipfian struct {
+	items uint64
+	theta float64
+	alpha float64
+	zeta2 float64
+	zetan float64
+	eta   float64
+}
+
+// NewZipfian computes zeta(items) from scratch, which is O(items).
+func NewZipfian(items uint64, theta float64) Zipfian {
+	return NewZipfianWithZeta(items, theta, zeta(0, items, theta, 0))
+}
+
+// NewZipfianWithZeta skips the zeta computation when it is known.
+func NewZipfianWithZeta(items uint64, theta, zetan float64) Zipfian {
+	z := Zipfian{
+		items: items,
+		theta: theta,
+		alpha: 1 / (1 - theta),
+		zeta2: zeta(0, 2, theta, 0),
+		zetan: zetan,
+	}
+	z.eta = z.etaFor()
+
+	return z
+}
+
+// zeta sums 1/i^theta for i in (from, to], continuing from initial.
+func zeta(from, to uint64, theta, initial float64) float64 {
+	sum := initial
+	for i := from; i < to; i++ {
+		sum += 1 / math.Pow(float64(i+1), theta)
+	}
+
+	return sum
+}
+
+func (z *Zipfian) etaFor() float64 {
+	return (1 - math.Pow(2/float64(z.items), 1-z.theta)) / (1 - z.zeta2/z.zetan)
+}
+
+// Grow extends the item range to items, adding only the new zeta terms.
+// YCSB's latest distribution grows its zipfian this way as inserts land.
+func (z *Zipfian) Grow(items uint64) {
+	if items <= z.items {
+		return
+	}
+
+	z.zetan = zeta(z.items, items, z.theta, z.zetan)
+	z.items = items
+	z.eta = z.etaFor()
+}
+
+// Next maps the uniform draw u to an item number.
+func (z *Zipfian) Next(u float64) uint64 {
+	uz := u * z.zetan
+	if uz < 1 {
+		return 0
+	}
+
+	if uz < 1+math.Pow(0.5, z.theta) {
+		return 1
+	}
+
+	return min(uint64(float64(z.items)*math.Pow(z.eta*u-z.eta+1, z.alpha)), z.items-1)
+}
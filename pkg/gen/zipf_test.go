@@ -0,0 +1,43 @@
+package gen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestZipfianSkew(t *testing.T) {
+	const items, draws = 1000, 100_000
+
+	z := NewZipfian(items, 0.99)
+	f := New(1).Domain("zipf@1").Field("u")
+	counts := make([]int, items)
+
+	for i := range uint64(draws) {
+		n := z.Next(f.Float64(i))
+		if n >= items {
+			t.Fatalf("draw %d outside [0, %d)", n, items)
+		}
+
+		counts[n]++
+	}
+
+	// P(rank 1) = 1/zeta(1000, 0.99) ~ 0.134; the tail is far rarer.
+	if share := float64(counts[0]) / draws; math.Abs(share-1/z.zetan) > 0.01 {
+		t.Fatalf("item 0 share = %.3f, want ~%.3f", share, 1/z.zetan)
+	}
+
+	if counts[0] <= counts[1] || counts[1] <= counts[items/2] {
+		t.Fatalf("counts not skewed: [0]=%d [1]=%d [%d]=%d", counts[0], counts[1], items/2, counts[items/2])
+	}
+}
+
+func TestZipfianGrowMatchesFresh(t *testing.T) {
+	grown := NewZipfian(1000, 0.99)
+	grown.Grow(1500)
+	grown.Grow(1200) // shrinking is ignored
+
+	fresh := NewZipfian(1500, 0.99)
+	if grown.items != fresh.items || math.Abs(grown.zetan-fresh.zetan) > 1e-9 || math.Abs(grown.eta-fresh.eta) > 1e-9 {
+		t.Fatalf("grown = %+v, fresh = %+v", grown, fresh)
+	}
+}
@@ -140,3 +140,61 @@ func RegionCount() int64 { return int64(regions.count) }
 // Money converts an integer-cents money value to a float dollar amount, the
 // way upstream FmtMoney renders it (value/100).
 func Money(cents int64) float64 { return float64(cents) / 100.0 }
+
+// --- refresh functions (update sets) ---
+//
+// Upstream dbgen -U emits numbered update sets: set n inserts UpdateOrderCount
+// new orders (RF1) and deletes as many loaded ones (RF2). New orders continue
+// the order sequence past the loaded rows, as upstream mk_order does for
+// updates, so their keys sit above every loaded key. Deletes walk the loaded
+// orders from the start, one set after another.
+
+// updPct is upstream UPD_PCT: one update set touches 0.1% of ORDERS.
+const updPct = 10
+
+// UpdateOrderCount returns how many orders one update set inserts and deletes
+// at scale sf: SF × 1500. Below SF 0.00067 upstream rounds to zero; the count
+// is kept at one so a tiny run still exercises the refresh path.
+func UpdateOrderCount(sf float64) int64 {
+	return int64(max(1, dssHuge(float64(tDefs[TOrder].base)/10000*sf*updPct)))
+}
+
+// SeekUpdateSet positions the order and line streams at the first new order
+// of update set `set` (1-based).
+func (g *Generator) SeekUpdateSet(set int) {
+	g.SeekOrderLine(updateInsertRow(set, 1) - 1)
+}
+
+// MakeUpdateOrder returns new order idx (1-based) of update set `set`, as RF1
+// inserts it. Call SeekUpdateSet(set) first and bracket each call with
+// RowStart/RowStop(TOrder), as for MakeOrder.
+func (g *Generator) MakeUpdateOrder(set int, idx int64) *Order {
+	row := dssHuge(updateInsertRow(set, idx))
+	order := g.makeOrder(row)
+
+	// Upstream mk_sparse puts set/1000 in the sequence bits, so sets past the
+	// thousandth cannot reuse a key of an earlier one.
+	if seq := dssHuge(set / (10000 / updPct)); seq != 0 {
+		order.OKey = makeSparseSeq(row, seq)
+		for i := range order.Lines {
+			order.Lines[i].OKey = order.OKey
+		}
+	}
+
+	return order
+}
+
+// UpdateDeleteKey returns order key idx (1-based) of update set `set` that RF2
+// deletes.
+func UpdateDeleteKey(set int, idx int64) int64 {
+	return int64(makeSparse(dssHuge(int64(set-1)*UpdateOrderCount(scale) + idx)))
+}
+
+func updateInsertRow(set int, idx int64) int64 {
+	return int64(float64(tDefs[TOrder].base)*scale) + int64(set-1)*UpdateOrderCount(scale) + idx
+}
+
+// makeSparseSeq is upstream mk_sparse with a non-zero sequence number.
+func makeSparseSeq(idx, seq dssHuge) dssHuge {
+	return ((((idx >> 3) << 2) | (seq & 0x0003)) << 3) | (idx & 0x0007)
+}
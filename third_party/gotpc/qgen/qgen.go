@@ -0,0 +1,189 @@
+// Package qgen is a lean port of the TPC-H query generator (qgen). Like the
+// TPC-DS dsqgen port it does NOT reproduce qgen's exact RNG stream: it draws
+// each query's substitution parameters from the §2.4 domains with its own
+// seeded RNG, using the dbgen distributions (nations, regions, colors, part
+// types, containers, segments, ship modes) so every value names real data.
+// A (seed, stream) pair always yields the same parameters.
+//
+// Parameters are positional, in the order qgen substitutes :1, :2, … into the
+// query template; the comment on each draw below names them.
+package qgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/stroppy-io/stroppy/third_party/gotpc/dbgen/dist"
+)
+
+// QueryCount is the number of TPC-H queries.
+const QueryCount = 22
+
+// Params are one query's substitution parameters, in :1..:n order.
+type Params []any
+
+// regionOfNation is n_regionkey for each entry of the nations distribution
+// (dists.dss stores it delta-encoded in the weights, which only dbgen reads).
+var regionOfNation = map[string]string{
+	"ALGERIA": "AFRICA", "ARGENTINA": "AMERICA", "BRAZIL": "AMERICA", "CANADA": "AMERICA",
+	"EGYPT": "MIDDLE EAST", "ETHIOPIA": "AFRICA", "FRANCE": "EUROPE", "GERMANY": "EUROPE",
+	"INDIA": "ASIA", "INDONESIA": "ASIA", "IRAN": "MIDDLE EAST", "IRAQ": "MIDDLE EAST",
+	"JAPAN": "ASIA", "JORDAN": "MIDDLE EAST", "KENYA": "AFRICA", "MOROCCO": "AFRICA",
+	"MOZAMBIQUE": "AFRICA", "PERU": "AMERICA", "CHINA": "ASIA", "ROMANIA": "EUROPE",
+	"SAUDI ARABIA": "MIDDLE EAST", "VIETNAM": "ASIA", "RUSSIA": "EUROPE",
+	"UNITED KINGDOM": "EUROPE", "UNITED STATES": "AMERICA",
+}
+
+// Stream returns the substitution parameters of Q1..Q22 (index 0 is Q1) for
+// one query stream at scale sf.
+func Stream(sf float64, seed int64, stream int) [QueryCount]Params {
+	g := &generator{rng: rand.New(rand.NewPCG(uint64(seed), uint64(stream)))}
+
+	var params [QueryCount]Params
+
+	for i := range params {
+		params[i] = g.query(i+1, sf)
+	}
+
+	return params
+}
+
+type generator struct{ rng *rand.Rand }
+
+// query draws the parameters of query q from its §2.4.q.3 domains.
+func (g *generator) query(q int, sf float64) Params {
+	switch q {
+	case 1: // DELTA
+		return Params{g.between(60, 120)}
+	case 2: // SIZE, TYPE (third part-type syllable), REGION
+		return Params{g.between(1, 50), g.syllable("p_types", 2), g.pick("regions")}
+	case 3: // SEGMENT, DATE
+		return Params{g.pick("msegmnt"), g.day("1995-03-01", 31)}
+	case 4: // DATE
+		return Params{g.month(1993, 1, 58)}
+	case 5: // REGION, DATE
+		return Params{g.pick("regions"), g.year()}
+	case 6: // DATE, DISCOUNT, QUANTITY
+		return Params{g.year(), float64(g.between(2, 9)) / 100, g.between(24, 25)}
+	case 7: // NATION1, NATION2
+		nations := g.distinct("nations", 2)
+
+		return Params{nations[0], nations[1]}
+	case 8: // NATION, REGION (the nation's), TYPE
+		nation := g.pick("nations")
+
+		return Params{nation, regionOfNation[nation], g.pick("p_types")}
+	case 9: // COLOR
+		return Params{g.pick("colors")}
+	case 10: // DATE
+		return Params{g.month(1993, 2, 24)}
+	case 11: // NATION, FRACTION
+		return Params{g.pick("nations"), 0.0001 / sf}
+	case 12: // SHIPMODE1, SHIPMODE2, DATE
+		modes := g.distinct("smode", 2)
+
+		return Params{modes[0], modes[1], g.year()}
+	case 13: // WORD1, WORD2
+		return Params{g.pick("Q13a"), g.pick("Q13b")}
+	case 14: // DATE
+		return Params{g.month(1993, 1, 60)}
+	case 15: // DATE
+		return Params{g.month(1993, 1, 58)}
+	case 16: // BRAND, TYPE (first two syllables), SIZE1..SIZE8
+		params := Params{g.brand(), g.typePrefix()}
+		for _, size := range g.sizes(8) {
+			params = append(params, size)
+		}
+
+		return params
+	case 17: // BRAND, CONTAINER
+		return Params{g.brand(), g.pick("p_cntr")}
+	case 18: // QUANTITY
+		return Params{g.between(312, 315)}
+	case 19: // QUANTITY1..3, BRAND1..3
+		return Params{
+			g.between(1, 10), g.between(10, 20), g.between(20, 30),
+			g.brand(), g.brand(), g.brand(),
+		}
+	case 20: // COLOR, DATE, NATION
+		return Params{g.pick("colors"), g.year(), g.pick("nations")}
+	case 21: // NATION
+		return Params{g.pick("nations")}
+	case 22: // I1..I7: distinct country codes
+		params := make(Params, 0, 7)
+		for _, code := range g.rng.Perm(25)[:7] {
+			params = append(params, fmt.Sprintf("%d", code+10))
+		}
+
+		return params
+	default:
+		return nil
+	}
+}
+
+func (g *generator) between(lo, hi int) int { return lo + g.rng.IntN(hi-lo+1) }
+
+func (g *generator) pick(name string) string {
+	items := dist.Maps[name]
+
+	return items[g.rng.IntN(len(items))].Text
+}
+
+func (g *generator) distinct(name string, n int) []string {
+	items := dist.Maps[name]
+	picked := make([]string, n)
+
+	for i, index := range g.rng.Perm(len(items))[:n] {
+		picked[i] = items[index].Text
+	}
+
+	return picked
+}
+
+// syllable returns word `index` of a random entry of a multi-word
+// distribution such as p_types ("STANDARD ANODIZED TIN").
+func (g *generator) syllable(name string, index int) string {
+	return strings.Fields(g.pick(name))[index]
+}
+
+// typePrefix is the first two syllables of a part type, "MEDIUM POLISHED".
+func (g *generator) typePrefix() string {
+	words := strings.Fields(g.pick("p_types"))
+
+	return words[0] + " " + words[1]
+}
+
+// brand is Brand#MN with M and N in [1, 5].
+func (g *generator) brand() string {
+	return fmt.Sprintf("Brand#%d%d", g.between(1, 5), g.between(1, 5))
+}
+
+func (g *generator) sizes(n int) []int {
+	sizes := make([]int, n)
+	for i, size := range g.rng.Perm(50)[:n] {
+		sizes[i] = size + 1
+	}
+
+	return sizes
+}
+
+// day returns a date within `days` days from `first`.
+func (g *generator) day(first string, days int) string {
+	start, _ := time.Parse(time.DateOnly, first)
+
+	return start.AddDate(0, 0, g.rng.IntN(days)).Format(time.DateOnly)
+}
+
+// month returns the first day of one of `months` months starting at year/month.
+func (g *generator) month(year, month, months int) string {
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+	return start.AddDate(0, g.rng.IntN(months), 0).Format(time.DateOnly)
+}
+
+// year returns January 1 of a year in [1993, 1997].
+func (g *generator) year() string {
+	return time.Date(g.between(1993, 1997), time.January, 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+}
@@ -0,0 +1,70 @@
+package qgen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stroppy-io/stroppy/third_party/gotpc/dbgen/dist"
+)
+
+func TestStreamIsReproducible(t *testing.T) {
+	if !reflect.DeepEqual(Stream(1, 7, 3), Stream(1, 7, 3)) {
+		t.Fatal("the same seed and stream must give the same parameters")
+	}
+
+	if reflect.DeepEqual(Stream(1, 7, 3), Stream(1, 7, 4)) {
+		t.Fatal("different streams should draw different parameters")
+	}
+}
+
+func TestStreamDomains(t *testing.T) {
+	for seed := range int64(200) {
+		params := Stream(10, seed, 0)
+
+		if delta := params[0][0].(int); delta < 60 || delta > 120 {
+			t.Fatalf("Q1 DELTA = %d, want [60, 120]", delta)
+		}
+
+		if params[6][0] == params[6][1] {
+			t.Fatalf("Q7 nations must differ, got %v", params[6])
+		}
+
+		if region := regionOfNation[params[7][0].(string)]; region == "" || region != params[7][1] {
+			t.Fatalf("Q8 region %v does not match nation %v", params[7][1], params[7][0])
+		}
+
+		if fraction := params[10][1].(float64); fraction != 0.00001 {
+			t.Fatalf("Q11 FRACTION = %v, want 0.0001 / SF", fraction)
+		}
+
+		sizes := map[any]bool{}
+		for _, size := range params[15][2:] {
+			if s := size.(int); s < 1 || s > 50 || sizes[size] {
+				t.Fatalf("Q16 sizes %v must be 8 distinct values in [1, 50]", params[15][2:])
+			}
+
+			sizes[size] = true
+		}
+
+		if date := params[14][0].(string); date < "1993-01-01" || date > "1997-10-01" || date[8:] != "01" {
+			t.Fatalf("Q15 DATE = %s, want the first of a month in 1993-01..1997-10", date)
+		}
+
+		codes := map[any]bool{}
+		for _, code := range params[21] {
+			if c := code.(string); c < "10" || c > "34" || codes[code] {
+				t.Fatalf("Q22 codes %v must be 7 distinct values in 10..34", params[21])
+			}
+
+			codes[code] = true
+		}
+	}
+}
+
+func TestEveryNationHasARegion(t *testing.T) {
+	for _, nation := range dist.Maps["nations"] {
+		if regionOfNation[nation.Text] == "" {
+			t.Fatalf("no region for %s", nation.Text)
+		}
+	}
+}
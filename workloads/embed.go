@@ -14,10 +14,12 @@ import (
 type Preset string
 
 const (
-	PresetTPCC  Preset = "tpcc"
-	PresetTPCB  Preset = "tpcb"
-	PresetTPCDS Preset = "tpcds"
-	PresetTPCH  Preset = "tpch"
+	PresetTPCC     Preset = "tpcc"
+	PresetTPCB     Preset = "tpcb"
+	PresetTPCDS    Preset = "tpcds"
+	PresetTPCH     Preset = "tpch"
+	PresetYCSB     Preset = "ycsb"
+	PresetSysbench Preset = "sysbench"
 )
 
 // ErrUnknownPreset is returned when an unknown preset name is requested.
@@ -33,6 +35,8 @@ func AvailablePresets() []string {
 		string(PresetTPCB),
 		string(PresetTPCDS),
 		string(PresetTPCH),
+		string(PresetYCSB),
+		string(PresetSysbench),
 	}
 }
 
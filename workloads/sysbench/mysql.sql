@@ -0,0 +1,55 @@
+-- {table} is replaced with sbtest1..sbtestN and {n} with the table number
+-- before a statement runs; every schema section runs once per table.
+
+
+--+ drop_schema
+--= sbtest
+DROP TABLE IF EXISTS {table}
+
+
+--+ create_schema
+--= sbtest
+CREATE TABLE {table} (
+    id INT NOT NULL,
+    k INT DEFAULT '0' NOT NULL,
+    c CHAR(120) DEFAULT '' NOT NULL,
+    pad CHAR(60) DEFAULT '' NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE=InnoDB
+
+
+--+ create_indexes
+-- Built after the bulk load, like sysbench's --create_secondary index.
+--= k
+CREATE INDEX k_{n} ON {table} (k)
+
+
+--+ analyze
+--=
+ANALYZE TABLE {table}
+
+
+--+ oltp
+-- One statement per sysbench oltp_common.lua prepared statement. The
+-- measured iteration picks a table per statement group, as sysbench does.
+-- max_id finds the largest id, past which oltp_insert adds rows.
+--= point_select
+SELECT c FROM {table} WHERE id = :id
+--= simple_range
+SELECT c FROM {table} WHERE id BETWEEN :start_id AND :end_id
+--= sum_range
+SELECT SUM(k) FROM {table} WHERE id BETWEEN :start_id AND :end_id
+--= order_range
+SELECT c FROM {table} WHERE id BETWEEN :start_id AND :end_id ORDER BY c
+--= distinct_range
+SELECT DISTINCT c FROM {table} WHERE id BETWEEN :start_id AND :end_id ORDER BY c
+--= index_update
+UPDATE {table} SET k = k + 1 WHERE id = :id
+--= non_index_update
+UPDATE {table} SET c = :c WHERE id = :id
+--= delete
+DELETE FROM {table} WHERE id = :id
+--= insert
+INSERT INTO {table} (id, k, c, pad) VALUES (:id, :k, :c, :pad)
+--= max_id
+SELECT MAX(id) FROM {table}
@@ -0,0 +1,71 @@
+--+ drop_schema
+--=
+DROP TABLE IF EXISTS pgbench_history
+--=
+DROP TABLE IF EXISTS pgbench_accounts
+--=
+DROP TABLE IF EXISTS pgbench_tellers
+--=
+DROP TABLE IF EXISTS pgbench_branches
+
+
+--+ create_schema
+--=
+CREATE TABLE pgbench_branches (
+    bid INTEGER NOT NULL PRIMARY KEY,
+    bbalance INTEGER,
+    filler CHAR(88)
+)
+--=
+CREATE TABLE pgbench_tellers (
+    tid INTEGER NOT NULL PRIMARY KEY,
+    bid INTEGER,
+    tbalance INTEGER,
+    filler CHAR(84)
+)
+--=
+CREATE TABLE pgbench_accounts (
+    aid INTEGER NOT NULL PRIMARY KEY,
+    bid INTEGER,
+    abalance INTEGER,
+    filler CHAR(84)
+)
+--=
+CREATE TABLE pgbench_history (
+    hid BIGINT NOT NULL PRIMARY KEY,
+    tid INTEGER,
+    bid INTEGER,
+    aid INTEGER,
+    delta INTEGER,
+    mtime DATETIME,
+    filler CHAR(22)
+)
+
+
+--+ create_indexes
+-- Built AFTER the bulk load: a one-shot index build is far cheaper than
+-- maintaining the index per row during population. SQLite cannot add foreign
+-- keys to existing tables, so there is no create_foreign_keys section.
+--= accounts_bid
+CREATE INDEX pgbench_accounts_bid_idx ON pgbench_accounts (bid)
+--= tellers_bid
+CREATE INDEX pgbench_tellers_bid_idx ON pgbench_tellers (bid)
+
+
+--+ analyze
+-- Refresh planner statistics after the bulk load.
+--=
+ANALYZE
+
+
+--+ workload_tx_tpcb
+--= update_account
+UPDATE pgbench_accounts SET abalance = abalance + :delta WHERE aid = :aid
+--= get_balance
+SELECT abalance FROM pgbench_accounts WHERE aid = :aid
+--= update_teller
+UPDATE pgbench_tellers SET tbalance = tbalance + :delta WHERE tid = :tid
+--= update_branch
+UPDATE pgbench_branches SET bbalance = bbalance + :delta WHERE bid = :bid
+--= insert_history
+INSERT INTO pgbench_history (hid, tid, bid, aid, delta, mtime, filler) VALUES (:hid, :tid, :bid, :aid, :delta, CURRENT_TIMESTAMP, '')
@@ -4885,3 +4885,34 @@ order by substr(w_warehouse_name,1,20)
         ,cc_name
 limit 100
 ;
+
+-- Data maintenance (spec §5.3). The LF_* inserts are bulk loads of the
+-- tpcdsgen update-set rows; the DF_* deletes below remove one sold-date window
+-- of a sales channel, returns first (matched by ticket/order number).
+--+ df_ss
+--= delete_returns
+DELETE FROM store_returns
+WHERE sr_ticket_number IN (
+    SELECT ss_ticket_number FROM store_sales
+    WHERE ss_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM store_sales WHERE ss_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
+--+ df_cs
+--= delete_returns
+DELETE FROM catalog_returns
+WHERE cr_order_number IN (
+    SELECT cs_order_number FROM catalog_sales
+    WHERE cs_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM catalog_sales WHERE cs_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
+--+ df_ws
+--= delete_returns
+DELETE FROM web_returns
+WHERE wr_order_number IN (
+    SELECT ws_order_number FROM web_sales
+    WHERE ws_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM web_sales WHERE ws_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
@@ -4489,3 +4489,34 @@ and cs_ship_mode_sk   = sm_ship_mode_sk
 and cs_call_center_sk = cc_call_center_sk
 group by Unicode::Substring(warehouse.w_warehouse_name, CAST(0 AS Uint32), CAST(20 AS Uint32)) AS gk18, ship_mode.sm_type, call_center.cc_name
  order by ((gk18) IS NULL), gk18, ((sm_type) IS NULL), sm_type, ((cc_name) IS NULL), cc_name limit 100;
+
+-- Data maintenance (spec §5.3). The LF_* inserts are bulk loads of the
+-- tpcdsgen update-set rows; the DF_* deletes below remove one sold-date window
+-- of a sales channel, returns first (matched by ticket/order number).
+--+ df_ss
+--= delete_returns
+DELETE FROM store_returns
+WHERE sr_ticket_number IN (
+    SELECT ss_ticket_number FROM store_sales
+    WHERE ss_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM store_sales WHERE ss_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
+--+ df_cs
+--= delete_returns
+DELETE FROM catalog_returns
+WHERE cr_order_number IN (
+    SELECT cs_order_number FROM catalog_sales
+    WHERE cs_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM catalog_sales WHERE cs_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
+--+ df_ws
+--= delete_returns
+DELETE FROM web_returns
+WHERE wr_order_number IN (
+    SELECT ws_order_number FROM web_sales
+    WHERE ws_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk
+);
+--= delete_sales
+DELETE FROM web_sales WHERE ws_sold_date_sk BETWEEN :first_date_sk AND :last_date_sk;
@@ -595,3 +595,37 @@ FROM (
 ) AS custsale
 GROUP  BY cntrycode
 ORDER  BY cntrycode;
+
+-- ==========================================================================
+-- Refresh functions (spec §2.5). RF1 inserts one new order and its lineitems
+-- per transaction; RF2 deletes one old order and its lineitems per
+-- transaction. Parameters are the column names of the dbgen update-set rows.
+-- ==========================================================================
+
+--+ rf1
+--= insert_order
+INSERT INTO orders (
+    o_orderkey, o_custkey, o_orderstatus, o_totalprice, o_orderdate,
+    o_orderpriority, o_clerk, o_shippriority, o_comment
+) VALUES (
+    :o_orderkey, :o_custkey, :o_orderstatus, :o_totalprice, :o_orderdate,
+    :o_orderpriority, :o_clerk, :o_shippriority, :o_comment
+);
+--= insert_lineitem
+INSERT INTO lineitem (
+    l_orderkey, l_partkey, l_suppkey, l_linenumber, l_quantity,
+    l_extendedprice, l_discount, l_tax, l_returnflag, l_linestatus,
+    l_shipdate, l_commitdate, l_receiptdate, l_shipinstruct, l_shipmode,
+    l_comment
+) VALUES (
+    :l_orderkey, :l_partkey, :l_suppkey, :l_linenumber, :l_quantity,
+    :l_extendedprice, :l_discount, :l_tax, :l_returnflag, :l_linestatus,
+    :l_shipdate, :l_commitdate, :l_receiptdate, :l_shipinstruct, :l_shipmode,
+    :l_comment
+);
+
+--+ rf2
+--= delete_lineitem
+DELETE FROM lineitem WHERE l_orderkey = :orderkey;
+--= delete_order
+DELETE FROM orders WHERE o_orderkey = :orderkey;